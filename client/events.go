@@ -0,0 +1,118 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gorilla/websocket"
+
+	webapi_events "github.com/iotaledger/goshimmer/plugins/webapi/events"
+)
+
+const (
+	routeEvents = "events"
+
+	eventBufferSize = 100
+)
+
+// EventFilter restricts the events of a subscription to the given payload types and addresses.
+// Empty fields do not filter anything.
+type EventFilter struct {
+	PayloadTypes []uint32
+	Addresses    []string
+}
+
+// EventSubscription is an open subscription to the event stream of a node.
+type EventSubscription struct {
+	conn   *websocket.Conn
+	events chan *webapi_events.Event
+	err    error
+	mutex  sync.Mutex
+}
+
+// SubscribeEvents opens a WebSocket connection to the event stream of the node and subscribes to the given topics.
+// All topics are subscribed if none are given.
+func (api *GoShimmerAPI) SubscribeEvents(topics []string, filter ...EventFilter) (*EventSubscription, error) {
+	wsURL, err := url.Parse(fmt.Sprintf("%s/%s", api.baseURL, routeEvents))
+	if err != nil {
+		return nil, err
+	}
+	switch wsURL.Scheme {
+	case "https":
+		wsURL.Scheme = "wss"
+	default:
+		wsURL.Scheme = "ws"
+	}
+
+	query := url.Values{}
+	if len(topics) > 0 {
+		query.Set(webapi_events.QueryTopics, strings.Join(topics, ","))
+	}
+	for _, f := range filter {
+		for _, payloadType := range f.PayloadTypes {
+			query.Add(webapi_events.QueryPayloadTypes, strconv.FormatUint(uint64(payloadType), 10))
+		}
+		for _, address := range f.Addresses {
+			query.Add(webapi_events.QueryAddresses, address)
+		}
+	}
+	wsURL.RawQuery = query.Encode()
+
+	header := http.Header{}
+	if len(api.jwt) > 0 {
+		header.Set("Authorization", fmt.Sprintf("Bearer %s", api.jwt))
+	}
+
+	conn, res, err := websocket.DefaultDialer.Dial(wsURL.String(), header)
+	if err != nil {
+		if res != nil && res.StatusCode != http.StatusSwitchingProtocols {
+			return nil, interpretBody(res, &struct{}{})
+		}
+		return nil, err
+	}
+
+	subscription := &EventSubscription{
+		conn:   conn,
+		events: make(chan *webapi_events.Event, eventBufferSize),
+	}
+	go subscription.readLoop()
+
+	return subscription, nil
+}
+
+// Events returns the channel of the received events. It gets closed when the subscription ends.
+func (s *EventSubscription) Events() <-chan *webapi_events.Event {
+	return s.events
+}
+
+// Err returns the error which ended the subscription.
+func (s *EventSubscription) Err() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.err
+}
+
+// Close closes the subscription.
+func (s *EventSubscription) Close() error {
+	return s.conn.Close()
+}
+
+func (s *EventSubscription) readLoop() {
+	defer close(s.events)
+
+	for {
+		event := &webapi_events.Event{}
+		if err := s.conn.ReadJSON(event); err != nil {
+			s.mutex.Lock()
+			s.err = err
+			s.mutex.Unlock()
+			return
+		}
+		s.events <- event
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_manualneighbors "github.com/iotaledger/goshimmer/plugins/webapi/manualneighbors"
+)
+
+const (
+	routeManualNeighbors = "gossip/manualNeighbors"
+)
+
+// GetManualNeighbors gets the manual neighbors and whether they are currently connected.
+func (api *GoShimmerAPI) GetManualNeighbors() (*webapi_manualneighbors.Response, error) {
+	res := &webapi_manualneighbors.Response{}
+	if err := api.do(http.MethodGet, routeManualNeighbors, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AddManualNeighbors adds the given manual neighbors in the form publicKey@host:port.
+func (api *GoShimmerAPI) AddManualNeighbors(neighbors []string) (*webapi_manualneighbors.Response, error) {
+	res := &webapi_manualneighbors.Response{}
+	if err := api.do(http.MethodPost, routeManualNeighbors,
+		&webapi_manualneighbors.AddRequest{Neighbors: neighbors}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RemoveManualNeighbors removes the manual neighbors with the given base64 encoded public keys.
+func (api *GoShimmerAPI) RemoveManualNeighbors(publicKeys []string) (*webapi_manualneighbors.Response, error) {
+	res := &webapi_manualneighbors.Response{}
+	if err := api.do(http.MethodDelete, routeManualNeighbors,
+		&webapi_manualneighbors.RemoveRequest{PublicKeys: publicKeys}, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
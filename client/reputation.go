@@ -0,0 +1,20 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_reputation "github.com/iotaledger/goshimmer/plugins/webapi/reputation"
+)
+
+const (
+	routeGetReputation = "gossip/reputation"
+)
+
+// GetReputation gets the scores of the neighbors and the currently blacklisted peers.
+func (api *GoShimmerAPI) GetReputation() (*webapi_reputation.Response, error) {
+	res := &webapi_reputation.Response{}
+	if err := api.do(http.MethodGet, routeGetReputation, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
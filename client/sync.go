@@ -0,0 +1,20 @@
+package client
+
+import (
+	"net/http"
+
+	webapi_sync "github.com/iotaledger/goshimmer/plugins/webapi/sync"
+)
+
+const (
+	routeSync = "sync"
+)
+
+// SyncProgress gets the synchronization progress of the node.
+func (api *GoShimmerAPI) SyncProgress() (*webapi_sync.Response, error) {
+	res := &webapi_sync.Response{}
+	if err := api.do(http.MethodGet, routeSync, nil, res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
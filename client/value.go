@@ -0,0 +1,230 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	webapi_addresshistory "github.com/iotaledger/goshimmer/plugins/webapi/value/addresshistory"
+	webapi_balances "github.com/iotaledger/goshimmer/plugins/webapi/value/balances"
+	webapi_branches "github.com/iotaledger/goshimmer/plugins/webapi/value/branches"
+	webapi_colors "github.com/iotaledger/goshimmer/plugins/webapi/value/colors"
+	webapi_conflicts "github.com/iotaledger/goshimmer/plugins/webapi/value/conflicts"
+	webapi_gettransactionbyid "github.com/iotaledger/goshimmer/plugins/webapi/value/gettransactionbyid"
+	webapi_sendtransaction "github.com/iotaledger/goshimmer/plugins/webapi/value/sendtransaction"
+	webapi_unspentoutputs "github.com/iotaledger/goshimmer/plugins/webapi/value/unspentoutputs"
+)
+
+const (
+	routeSendTxn        = "value/sendTransaction"
+	routeUnspentOutputs = "value/unspentOutputs"
+	routeBalances       = "value/balances"
+	routeAddressHistory = "value/addressHistory"
+	routeGetTxnByID     = "value/transactionByID"
+	routeColors         = "value/colors"
+	routeConflicts      = "value/conflicts"
+	routeBranch         = "value/branch"
+	routeBranchTxns     = "value/branch/transactions"
+)
+
+// SendTransaction sends the transaction (bytes) to the value tangle and returns its transaction ID.
+func (api *GoShimmerAPI) SendTransaction(txnBytes []byte) (string, error) {
+	res := &webapi_sendtransaction.Response{}
+	if err := api.do(http.MethodPost, routeSendTxn,
+		&webapi_sendtransaction.Request{TransactionBytes: txnBytes}, res); err != nil {
+		return "", err
+	}
+
+	return res.TransactionID, nil
+}
+
+// CreateUnsignedTransaction creates an UnsignedTransaction that spends the given inputs. The balances and conditions of
+// the spent outputs are queried from the node, so the inputs have to be unspent.
+func (api *GoShimmerAPI) CreateUnsignedTransaction(inputs []transaction.OutputID, outputs *transaction.Outputs, dataPayload []byte) (*transaction.UnsignedTransaction, error) {
+	base58EncodedAddresses := make([]string, 0, len(inputs))
+	seenAddresses := make(map[string]bool)
+	for _, input := range inputs {
+		if base58EncodedAddress := input.Address().String(); !seenAddresses[base58EncodedAddress] {
+			seenAddresses[base58EncodedAddress] = true
+			base58EncodedAddresses = append(base58EncodedAddresses, base58EncodedAddress)
+		}
+	}
+
+	res, err := api.GetUnspentOutputs(base58EncodedAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	unspentOutputs := make(map[transaction.OutputID]*transaction.SpentOutput)
+	for _, unspentOutput := range res.UnspentOutputs {
+		for _, outputID := range unspentOutput.OutputIDs {
+			id, err := transaction.OutputIDFromBase58(outputID.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			balances := make([]*balance.Balance, len(outputID.Balances))
+			for i, coloredBalance := range outputID.Balances {
+				color, err := balance.ColorFromBase58(coloredBalance.Color)
+				if err != nil {
+					return nil, err
+				}
+				balances[i] = balance.New(color, coloredBalance.Value)
+			}
+
+			conditions, err := outputID.Conditions.ToOutputConditions()
+			if err != nil {
+				return nil, err
+			}
+
+			unspentOutputs[id] = transaction.NewSpentOutput(id, balances, conditions)
+		}
+	}
+
+	spentOutputs := make([]*transaction.SpentOutput, len(inputs))
+	for i, input := range inputs {
+		spentOutput, exists := unspentOutputs[input]
+		if !exists {
+			return nil, fmt.Errorf("input %s is not an unspent output", input)
+		}
+		spentOutputs[i] = spentOutput
+	}
+
+	tx := transaction.New(transaction.NewInputs(inputs...), outputs)
+	if len(dataPayload) != 0 {
+		if err := tx.SetDataPayload(dataPayload); err != nil {
+			return nil, err
+		}
+	}
+
+	return transaction.NewUnsignedTransaction(tx, spentOutputs...)
+}
+
+// SendUnsignedTransaction finalizes the given UnsignedTransaction, sends it to the value tangle and returns its
+// transaction ID. It fails if signatures are missing.
+func (api *GoShimmerAPI) SendUnsignedTransaction(unsignedTx *transaction.UnsignedTransaction) (string, error) {
+	tx, err := unsignedTx.Finalize(time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return api.SendTransaction(tx.Bytes())
+}
+
+// GetUnspentOutputs returns the outputs of the given base58 encoded addresses, that have not been spent yet.
+func (api *GoShimmerAPI) GetUnspentOutputs(base58EncodedAddresses []string) (*webapi_unspentoutputs.Response, error) {
+	res := &webapi_unspentoutputs.Response{}
+	if err := api.do(http.MethodPost, routeUnspentOutputs,
+		&webapi_unspentoutputs.Request{Addresses: base58EncodedAddresses}, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBalances returns the colored balances of the given base58 encoded address.
+func (api *GoShimmerAPI) GetBalances(base58EncodedAddress string) (*webapi_balances.Response, error) {
+	res := &webapi_balances.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s", routeBalances, base58EncodedAddress)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetAddressHistory returns the transactions that spent from or created outputs on the given base58 encoded address
+// ordered by the time they were booked. It returns at most limit entries starting at the given offset.
+func (api *GoShimmerAPI) GetAddressHistory(base58EncodedAddress string, offset int, limit int) (*webapi_addresshistory.Response, error) {
+	res := &webapi_addresshistory.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?address=%s&offset=%d&limit=%d", routeAddressHistory, base58EncodedAddress, offset, limit)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetTransactionByID gets the transaction and its inclusion state for the given base58 encoded transaction ID.
+func (api *GoShimmerAPI) GetTransactionByID(base58EncodedTxnID string) (*webapi_gettransactionbyid.Response, error) {
+	res := &webapi_gettransactionbyid.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?txnID=%s", routeGetTxnByID, base58EncodedTxnID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetColors returns the minting information and the supply of all colors known to the node.
+func (api *GoShimmerAPI) GetColors() (*webapi_colors.Response, error) {
+	res := &webapi_colors.Response{}
+	if err := api.do(http.MethodGet, routeColors, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetColor returns the minting information and the supply of the given base58 encoded color.
+func (api *GoShimmerAPI) GetColor(base58EncodedColor string) (*webapi_colors.Response, error) {
+	res := &webapi_colors.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?color=%s", routeColors, base58EncodedColor)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetConflicts returns all conflicts known to the node together with their member branches.
+func (api *GoShimmerAPI) GetConflicts() (*webapi_conflicts.Response, error) {
+	res := &webapi_conflicts.Response{}
+	if err := api.do(http.MethodGet, routeConflicts, nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetConflict returns the conflict with the given base58 encoded ID together with its member branches.
+func (api *GoShimmerAPI) GetConflict(base58EncodedConflictID string) (*webapi_conflicts.Response, error) {
+	res := &webapi_conflicts.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?conflictID=%s", routeConflicts, base58EncodedConflictID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBranch returns the branch with the given base58 encoded ID together with its children and aggregated branches.
+func (api *GoShimmerAPI) GetBranch(base58EncodedBranchID string) (*webapi_branches.Response, error) {
+	res := &webapi_branches.Response{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?branchID=%s", routeBranch, base58EncodedBranchID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetBranchTransactions returns the IDs of the transactions booked into the branch with the given base58 encoded ID.
+func (api *GoShimmerAPI) GetBranchTransactions(base58EncodedBranchID string) ([]string, error) {
+	res := &webapi_branches.TransactionsResponse{}
+	if err := api.do(http.MethodGet, func() string {
+		return fmt.Sprintf("%s?branchID=%s", routeBranchTxns, base58EncodedBranchID)
+	}(), nil, res); err != nil {
+		return nil, err
+	}
+
+	return res.TransactionIDs, nil
+}
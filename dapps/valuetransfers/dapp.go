@@ -1,16 +1,19 @@
 package valuetransfers
 
 import (
+	"os"
 	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tipmanager"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
 
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/consensus"
 	valuepayload "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
@@ -25,9 +28,6 @@ import (
 const (
 	// PluginName contains the human readable name of the plugin.
 	PluginName = "ValueTransfers"
-
-	// AverageNetworkDelay contains the average time it takes for a network to propagate through gossip.
-	AverageNetworkDelay = 5 * time.Second
 )
 
 var (
@@ -40,6 +40,9 @@ var (
 	// FCOB contains the fcob consensus logic.
 	FCOB *consensus.FCOB
 
+	// OpinionStore persists the opinions of the node, that are handed out to other nodes by the vote server.
+	OpinionStore *consensus.OpinionStore
+
 	// LedgerState represents the ledger state, that keeps track of the liked branches and offers an API to access funds.
 	LedgerState *tangle.LedgerState
 
@@ -63,6 +66,14 @@ func configure(_ *node.Plugin) {
 		log.Error(err)
 	}))
 
+	// load the genesis snapshot
+	if snapshotFile := config.Node.GetString(CfgValueTransfersSnapshotFile); snapshotFile != "" {
+		loadSnapshot(snapshotFile)
+	}
+
+	// configure LedgerState
+	LedgerState = tangle.NewLedgerState(Tangle)
+
 	// initialize tip manager and value object factory
 	tipManager = TipManager()
 	valueObjectFactory = ValueObjectFactory()
@@ -76,24 +87,56 @@ func configure(_ *node.Plugin) {
 		cachedPayload.Consume(tipManager.RemoveTip)
 	}))
 
+	// configure OpinionStore
+	OpinionStore = consensus.NewOpinionStore(database.Store())
+
 	// configure FCOB consensus rules
-	FCOB = consensus.NewFCOB(Tangle, AverageNetworkDelay)
+	FCOB = consensus.NewFCOB(Tangle, time.Duration(config.Node.GetInt(CfgValueTransfersAverageNetworkDelay))*time.Second)
 	FCOB.Events.Vote.Attach(events.NewClosure(func(id string, initOpn vote.Opinion) {
-		if err := voter.Vote(id, initOpn); err != nil {
+		OpinionStore.SetOpinion(id, initOpn)
+
+		if err := startVote(id, initOpn); err != nil {
 			log.Error(err)
 		}
 	}))
+	FCOB.Events.Opinion.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		OpinionStore.SetOpinion(id, opinion)
+	}))
+	FCOB.Events.Finalized.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		// opinions that are voted on are finalized by the voter only
+		if voteStarted(id) {
+			return
+		}
+
+		OpinionStore.SetFinalized(id, opinion)
+	}))
 	FCOB.Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Error(err)
 	}))
 
 	// configure FPC + link to consensus
 	configureFPC()
-	voter.Events().Finalized.Attach(events.NewClosure(FCOB.ProcessVoteResult))
-	voter.Events().Failed.Attach(events.NewClosure(func(id string, lastOpinion vote.Opinion) {
+	Voter().Events().Finalized.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		OpinionStore.SetFinalized(id, opinion)
+		endVote(id)
+	}))
+	Voter().Events().Finalized.Attach(events.NewClosure(FCOB.ProcessVoteResult))
+	Voter().Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		for id, voteContext := range roundStats.ActiveVoteContexts {
+			OpinionStore.SetOpinion(id, voteContext.LastOpinion())
+		}
+	}))
+	Voter().Events().Failed.Attach(events.NewClosure(func(id string, lastOpinion vote.Opinion) {
+		endVote(id)
 		log.Errorf("FPC failed for transaction with id '%s' - last opinion: '%s'", id, lastOpinion)
 	}))
 
+	// the issuers of messages are weighted by their funds for the approval weighted tip selection
+	messagelayer.SetApprovalWeight(identityBalance)
+
+	// messages with value payloads may only be pruned once their transactions are final
+	messagelayer.SetFinalityCheck(messageFinalized)
+
 	// subscribe to message-layer
 	messagelayer.Tangle.Events.MessageSolid.Attach(events.NewClosure(onReceiveMessageFromMessageLayer))
 }
@@ -102,8 +145,20 @@ func run(*node.Plugin) {
 	_ = daemon.BackgroundWorker("Tangle", func(shutdownSignal <-chan struct{}) {
 		<-shutdownSignal
 		Tangle.Shutdown()
+		OpinionStore.Shutdown()
 	}, shutdown.PriorityTangle)
 
+	if pruningWindow := time.Duration(config.Node.GetInt(database.CfgDatabasePruningWindowSec)) * time.Second; pruningWindow > 0 {
+		_ = daemon.BackgroundWorker("ValueTangle[Pruning]", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(func() {
+				prunedPayloads, prunedTransactions := Tangle.PruneOlderThan(time.Now().Add(-pruningWindow))
+				if prunedPayloads > 0 || prunedTransactions > 0 {
+					log.Infof("pruned %d value payloads and %d transactions", prunedPayloads, prunedTransactions)
+				}
+			}, time.Duration(config.Node.GetInt(database.CfgDatabasePruningIntervalSec))*time.Second, shutdownSignal)
+		}, shutdown.PriorityPruning)
+	}
+
 	runFPC()
 }
 
@@ -130,7 +185,42 @@ func onReceiveMessageFromMessageLayer(cachedMessage *message.CachedMessage, cach
 		return
 	}
 
-	Tangle.AttachPayload(valuePayload)
+	Tangle.AttachPayload(valuePayload, solidMessage.IssuingTime())
+}
+
+// messageFinalized returns true if the given message carries no value payload or if the transaction of its value
+// payload is finalized. Value payloads that were never booked (i.e. they were invalid) do not block the pruning.
+func messageFinalized(msg *message.Message) (finalized bool) {
+	valuePayload, ok := msg.Payload().(*valuepayload.Payload)
+	if !ok {
+		return true
+	}
+
+	if !Tangle.TransactionMetadata(valuePayload.Transaction().ID()).Consume(func(transactionMetadata *tangle.TransactionMetadata) {
+		finalized = transactionMetadata.Finalized()
+	}) {
+		return true
+	}
+
+	return
+}
+
+// loadSnapshot reads the snapshot file from the given path and loads it into the Tangle. Outputs that exist already
+// (the snapshot was loaded on a previous start) are not overwritten.
+func loadSnapshot(snapshotFile string) {
+	file, err := os.Open(snapshotFile)
+	if err != nil {
+		log.Fatalf("unable to open snapshot file '%s': %s", snapshotFile, err)
+	}
+	defer file.Close()
+
+	snapshot := tangle.Snapshot{}
+	if _, err := snapshot.ReadFrom(file); err != nil {
+		log.Fatalf("unable to read snapshot file '%s': %s", snapshotFile, err)
+	}
+	Tangle.LoadSnapshot(snapshot)
+
+	log.Infof("loaded snapshot from '%s' containing %d transactions", snapshotFile, len(snapshot))
 }
 
 // TipManager returns the TipManager singleton.
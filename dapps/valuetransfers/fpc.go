@@ -2,18 +2,19 @@ package valuetransfers
 
 import (
 	"context"
-	"flag"
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 
 	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
-	"github.com/iotaledger/hive.go/logger"
 	"google.golang.org/grpc"
 
-	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
 	"github.com/iotaledger/goshimmer/packages/prng"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/packages/vote"
@@ -22,34 +23,17 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/config"
-
-	"sync"
-
-	"github.com/iotaledger/hive.go/autopeering/peer/service"
-)
-
-const (
-	// CfgFPCQuerySampleSize defines how many nodes will be queried each round.
-	CfgFPCQuerySampleSize = "fpc.querySampleSize"
-
-	// CfgFPCRoundInterval defines how long a round lasts (in seconds)
-	CfgFPCRoundInterval = "fpc.roundInterval"
-
-	// CfgFPCBindAddress defines on which address the FPC service should listen.
-	CfgFPCBindAddress = "fpc.bindAddress"
 )
 
-func init() {
-	flag.Int(CfgFPCQuerySampleSize, 3, "Size of the voting quorum (k)")
-	flag.Int(CfgFPCRoundInterval, 5, "FPC round interval [s]")
-	flag.String(CfgFPCBindAddress, "0.0.0.0:10895", "the bind address on which the FPC vote server binds to")
-}
-
 var (
-	voter                *fpc.FPC
-	voterOnce            sync.Once
-	voterServer          *votenet.VoterServer
-	roundIntervalSeconds int64 = 5
+	voter       *fpc.FPC
+	voterOnce   sync.Once
+	voterServer *votenet.VoterServer
+
+	// startedVotes contains the ids that were submitted to the voter and whose vote did not end yet. It is kept in
+	// memory only, as the vote contexts of the voter do not survive a restart either.
+	startedVotes      = make(map[string]struct{})
+	startedVotesMutex sync.Mutex
 )
 
 // Voter returns the DRNGRoundBasedVoter instance used by the FPC plugin.
@@ -68,13 +52,23 @@ func Voter() vote.DRNGRoundBasedVoter {
 			}
 			return opinionGivers, nil
 		}
-		voter = fpc.New(opinionGiverFunc)
+
+		parameters := fpc.DefaultParameters()
+		parameters.QuerySampleSize = config.Node.GetInt(CfgFPCQuerySampleSize)
+		switch weighting := config.Node.GetString(CfgFPCWeighting); weighting {
+		case WeightingUniform:
+		case WeightingBalance:
+			parameters.OpinionGiverWeightProvider = &BalanceWeightProvider{}
+		default:
+			log.Fatalf("invalid FPC weighting '%s'", weighting)
+		}
+
+		voter = fpc.New(opinionGiverFunc, parameters)
 	})
 	return voter
 }
 
 func configureFPC() {
-	log = logger.NewLogger(PluginName)
 	lPeer := local.GetInstance()
 
 	bindAddr := config.Node.GetString(CfgFPCBindAddress)
@@ -91,37 +85,46 @@ func configureFPC() {
 		log.Fatalf("could not update services: %v", err)
 	}
 
-	voter.Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+	if config.Node.GetString(CfgFPCWeighting) == WeightingBalance {
+		log.Infof("the FPC weight of this node is determined by the funds on address %s", address.FromED25519PubKey(lPeer.PublicKey()))
+	}
+
+	Voter().Events().RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
 		peersQueried := len(roundStats.QueriedOpinions)
 		voteContextsCount := len(roundStats.ActiveVoteContexts)
 		log.Infof("executed round with rand %0.4f for %d vote contexts on %d peers, took %v", roundStats.RandUsed, voteContextsCount, peersQueried, roundStats.Duration)
 	}))
 }
 
-func runFPC() {
-	daemon.BackgroundWorker("FPCVoterServer", func(shutdownSignal <-chan struct{}) {
-		voterServer = votenet.New(Voter(), func(id string) vote.Opinion {
-			branchID, err := branchmanager.BranchIDFromBase58(id)
-			if err != nil {
-				log.Errorf("received invalid vote request for branch '%s'", id)
+// startVote submits the given id to the voter and remembers that its opinion is finalized by the voter.
+func startVote(id string, initialOpinion vote.Opinion) error {
+	startedVotesMutex.Lock()
+	startedVotes[id] = struct{}{}
+	startedVotesMutex.Unlock()
 
-				return vote.Unknown
-			}
+	return Voter().Vote(id, initialOpinion)
+}
 
-			cachedBranch := Tangle.BranchManager().Branch(branchID)
-			defer cachedBranch.Release()
+// endVote forgets the vote on the given id after the voter finalized it or gave up.
+func endVote(id string) {
+	startedVotesMutex.Lock()
+	delete(startedVotes, id)
+	startedVotesMutex.Unlock()
+}
 
-			branch := cachedBranch.Unwrap()
-			if branch == nil {
-				return vote.Unknown
-			}
+// voteStarted returns true if a vote on the given id was started and did not end yet.
+func voteStarted(id string) bool {
+	startedVotesMutex.Lock()
+	defer startedVotesMutex.Unlock()
 
-			if !branch.Preferred() {
-				return vote.Dislike
-			}
+	_, started := startedVotes[id]
 
-			return vote.Like
-		}, config.Node.GetString(CfgFPCBindAddress))
+	return started
+}
+
+func runFPC() {
+	daemon.BackgroundWorker("FPCVoterServer", func(shutdownSignal <-chan struct{}) {
+		voterServer = votenet.New(Voter(), OpinionStore.OpinionRetriever, config.Node.GetString(CfgFPCBindAddress))
 
 		go func() {
 			if err := voterServer.Run(); err != nil {
@@ -137,7 +140,7 @@ func runFPC() {
 
 	daemon.BackgroundWorker("FPCRoundsInitiator", func(shutdownSignal <-chan struct{}) {
 		log.Infof("Started FPC round initiator")
-		unixTsPRNG := prng.NewUnixTimestampPRNG(roundIntervalSeconds)
+		unixTsPRNG := prng.NewUnixTimestampPRNG(config.Node.GetInt64(CfgFPCRoundInterval))
 		defer unixTsPRNG.Stop()
 	exit:
 		for {
@@ -193,3 +196,27 @@ func (pog *PeerOpinionGiver) Query(ctx context.Context, ids []string) (vote.Opin
 func (pog *PeerOpinionGiver) ID() string {
 	return pog.p.ID().String()
 }
+
+// BalanceWeightProvider weights the opinion givers by the funds that are held in the ledger on the address which is
+// derived from the public key of their identity. Nodes can increase their weight by pledging funds to this address.
+type BalanceWeightProvider struct{}
+
+// Weight returns the sum of the unspent balances on the address of the given opinion giver.
+func (balanceWeightProvider *BalanceWeightProvider) Weight(opinionGiver vote.OpinionGiver) float64 {
+	peerOpinionGiver, ok := opinionGiver.(*PeerOpinionGiver)
+	if !ok {
+		return 0
+	}
+
+	return identityBalance(peerOpinionGiver.p.PublicKey())
+}
+
+// identityBalance returns the sum of the unspent balances on the address derived from the given public key.
+func identityBalance(publicKey ed25519.PublicKey) float64 {
+	var balance int64
+	for _, coloredBalance := range LedgerState.Balances(address.FromED25519PubKey(publicKey)) {
+		balance += coloredBalance
+	}
+
+	return float64(balance)
+}
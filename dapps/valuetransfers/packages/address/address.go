@@ -28,6 +28,10 @@ const (
 
 	// VersionBLS represents the address version that uses BLS signatures.
 	VersionBLS = byte(2)
+
+	// VersionMultisigED25519 represents the address version that requires a threshold of ED25519 signatures of a
+	// fixed set of public keys (M-of-N).
+	VersionMultisigED25519 = byte(3)
 )
 
 // Random creates a random address, which can for example be used in unit tests.
@@ -89,6 +93,24 @@ func FromBLSPubKey(pubKey []byte) (address Address) {
 	return
 }
 
+// FromMultisigED25519PubKeys creates a multisig address from an ordered list of ed25519 public keys and the amount of
+// signatures that are required to spend from it. The digest commits to the threshold, the amount and the order of the
+// keys, so the same keys with a different threshold or order result in a different address.
+func FromMultisigED25519PubKeys(threshold byte, publicKeys []ed25519.PublicKey) (address Address) {
+	marshalUtil := marshalutil.New(2 + len(publicKeys)*ed25519.PublicKeySize)
+	marshalUtil.WriteByte(threshold)
+	marshalUtil.WriteByte(byte(len(publicKeys)))
+	for _, publicKey := range publicKeys {
+		marshalUtil.WriteBytes(publicKey[:])
+	}
+	digest := blake2b.Sum256(marshalUtil.Bytes())
+
+	address[0] = VersionMultisigED25519
+	copy(address[1:], digest[:])
+
+	return
+}
+
 // FromBytes unmarshals an address from a sequence of bytes.
 func FromBytes(bytes []byte) (result Address, consumedBytes int, err error) {
 	// parse the bytes
@@ -0,0 +1,347 @@
+package signaturescheme
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+)
+
+// MaxMultisigPublicKeys defines the maximum amount of public keys that a multisig address can commit to. It keeps a
+// fully signed multisig signature within the size limits of a value transfer.
+const MaxMultisigPublicKeys = 16
+
+// region PUBLIC API ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigED25519 creates an instance of a signature scheme, that is used to sign the multisig address that requires
+// threshold signatures of the given ordered public keys. The signatures it creates only contain the partial signatures
+// of the given key pairs, so the signatures of independent signers have to be combined with
+// AggregateMultisigSignatures before they become valid.
+func MultisigED25519(threshold byte, publicKeys []ed25519.PublicKey, keyPairs ...ed25519.KeyPair) (SignatureScheme, error) {
+	if err := validateMultisigParameters(threshold, publicKeys); err != nil {
+		return nil, err
+	}
+
+	signatureScheme := &multisigED25519SignatureScheme{
+		threshold:  threshold,
+		publicKeys: publicKeys,
+		keyPairs:   make(map[byte]ed25519.KeyPair, len(keyPairs)),
+	}
+	for _, keyPair := range keyPairs {
+		index, exists := multisigPublicKeyIndex(publicKeys, keyPair.PublicKey)
+		if !exists {
+			return nil, fmt.Errorf("public key %s is not part of the multisig address", keyPair.PublicKey)
+		}
+		signatureScheme.keyPairs[index] = keyPair
+	}
+
+	return signatureScheme, nil
+}
+
+// AggregateMultisigSignatures combines the partial signatures of multiple multisig signatures for the same address into
+// a single one.
+func AggregateMultisigSignatures(sigs ...Signature) (Signature, error) {
+	if len(sigs) == 0 {
+		return nil, fmt.Errorf("must be at least one signature to aggregate")
+	}
+
+	var result *MultisigSignature
+	for _, sig := range sigs {
+		multisigSignature, ok := sig.(*MultisigSignature)
+		if !ok {
+			return nil, fmt.Errorf("not a multisig signature")
+		}
+
+		if result == nil {
+			result = &MultisigSignature{
+				threshold:  multisigSignature.threshold,
+				publicKeys: multisigSignature.publicKeys,
+			}
+		} else if multisigSignature.Address() != result.Address() {
+			return nil, fmt.Errorf("multisig signatures belong to different addresses")
+		}
+
+		for _, partialSignature := range multisigSignature.partialSignatures {
+			result.addPartialSignature(partialSignature)
+		}
+	}
+
+	return result, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region signature scheme implementation //////////////////////////////////////////////////////////////////////////////
+
+// multisigED25519SignatureScheme defines an interface for threshold signatures of a set of ED25519 key pairs.
+type multisigED25519SignatureScheme struct {
+	threshold  byte
+	publicKeys []ed25519.PublicKey
+	keyPairs   map[byte]ed25519.KeyPair
+}
+
+// Version returns the version byte that is associated to this signature scheme.
+func (signatureScheme *multisigED25519SignatureScheme) Version() byte {
+	return address.VersionMultisigED25519
+}
+
+// Address returns the address that this signature scheme instance is securing.
+func (signatureScheme *multisigED25519SignatureScheme) Address() address.Address {
+	return address.FromMultisigED25519PubKeys(signatureScheme.threshold, signatureScheme.publicKeys)
+}
+
+// Sign creates the partial signatures of the known key pairs for the given data.
+func (signatureScheme *multisigED25519SignatureScheme) Sign(data []byte) Signature {
+	signature := &MultisigSignature{
+		threshold:  signatureScheme.threshold,
+		publicKeys: signatureScheme.publicKeys,
+	}
+	for index, keyPair := range signatureScheme.keyPairs {
+		signature.addPartialSignature(multisigPartialSignature{
+			index:     index,
+			signature: keyPair.PrivateKey.Sign(data),
+		})
+	}
+
+	return signature
+}
+
+// interface contract (allow the compiler to check if the implementation has all of the required methods).
+var _ SignatureScheme = &multisigED25519SignatureScheme{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region signature implementation /////////////////////////////////////////////////////////////////////////////////////
+
+// MultisigSignature represents a signature for a multisig address. It contains the public keys that the address
+// commits to and the partial signatures of the signers, which are identified by the index of their public key.
+type MultisigSignature struct {
+	threshold         byte
+	publicKeys        []ed25519.PublicKey
+	partialSignatures []multisigPartialSignature
+}
+
+// multisigPartialSignature is the signature of a single signer of a multisig address.
+type multisigPartialSignature struct {
+	index     byte
+	signature ed25519.Signature
+}
+
+// MultisigSignatureFromBytes unmarshals a multisig signature from a sequence of bytes.
+// It either creates a new signature or fills the optionally provided object with the parsed information.
+func MultisigSignatureFromBytes(bytes []byte, optionalTargetObject ...*MultisigSignature) (result *MultisigSignature, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &MultisigSignature{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to MultisigSignatureFromBytes")
+	}
+
+	// initialize helper
+	marshalUtil := marshalutil.New(bytes)
+
+	// read version
+	versionByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	} else if versionByte != address.VersionMultisigED25519 {
+		err = fmt.Errorf("invalid version byte when parsing multisig signature")
+
+		return
+	}
+
+	// read threshold and public keys
+	if result.threshold, err = marshalUtil.ReadByte(); err != nil {
+		return
+	}
+	publicKeysCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	}
+	result.publicKeys = make([]ed25519.PublicKey, publicKeysCount)
+	for i := range result.publicKeys {
+		publicKey, publicKeyErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return ed25519.PublicKeyFromBytes(data) })
+		if publicKeyErr != nil {
+			err = publicKeyErr
+
+			return
+		}
+		result.publicKeys[i] = publicKey.(ed25519.PublicKey)
+	}
+	if err = validateMultisigParameters(result.threshold, result.publicKeys); err != nil {
+		return
+	}
+
+	// read partial signatures (they have to be ordered by their strictly increasing index)
+	partialSignaturesCount, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	} else if int(partialSignaturesCount) > len(result.publicKeys) {
+		err = fmt.Errorf("multisig signature contains more partial signatures than public keys")
+
+		return
+	}
+	result.partialSignatures = make([]multisigPartialSignature, partialSignaturesCount)
+	for i := range result.partialSignatures {
+		if result.partialSignatures[i].index, err = marshalUtil.ReadByte(); err != nil {
+			return
+		}
+		if int(result.partialSignatures[i].index) >= len(result.publicKeys) {
+			err = fmt.Errorf("index %d of partial signature out of range", result.partialSignatures[i].index)
+
+			return
+		}
+		if i > 0 && result.partialSignatures[i].index <= result.partialSignatures[i-1].index {
+			err = fmt.Errorf("partial signatures of multisig signature are not ordered by index")
+
+			return
+		}
+
+		signature, signatureErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return ed25519.SignatureFromBytes(data) })
+		if signatureErr != nil {
+			err = signatureErr
+
+			return
+		}
+		result.partialSignatures[i].signature = signature.(ed25519.Signature)
+	}
+
+	// return the number of bytes we processed
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// Threshold returns the amount of partial signatures that are required for the signature to be valid.
+func (signature *MultisigSignature) Threshold() byte {
+	return signature.threshold
+}
+
+// PublicKeys returns the ordered public keys that the multisig address commits to.
+func (signature *MultisigSignature) PublicKeys() []ed25519.PublicKey {
+	return signature.publicKeys
+}
+
+// Signers returns the public keys of the signers that contributed a partial signature.
+func (signature *MultisigSignature) Signers() []ed25519.PublicKey {
+	signers := make([]ed25519.PublicKey, len(signature.partialSignatures))
+	for i, partialSignature := range signature.partialSignatures {
+		signers[i] = signature.publicKeys[partialSignature.index]
+	}
+
+	return signers
+}
+
+// IsValid returns true if the signature contains at least threshold partial signatures and all of them are valid for
+// the given data.
+func (signature *MultisigSignature) IsValid(signedData []byte) bool {
+	return len(signature.partialSignatures) >= int(signature.threshold) && signature.PartialSignaturesValid(signedData)
+}
+
+// PartialSignaturesValid returns true if all contained partial signatures are valid for the given data, regardless of
+// whether they reach the threshold. It is used to check the contributions of single signers before they are
+// aggregated.
+func (signature *MultisigSignature) PartialSignaturesValid(signedData []byte) bool {
+	if validateMultisigParameters(signature.threshold, signature.publicKeys) != nil {
+		return false
+	}
+
+	for i, partialSignature := range signature.partialSignatures {
+		if int(partialSignature.index) >= len(signature.publicKeys) {
+			return false
+		}
+		if i > 0 && partialSignature.index <= signature.partialSignatures[i-1].index {
+			return false
+		}
+		if !signature.publicKeys[partialSignature.index].VerifySignature(signedData, partialSignature.signature) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Bytes returns a marshaled version of the signature.
+func (signature *MultisigSignature) Bytes() []byte {
+	marshalUtil := marshalutil.New(4 + len(signature.publicKeys)*ed25519.PublicKeySize + len(signature.partialSignatures)*(1+ed25519.SignatureSize))
+	marshalUtil.WriteByte(address.VersionMultisigED25519)
+	marshalUtil.WriteByte(signature.threshold)
+	marshalUtil.WriteByte(byte(len(signature.publicKeys)))
+	for _, publicKey := range signature.publicKeys {
+		marshalUtil.WriteBytes(publicKey[:])
+	}
+	marshalUtil.WriteByte(byte(len(signature.partialSignatures)))
+	for _, partialSignature := range signature.partialSignatures {
+		marshalUtil.WriteByte(partialSignature.index)
+		marshalUtil.WriteBytes(partialSignature.signature[:])
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// Address returns the address, that this signature signs.
+func (signature *MultisigSignature) Address() address.Address {
+	return address.FromMultisigED25519PubKeys(signature.threshold, signature.publicKeys)
+}
+
+// addPartialSignature adds the partial signature while keeping them ordered by their index. A partial signature with an
+// index that exists already is ignored.
+func (signature *MultisigSignature) addPartialSignature(partialSignature multisigPartialSignature) {
+	position := sort.Search(len(signature.partialSignatures), func(i int) bool {
+		return signature.partialSignatures[i].index >= partialSignature.index
+	})
+	if position < len(signature.partialSignatures) && signature.partialSignatures[position].index == partialSignature.index {
+		return
+	}
+
+	signature.partialSignatures = append(signature.partialSignatures, multisigPartialSignature{})
+	copy(signature.partialSignatures[position+1:], signature.partialSignatures[position:])
+	signature.partialSignatures[position] = partialSignature
+}
+
+// interface contract (allow the compiler to check if the implementation has all of the required methods).
+var _ Signature = &MultisigSignature{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region utility functions ////////////////////////////////////////////////////////////////////////////////////////////
+
+// validateMultisigParameters checks that the threshold can be reached with the given public keys.
+func validateMultisigParameters(threshold byte, publicKeys []ed25519.PublicKey) error {
+	switch {
+	case len(publicKeys) == 0:
+		return fmt.Errorf("multisig address requires at least one public key")
+	case len(publicKeys) > MaxMultisigPublicKeys:
+		return fmt.Errorf("multisig address must not have more than %d public keys", MaxMultisigPublicKeys)
+	case threshold == 0 || int(threshold) > len(publicKeys):
+		return fmt.Errorf("multisig threshold must be between 1 and %d", len(publicKeys))
+	}
+
+	for i := range publicKeys {
+		if _, exists := multisigPublicKeyIndex(publicKeys[:i], publicKeys[i]); exists {
+			return fmt.Errorf("duplicate public key %s in multisig address", publicKeys[i])
+		}
+	}
+
+	return nil
+}
+
+// multisigPublicKeyIndex returns the index of the given public key in the list of public keys.
+func multisigPublicKeyIndex(publicKeys []ed25519.PublicKey, publicKey ed25519.PublicKey) (byte, bool) {
+	for i := range publicKeys {
+		if bytes.Equal(publicKeys[i][:], publicKey[:]) {
+			return byte(i), true
+		}
+	}
+
+	return 0, false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
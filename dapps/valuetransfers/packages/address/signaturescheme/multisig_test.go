@@ -0,0 +1,117 @@
+package signaturescheme
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+)
+
+func generateMultisigKeyPairs(count int) (keyPairs []ed25519.KeyPair, publicKeys []ed25519.PublicKey) {
+	for i := 0; i < count; i++ {
+		keyPair := ed25519.GenerateKeyPair()
+		keyPairs = append(keyPairs, keyPair)
+		publicKeys = append(publicKeys, keyPair.PublicKey)
+	}
+
+	return
+}
+
+func TestMultisig_Address(t *testing.T) {
+	_, publicKeys := generateMultisigKeyPairs(3)
+
+	addr := address.FromMultisigED25519PubKeys(2, publicKeys)
+	assert.Equal(t, address.VersionMultisigED25519, addr.Version())
+
+	// the digest commits to the threshold and the order of the keys
+	assert.NotEqual(t, addr, address.FromMultisigED25519PubKeys(3, publicKeys))
+	assert.NotEqual(t, addr, address.FromMultisigED25519PubKeys(2, []ed25519.PublicKey{publicKeys[1], publicKeys[0], publicKeys[2]}))
+
+	sigScheme, err := MultisigED25519(2, publicKeys)
+	require.NoError(t, err)
+	assert.Equal(t, addr, sigScheme.Address())
+}
+
+func TestMultisig_InvalidParameters(t *testing.T) {
+	keyPairs, publicKeys := generateMultisigKeyPairs(3)
+
+	_, err := MultisigED25519(0, publicKeys)
+	assert.Error(t, err)
+	_, err = MultisigED25519(4, publicKeys)
+	assert.Error(t, err)
+	_, err = MultisigED25519(1, nil)
+	assert.Error(t, err)
+	_, err = MultisigED25519(2, []ed25519.PublicKey{publicKeys[0], publicKeys[0]})
+	assert.Error(t, err)
+	_, tooManyPublicKeys := generateMultisigKeyPairs(MaxMultisigPublicKeys + 1)
+	_, err = MultisigED25519(2, tooManyPublicKeys)
+	assert.Error(t, err)
+
+	// key pair that is not part of the address
+	_, err = MultisigED25519(2, publicKeys[:2], keyPairs[2])
+	assert.Error(t, err)
+}
+
+func TestMultisig_Threshold(t *testing.T) {
+	keyPairs, publicKeys := generateMultisigKeyPairs(3)
+
+	// a single signer does not reach the threshold
+	sigScheme0, err := MultisigED25519(2, publicKeys, keyPairs[0])
+	require.NoError(t, err)
+	signature0 := sigScheme0.Sign(dataToSign)
+	assert.Equal(t, sigScheme0.Address(), signature0.Address())
+	assert.False(t, signature0.IsValid(dataToSign))
+
+	// the partial signatures of independent signers can be aggregated
+	sigScheme2, err := MultisigED25519(2, publicKeys, keyPairs[2])
+	require.NoError(t, err)
+	aggregated, err := AggregateMultisigSignatures(signature0, sigScheme2.Sign(dataToSign))
+	require.NoError(t, err)
+	assert.True(t, aggregated.IsValid(dataToSign))
+	assert.False(t, aggregated.IsValid([]byte("other data")))
+	assert.Equal(t, []ed25519.PublicKey{publicKeys[0], publicKeys[2]}, aggregated.(*MultisigSignature).Signers())
+
+	// the same signer counts only once
+	duplicate, err := AggregateMultisigSignatures(signature0, sigScheme0.Sign(dataToSign))
+	require.NoError(t, err)
+	assert.False(t, duplicate.IsValid(dataToSign))
+
+	// signatures of different addresses can not be aggregated
+	otherSigScheme, err := MultisigED25519(1, publicKeys, keyPairs[1])
+	require.NoError(t, err)
+	_, err = AggregateMultisigSignatures(signature0, otherSigScheme.Sign(dataToSign))
+	assert.Error(t, err)
+	_, err = AggregateMultisigSignatures(signature0, ED25519(keyPairs[1]).Sign(dataToSign))
+	assert.Error(t, err)
+}
+
+func TestMultisig_Marshaling(t *testing.T) {
+	keyPairs, publicKeys := generateMultisigKeyPairs(3)
+
+	sigScheme, err := MultisigED25519(2, publicKeys, keyPairs[2], keyPairs[1])
+	require.NoError(t, err)
+	signature := sigScheme.Sign(dataToSign)
+	require.True(t, signature.IsValid(dataToSign))
+
+	clonedSignature, consumedBytes, err := MultisigSignatureFromBytes(signature.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(signature.Bytes()), consumedBytes)
+	assert.Equal(t, signature.Bytes(), clonedSignature.Bytes())
+	assert.Equal(t, signature.Address(), clonedSignature.Address())
+	assert.True(t, clonedSignature.IsValid(dataToSign))
+
+	// partial signatures with an out of range index are rejected
+	corruptedBytes := signature.Bytes()
+	corruptedBytes[3+len(publicKeys)*ed25519.PublicKeySize+1] = byte(len(publicKeys))
+	_, _, err = MultisigSignatureFromBytes(corruptedBytes)
+	assert.Error(t, err)
+
+	// duplicate public keys are rejected
+	corruptedBytes = signature.Bytes()
+	copy(corruptedBytes[3+ed25519.PublicKeySize:], publicKeys[0][:])
+	_, _, err = MultisigSignatureFromBytes(corruptedBytes)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package balance
 
 import (
+	"fmt"
+
 	"github.com/iotaledger/hive.go/marshalutil"
 	"github.com/mr-tron/base58"
 )
@@ -22,6 +24,39 @@ func ColorFromBytes(bytes []byte) (result Color, consumedBytes int, err error) {
 	return
 }
 
+// ParseColor unmarshals a Color using the given marshalUtil (for easier marshaling/unmarshaling).
+func ParseColor(marshalUtil *marshalutil.MarshalUtil) (Color, error) {
+	color, err := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return ColorFromBytes(data) })
+	if err != nil {
+		return Color{}, err
+	}
+
+	return color.(Color), nil
+}
+
+// ColorFromBase58 creates a Color from a base58 encoded string. The string "IOTA" is accepted as an alias for
+// ColorIOTA (the inverse of String).
+func ColorFromBase58(base58String string) (color Color, err error) {
+	if base58String == "IOTA" {
+		return ColorIOTA, nil
+	}
+
+	bytes, err := base58.Decode(base58String)
+	if err != nil {
+		return
+	}
+
+	if len(bytes) != ColorLength {
+		err = fmt.Errorf("base58 encoded string does not match the length of a color")
+
+		return
+	}
+
+	copy(color[:], bytes)
+
+	return
+}
+
 // Bytes marshals the Color into a sequence of bytes.
 func (color Color) Bytes() []byte {
 	return color[:]
@@ -94,6 +94,43 @@ func (branchManager *BranchManager) ConflictMembers(conflictID ConflictID) Cache
 	return conflictMembers
 }
 
+// ForEachConflict iterates through all Conflicts that are known to the BranchManager.
+func (branchManager *BranchManager) ForEachConflict(consumer func(cachedConflict *CachedConflict) bool) {
+	branchManager.conflictStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		return consumer(&CachedConflict{CachedObject: cachedObject})
+	})
+}
+
+// AggregatedBranchConstituents returns the conflict branches that were merged into the given aggregated Branch. It
+// returns an empty list for conflict branches.
+func (branchManager *BranchManager) AggregatedBranchConstituents(branchID BranchID) (constituents []BranchID, err error) {
+	cachedBranch := branchManager.Branch(branchID)
+	defer cachedBranch.Release()
+
+	branch := cachedBranch.Unwrap()
+	if branch == nil {
+		err = fmt.Errorf("failed to load branch '%s'", branchID)
+
+		return
+	}
+	if !branch.IsAggregated() {
+		return
+	}
+
+	closestConflictAncestors := make(CachedBranches)
+	defer closestConflictAncestors.Release()
+	if err = branchManager.collectClosestConflictAncestors(branch, closestConflictAncestors); err != nil {
+		return
+	}
+
+	constituents = make([]BranchID, 0, len(closestConflictAncestors))
+	for constituentID := range closestConflictAncestors {
+		constituents = append(constituents, constituentID)
+	}
+
+	return
+}
+
 // Fork adds a new Branch to the branch-DAG and automatically creates the Conflicts and references if they don't exist.
 // It can also be used to update an existing Branch and add it to additional conflicts.
 func (branchManager *BranchManager) Fork(branchID BranchID, parentBranches []BranchID, conflicts []ConflictID) (cachedBranch *CachedBranch, newBranchCreated bool) {
@@ -6,6 +6,8 @@ import (
 
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSomething(t *testing.T) {
@@ -21,3 +23,47 @@ func TestSomething(t *testing.T) {
 
 	fmt.Println(branchManager.BranchesConflicting(MasterBranchID, branch2.ID()))
 }
+
+func TestForEachConflict(t *testing.T) {
+	branchManager := New(mapdb.NewMapDB())
+
+	cachedBranch1, _ := branchManager.Fork(BranchID{2}, []BranchID{MasterBranchID}, []ConflictID{transaction.OutputID{4}})
+	cachedBranch1.Release()
+	cachedBranch2, _ := branchManager.Fork(BranchID{3}, []BranchID{MasterBranchID}, []ConflictID{transaction.OutputID{4}, transaction.OutputID{5}})
+	cachedBranch2.Release()
+
+	memberCounts := make(map[ConflictID]int)
+	branchManager.ForEachConflict(func(cachedConflict *CachedConflict) bool {
+		cachedConflict.Consume(func(conflict *Conflict) {
+			memberCounts[conflict.ID()] = conflict.MemberCount()
+		})
+
+		return true
+	})
+	assert.Equal(t, map[ConflictID]int{transaction.OutputID{4}: 2, transaction.OutputID{5}: 1}, memberCounts)
+}
+
+func TestAggregatedBranchConstituents(t *testing.T) {
+	branchManager := New(mapdb.NewMapDB())
+
+	cachedBranch1, _ := branchManager.Fork(BranchID{2}, []BranchID{MasterBranchID}, []ConflictID{transaction.OutputID{4}})
+	cachedBranch1.Release()
+	cachedBranch2, _ := branchManager.Fork(BranchID{3}, []BranchID{MasterBranchID}, []ConflictID{transaction.OutputID{5}})
+	cachedBranch2.Release()
+
+	cachedAggregatedBranch, err := branchManager.AggregateBranches(BranchID{2}, BranchID{3})
+	require.NoError(t, err)
+	aggregatedBranchID := cachedAggregatedBranch.Unwrap().ID()
+	cachedAggregatedBranch.Release()
+
+	constituents, err := branchManager.AggregatedBranchConstituents(aggregatedBranchID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []BranchID{{2}, {3}}, constituents)
+
+	constituents, err = branchManager.AggregatedBranchConstituents(BranchID{2})
+	require.NoError(t, err)
+	assert.Empty(t, constituents)
+
+	_, err = branchManager.AggregatedBranchConstituents(BranchID{9})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/iotaledger/hive.go/events"
 
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
 	"github.com/iotaledger/goshimmer/packages/vote"
@@ -28,7 +29,10 @@ func NewFCOB(tangle *tangle.Tangle, averageNetworkDelay time.Duration) (fcob *FC
 		tangle:              tangle,
 		averageNetworkDelay: averageNetworkDelay,
 		Events: &FCOBEvents{
-			Error: events.NewEvent(events.ErrorCaller),
+			Error:     events.NewEvent(events.ErrorCaller),
+			Vote:      events.NewEvent(vote.OpinionCaller),
+			Opinion:   events.NewEvent(vote.OpinionCaller),
+			Finalized: events.NewEvent(vote.OpinionCaller),
 		},
 	}
 
@@ -100,6 +104,8 @@ func (fcob *FCOB) setPreferred(cachedTransactionMetadata *tangle.CachedTransacti
 		}
 
 		if modified {
+			fcob.Events.Opinion.Trigger(transactionMetadata.ID().String(), vote.Like)
+
 			fcob.scheduleSetFinalized(cachedTransactionMetadata.Retain())
 		}
 	})
@@ -125,15 +131,18 @@ func (fcob *FCOB) setFinalized(cachedTransactionMetadata *tangle.CachedTransacti
 			return
 		}
 
-		transactionMetadata.SetFinalized(true)
+		if transactionMetadata.SetFinalized(true) {
+			fcob.Events.Finalized.Trigger(transactionMetadata.ID().String(), vote.Like)
+		}
 	})
 }
 
 // onFork triggers a voting process whenever a Transaction gets forked into a new Branch. The initial opinion is derived
 // from the preferred flag that was set using the FCOB rule.
-func (fcob *FCOB) onFork(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *tangle.CachedTransactionMetadata) {
+func (fcob *FCOB) onFork(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *tangle.CachedTransactionMetadata, cachedTargetBranch *branchmanager.CachedBranch, _ []transaction.OutputID) {
 	defer cachedTransaction.Release()
 	defer cachedTransactionMetadata.Release()
+	defer cachedTargetBranch.Release()
 
 	transactionMetadata := cachedTransactionMetadata.Unwrap()
 	if transactionMetadata == nil {
@@ -155,4 +164,10 @@ type FCOBEvents struct {
 
 	// Vote gets called when FCOB needs to vote on a transaction.
 	Vote *events.Event
+
+	// Opinion gets called when FCOB formed an opinion about a transaction without the need to vote on it.
+	Opinion *events.Event
+
+	// Finalized gets called when FCOB finalized the opinion about a transaction without the need to vote on it.
+	Finalized *events.Event
 }
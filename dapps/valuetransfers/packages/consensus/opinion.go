@@ -0,0 +1,192 @@
+package consensus
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+// Opinion stores the opinion of the node about a transaction or conflict, that is identified by the id that is used
+// in the voting process.
+type Opinion struct {
+	objectstorage.StorableObjectFlags
+
+	id        string
+	opinion   vote.Opinion
+	finalized bool
+
+	opinionMutex sync.RWMutex
+}
+
+// NewOpinion creates a new Opinion with the given id and opinion that is not finalized, yet.
+func NewOpinion(id string, opinion vote.Opinion) *Opinion {
+	return &Opinion{
+		id:      id,
+		opinion: opinion,
+	}
+}
+
+// OpinionFromStorageKey is a factory method that creates a new Opinion instance from a storage key of the
+// objectstorage. It is used by the objectstorage, to create new instances of this entity.
+func OpinionFromStorageKey(key []byte, optionalTargetObject ...*Opinion) (result *Opinion, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &Opinion{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to OpinionFromStorageKey")
+	}
+
+	// the key consists of the id only
+	result.id = string(key)
+	consumedBytes = len(key)
+
+	return
+}
+
+// ID returns the id of the transaction or conflict that this Opinion belongs to.
+func (opinion *Opinion) ID() string {
+	return opinion.id
+}
+
+// Opinion returns the current opinion of the node.
+func (opinion *Opinion) Opinion() vote.Opinion {
+	opinion.opinionMutex.RLock()
+	defer opinion.opinionMutex.RUnlock()
+
+	return opinion.opinion
+}
+
+// Finalized returns true if the opinion was finalized and is not going to change anymore.
+func (opinion *Opinion) Finalized() bool {
+	opinion.opinionMutex.RLock()
+	defer opinion.opinionMutex.RUnlock()
+
+	return opinion.finalized
+}
+
+// SetOpinion updates the current opinion of the node. Finalized opinions can not be changed anymore, so the method
+// returns false if the opinion was finalized already or if it did not change.
+func (opinion *Opinion) SetOpinion(newOpinion vote.Opinion) (modified bool) {
+	opinion.opinionMutex.Lock()
+	defer opinion.opinionMutex.Unlock()
+
+	if opinion.finalized || opinion.opinion == newOpinion {
+		return
+	}
+
+	opinion.opinion = newOpinion
+	opinion.SetModified()
+	modified = true
+
+	return
+}
+
+// SetFinalized sets the opinion to the given final value. It returns false if the opinion was finalized already.
+func (opinion *Opinion) SetFinalized(finalOpinion vote.Opinion) (modified bool) {
+	opinion.opinionMutex.Lock()
+	defer opinion.opinionMutex.Unlock()
+
+	if opinion.finalized {
+		return
+	}
+
+	opinion.opinion = finalOpinion
+	opinion.finalized = true
+	opinion.SetModified()
+	modified = true
+
+	return
+}
+
+// String returns a human readable version of the Opinion.
+func (opinion *Opinion) String() string {
+	return stringify.Struct("Opinion",
+		stringify.StructField("id", opinion.ID()),
+		stringify.StructField("opinion", opinion.Opinion()),
+		stringify.StructField("finalized", opinion.Finalized()),
+	)
+}
+
+// Update is disabled and panics if it ever gets called - updates are supposed to happen through the setters.
+// It is required to match StorableObject interface.
+func (opinion *Opinion) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+// It is required to match StorableObject interface.
+func (opinion *Opinion) ObjectStorageKey() []byte {
+	return []byte(opinion.id)
+}
+
+// ObjectStorageValue marshals the Opinion into a sequence of bytes. The id is not serialized here as it is only used as
+// a key in the objectstorage.
+func (opinion *Opinion) ObjectStorageValue() []byte {
+	opinion.opinionMutex.RLock()
+	defer opinion.opinionMutex.RUnlock()
+
+	return marshalutil.New(1 + marshalutil.BOOL_SIZE).
+		WriteByte(byte(opinion.opinion)).
+		WriteBool(opinion.finalized).
+		Bytes()
+}
+
+// UnmarshalObjectStorageValue unmarshals the bytes that are stored in the value of the objectstorage.
+func (opinion *Opinion) UnmarshalObjectStorageValue(data []byte) (consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(data)
+	opinionByte, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	}
+	opinion.opinion = vote.Opinion(opinionByte)
+	if opinion.finalized, err = marshalUtil.ReadBool(); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// CachedOpinion is a wrapper for the generic CachedObject returned by the objectstorage, that overrides the accessor
+// methods, with a type-casted one.
+type CachedOpinion struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (cachedOpinion *CachedOpinion) Retain() *CachedOpinion {
+	return &CachedOpinion{cachedOpinion.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (cachedOpinion *CachedOpinion) Unwrap() *Opinion {
+	untypedObject := cachedOpinion.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*Opinion)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (cachedOpinion *CachedOpinion) Consume(consumer func(opinion *Opinion)) (consumed bool) {
+	return cachedOpinion.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*Opinion))
+	})
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ objectstorage.StorableObject = &Opinion{}
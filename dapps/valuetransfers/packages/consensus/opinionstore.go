@@ -0,0 +1,103 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/objectstorage"
+
+	"github.com/iotaledger/goshimmer/packages/binary/storageprefix"
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+const (
+	// the following values are a list of prefixes defined as an enum
+	_ byte = iota
+
+	// prefixes used for the objectstorage
+	osOpinion
+)
+
+// OpinionStore persists the opinions of the node about transactions and conflicts, so they can be handed out to other
+// nodes that query the node during their voting process.
+type OpinionStore struct {
+	opinionStorage *objectstorage.ObjectStorage
+}
+
+// NewOpinionStore is the constructor of the OpinionStore and creates a store that persists the opinions in the given
+// KVStore.
+func NewOpinionStore(store kvstore.KVStore) *OpinionStore {
+	osFactory := objectstorage.NewFactory(store, storageprefix.Consensus)
+
+	return &OpinionStore{
+		opinionStorage: osFactory.New(osOpinion, osOpinionFactory, objectstorage.CacheTime(time.Second)),
+	}
+}
+
+// Opinion loads the stored opinion about the given id from the objectstorage.
+func (opinionStore *OpinionStore) Opinion(id string) *CachedOpinion {
+	return &CachedOpinion{CachedObject: opinionStore.opinionStorage.Load([]byte(id))}
+}
+
+// OpinionRetriever returns the current opinion about the given id or vote.Unknown if there is no opinion, yet. It
+// matches the signature of the OpinionRetriever that is used by the vote server.
+func (opinionStore *OpinionStore) OpinionRetriever(id string) (result vote.Opinion) {
+	result = vote.Unknown
+	opinionStore.Opinion(id).Consume(func(opinion *Opinion) {
+		result = opinion.Opinion()
+	})
+
+	return
+}
+
+// SetOpinion stores the current opinion about the given id. Opinions that were finalized already are not changed.
+func (opinionStore *OpinionStore) SetOpinion(id string, opinion vote.Opinion) (modified bool) {
+	cachedOpinion, created := opinionStore.computeIfAbsent(id, opinion)
+	cachedOpinion.Consume(func(storedOpinion *Opinion) {
+		modified = storedOpinion.SetOpinion(opinion) || created
+	})
+
+	return
+}
+
+// SetFinalized stores the final opinion about the given id.
+func (opinionStore *OpinionStore) SetFinalized(id string, opinion vote.Opinion) (modified bool) {
+	cachedOpinion, _ := opinionStore.computeIfAbsent(id, opinion)
+	cachedOpinion.Consume(func(storedOpinion *Opinion) {
+		modified = storedOpinion.SetFinalized(opinion)
+	})
+
+	return
+}
+
+// Shutdown marks the OpinionStore as stopped, so it will not accept any new opinions (waits for all pending opinions
+// to be persisted).
+func (opinionStore *OpinionStore) Shutdown() *OpinionStore {
+	opinionStore.opinionStorage.Shutdown()
+
+	return opinionStore
+}
+
+// Prune resets the database and deletes all stored opinions.
+func (opinionStore *OpinionStore) Prune() error {
+	return opinionStore.opinionStorage.Prune()
+}
+
+// computeIfAbsent loads the Opinion about the given id or creates a new one with the given initial opinion.
+func (opinionStore *OpinionStore) computeIfAbsent(id string, initialOpinion vote.Opinion) (cachedOpinion *CachedOpinion, created bool) {
+	cachedOpinion = &CachedOpinion{CachedObject: opinionStore.opinionStorage.ComputeIfAbsent([]byte(id), func(key []byte) objectstorage.StorableObject {
+		created = true
+
+		newOpinion := NewOpinion(id, initialOpinion)
+		newOpinion.Persist()
+		newOpinion.SetModified()
+
+		return newOpinion
+	})}
+
+	return
+}
+
+func osOpinionFactory(key []byte) (objectstorage.StorableObject, int, error) {
+	return OpinionFromStorageKey(key)
+}
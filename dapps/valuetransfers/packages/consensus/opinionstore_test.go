@@ -0,0 +1,48 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+func TestOpinionStore(t *testing.T) {
+	opinionStore := NewOpinionStore(mapdb.NewMapDB())
+	defer opinionStore.Shutdown()
+
+	// unknown ids are answered with an unknown opinion
+	assert.Equal(t, vote.Unknown, opinionStore.OpinionRetriever("transaction"))
+
+	// the current opinion can change until it is finalized
+	assert.True(t, opinionStore.SetOpinion("transaction", vote.Like))
+	assert.True(t, opinionStore.SetOpinion("transaction", vote.Dislike))
+	assert.False(t, opinionStore.SetOpinion("transaction", vote.Dislike))
+	assert.Equal(t, vote.Dislike, opinionStore.OpinionRetriever("transaction"))
+
+	assert.True(t, opinionStore.SetFinalized("transaction", vote.Like))
+	assert.False(t, opinionStore.SetFinalized("transaction", vote.Dislike))
+	assert.False(t, opinionStore.SetOpinion("transaction", vote.Dislike))
+	assert.Equal(t, vote.Like, opinionStore.OpinionRetriever("transaction"))
+
+	assert.True(t, opinionStore.Opinion("transaction").Consume(func(opinion *Opinion) {
+		assert.True(t, opinion.Finalized())
+	}))
+}
+
+func TestOpinion_MarshalUnmarshal(t *testing.T) {
+	opinion := NewOpinion("transaction", vote.Dislike)
+	opinion.SetFinalized(vote.Like)
+
+	restoredOpinion, _, err := OpinionFromStorageKey(opinion.ObjectStorageKey())
+	require.NoError(t, err)
+	_, err = restoredOpinion.UnmarshalObjectStorageValue(opinion.ObjectStorageValue())
+	require.NoError(t, err)
+
+	assert.Equal(t, "transaction", restoredOpinion.ID())
+	assert.Equal(t, vote.Like, restoredOpinion.Opinion())
+	assert.True(t, restoredOpinion.Finalized())
+}
@@ -257,7 +257,7 @@ func init() {
 		payload, _, err = FromBytes(data)
 
 		return
-	})
+	}, payload.MaxSize)
 }
 
 // define contract (ensure that the struct fulfills the corresponding interface)
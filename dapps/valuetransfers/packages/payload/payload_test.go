@@ -65,6 +65,9 @@ func ExamplePayload() {
 
 		// payload
 		valuePayload,
+
+		// the proof-of-work nonce
+		0,
 	)
 
 	fmt.Println(tx)
@@ -0,0 +1,247 @@
+package tangle
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// AddressTransactionPartitionKeys defines the "layout" of the key. This enables prefix iterations in the objectstorage.
+var AddressTransactionPartitionKeys = objectstorage.PartitionKey([]int{address.Length, marshalutil.TIME_SIZE, transaction.IDLength}...)
+
+// AddressTransaction stores the information that a transaction spent outputs from or created outputs on an address. It
+// is used to look up the transaction history of an address. The timestamp is part of the key and encoded in big endian,
+// so that the keys of an address are sorted by time.
+type AddressTransaction struct {
+	objectstorage.StorableObjectFlags
+
+	address       address.Address
+	transactionID transaction.ID
+	timestamp     time.Time
+	spent         bool
+	created       bool
+
+	storageKey []byte
+}
+
+// NewAddressTransaction creates an AddressTransaction object with the given information.
+func NewAddressTransaction(addr address.Address, transactionID transaction.ID, timestamp time.Time, spent bool, created bool) *AddressTransaction {
+	return &AddressTransaction{
+		address:       addr,
+		transactionID: transactionID,
+		timestamp:     timestamp,
+		spent:         spent,
+		created:       created,
+
+		storageKey: marshalutil.New(address.Length + marshalutil.TIME_SIZE + transaction.IDLength).
+			WriteBytes(addr.Bytes()).
+			WriteBytes(timestampKeyBytes(timestamp)).
+			WriteBytes(transactionID.Bytes()).
+			Bytes(),
+	}
+}
+
+// AddressTransactionFromBytes unmarshals an AddressTransaction from a sequence of bytes - it either creates a new object
+// or fills the optionally provided one with the parsed information.
+func AddressTransactionFromBytes(bytes []byte, optionalTargetObject ...*AddressTransaction) (result *AddressTransaction, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result, err = ParseAddressTransaction(marshalUtil, optionalTargetObject...)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseAddressTransaction unmarshals an AddressTransaction using the given marshalUtil (for easier
+// marshaling/unmarshaling).
+func ParseAddressTransaction(marshalUtil *marshalutil.MarshalUtil, optionalTargetObject ...*AddressTransaction) (result *AddressTransaction, err error) {
+	parsedObject, parseErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) {
+		return AddressTransactionFromStorageKey(data, optionalTargetObject...)
+	})
+	if parseErr != nil {
+		err = parseErr
+
+		return
+	}
+
+	result = parsedObject.(*AddressTransaction)
+	_, err = marshalUtil.Parse(func(data []byte) (parseResult interface{}, parsedBytes int, parseErr error) {
+		parsedBytes, parseErr = result.UnmarshalObjectStorageValue(data)
+
+		return
+	})
+
+	return
+}
+
+// AddressTransactionFromStorageKey is a factory method that creates a new AddressTransaction instance from a storage
+// key of the objectstorage. It is used by the objectstorage, to create new instances of this entity.
+func AddressTransactionFromStorageKey(key []byte, optionalTargetObject ...*AddressTransaction) (result *AddressTransaction, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &AddressTransaction{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to AddressTransactionFromStorageKey")
+	}
+
+	// parse the properties that are stored in the key
+	marshalUtil := marshalutil.New(key)
+	if result.address, err = address.Parse(marshalUtil); err != nil {
+		return
+	}
+	timestampBytes, err := marshalUtil.ReadBytes(marshalutil.TIME_SIZE)
+	if err != nil {
+		return
+	}
+	result.timestamp = time.Unix(0, int64(binary.BigEndian.Uint64(timestampBytes)))
+	if result.transactionID, err = transaction.ParseID(marshalUtil); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+	result.storageKey = marshalutil.New(key[:consumedBytes]).Bytes(true)
+
+	return
+}
+
+// Address returns the address that the transaction spent from or created outputs on.
+func (addressTransaction *AddressTransaction) Address() address.Address {
+	return addressTransaction.address
+}
+
+// TransactionID returns the ID of the transaction.
+func (addressTransaction *AddressTransaction) TransactionID() transaction.ID {
+	return addressTransaction.transactionID
+}
+
+// Timestamp returns the time when the transaction was booked.
+func (addressTransaction *AddressTransaction) Timestamp() time.Time {
+	return addressTransaction.timestamp
+}
+
+// Spent returns true if the transaction consumed outputs of the address.
+func (addressTransaction *AddressTransaction) Spent() bool {
+	return addressTransaction.spent
+}
+
+// Created returns true if the transaction created outputs on the address.
+func (addressTransaction *AddressTransaction) Created() bool {
+	return addressTransaction.created
+}
+
+// Bytes marshals the AddressTransaction into a sequence of bytes.
+func (addressTransaction *AddressTransaction) Bytes() []byte {
+	return marshalutil.New().
+		WriteBytes(addressTransaction.ObjectStorageKey()).
+		WriteBytes(addressTransaction.ObjectStorageValue()).
+		Bytes()
+}
+
+// String returns a human readable version of the AddressTransaction.
+func (addressTransaction *AddressTransaction) String() string {
+	return stringify.Struct("AddressTransaction",
+		stringify.StructField("address", addressTransaction.Address()),
+		stringify.StructField("transactionId", addressTransaction.TransactionID()),
+		stringify.StructField("timestamp", addressTransaction.Timestamp()),
+		stringify.StructField("spent", addressTransaction.Spent()),
+		stringify.StructField("created", addressTransaction.Created()),
+	)
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (addressTransaction *AddressTransaction) ObjectStorageKey() []byte {
+	return addressTransaction.storageKey
+}
+
+// ObjectStorageValue marshals the "content part" of an AddressTransaction to a sequence of bytes.
+func (addressTransaction *AddressTransaction) ObjectStorageValue() []byte {
+	return marshalutil.New(2 * marshalutil.BOOL_SIZE).
+		WriteBool(addressTransaction.spent).
+		WriteBool(addressTransaction.created).
+		Bytes()
+}
+
+// UnmarshalObjectStorageValue unmarshals the "content part" of an AddressTransaction from a sequence of bytes.
+func (addressTransaction *AddressTransaction) UnmarshalObjectStorageValue(data []byte) (consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(data)
+	if addressTransaction.spent, err = marshalUtil.ReadBool(); err != nil {
+		return
+	}
+	if addressTransaction.created, err = marshalUtil.ReadBool(); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// Update is disabled - updates are supposed to happen through the setters (if existing).
+func (addressTransaction *AddressTransaction) Update(other objectstorage.StorableObject) {
+	panic("update forbidden")
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ objectstorage.StorableObject = &AddressTransaction{}
+
+// timestampKeyBytes encodes the given time as the nanoseconds since the unix epoch in big endian (in contrast to the
+// little endian encoding of the marshalutil), so that the keys can be compared byte-wise.
+func timestampKeyBytes(timestamp time.Time) []byte {
+	timestampBytes := make([]byte, marshalutil.TIME_SIZE)
+	binary.BigEndian.PutUint64(timestampBytes, uint64(timestamp.UnixNano()))
+
+	return timestampBytes
+}
+
+// region CachedAddressTransaction /////////////////////////////////////////////////////////////////////////////////////
+
+// CachedAddressTransaction is a wrapper for the generic CachedObject returned by the objectstorage, that overrides the
+// accessor methods, with a type-casted one.
+type CachedAddressTransaction struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (cachedAddressTransaction *CachedAddressTransaction) Unwrap() *AddressTransaction {
+	untypedObject := cachedAddressTransaction.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*AddressTransaction)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (cachedAddressTransaction *CachedAddressTransaction) Consume(consumer func(addressTransaction *AddressTransaction)) (consumed bool) {
+	return cachedAddressTransaction.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*AddressTransaction))
+	})
+}
+
+// CachedAddressTransactions represents a collection of CachedAddressTransactions.
+type CachedAddressTransactions []*CachedAddressTransaction
+
+// Consume iterates over the CachedObjects, unwraps them and passes a type-casted version to the consumer (if the object
+// is not empty - it exists). It automatically releases the object when the consumer finishes. It returns true, if at
+// least one object was consumed.
+func (cachedAddressTransactions CachedAddressTransactions) Consume(consumer func(addressTransaction *AddressTransaction)) (consumed bool) {
+	for _, cachedAddressTransaction := range cachedAddressTransactions {
+		consumed = cachedAddressTransaction.Consume(consumer) || consumed
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
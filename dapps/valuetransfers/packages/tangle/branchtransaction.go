@@ -0,0 +1,171 @@
+package tangle
+
+import (
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// BranchTransactionPartitionKeys defines the "layout" of the key. This enables prefix iterations in the objectstorage.
+var BranchTransactionPartitionKeys = objectstorage.PartitionKey([]int{branchmanager.BranchIDLength, transaction.IDLength}...)
+
+// BranchTransaction stores the information that a transaction is booked into a branch. It is used to look up the
+// transactions of a branch without having to iterate through the metadata of all transactions.
+type BranchTransaction struct {
+	objectstorage.StorableObjectFlags
+
+	branchID      branchmanager.BranchID
+	transactionID transaction.ID
+
+	storageKey []byte
+}
+
+// NewBranchTransaction creates a BranchTransaction object with the given information.
+func NewBranchTransaction(branchID branchmanager.BranchID, transactionID transaction.ID) *BranchTransaction {
+	return &BranchTransaction{
+		branchID:      branchID,
+		transactionID: transactionID,
+
+		storageKey: marshalutil.New(branchmanager.BranchIDLength + transaction.IDLength).
+			WriteBytes(branchID.Bytes()).
+			WriteBytes(transactionID.Bytes()).
+			Bytes(),
+	}
+}
+
+// BranchTransactionFromBytes unmarshals a BranchTransaction from a sequence of bytes - it either creates a new object
+// or fills the optionally provided one with the parsed information.
+func BranchTransactionFromBytes(bytes []byte, optionalTargetObject ...*BranchTransaction) (result *BranchTransaction, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result, err = ParseBranchTransaction(marshalUtil, optionalTargetObject...)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseBranchTransaction unmarshals a BranchTransaction using the given marshalUtil (for easier marshaling/unmarshaling).
+func ParseBranchTransaction(marshalUtil *marshalutil.MarshalUtil, optionalTargetObject ...*BranchTransaction) (result *BranchTransaction, err error) {
+	parsedObject, parseErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) {
+		return BranchTransactionFromStorageKey(data, optionalTargetObject...)
+	})
+	if parseErr != nil {
+		err = parseErr
+
+		return
+	}
+
+	result = parsedObject.(*BranchTransaction)
+
+	return
+}
+
+// BranchTransactionFromStorageKey is a factory method that creates a new BranchTransaction instance from a storage key
+// of the objectstorage. All of the information of a BranchTransaction is stored in its key.
+func BranchTransactionFromStorageKey(key []byte, optionalTargetObject ...*BranchTransaction) (result *BranchTransaction, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &BranchTransaction{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to BranchTransactionFromStorageKey")
+	}
+
+	// parse the properties that are stored in the key
+	marshalUtil := marshalutil.New(key)
+	if result.branchID, err = branchmanager.ParseBranchID(marshalUtil); err != nil {
+		return
+	}
+	if result.transactionID, err = transaction.ParseID(marshalUtil); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+	result.storageKey = marshalutil.New(key[:consumedBytes]).Bytes(true)
+
+	return
+}
+
+// BranchID returns the ID of the branch that the transaction is booked into.
+func (branchTransaction *BranchTransaction) BranchID() branchmanager.BranchID {
+	return branchTransaction.branchID
+}
+
+// TransactionID returns the ID of the transaction.
+func (branchTransaction *BranchTransaction) TransactionID() transaction.ID {
+	return branchTransaction.transactionID
+}
+
+// Bytes marshals the BranchTransaction into a sequence of bytes.
+func (branchTransaction *BranchTransaction) Bytes() []byte {
+	return branchTransaction.ObjectStorageKey()
+}
+
+// String returns a human readable version of the BranchTransaction.
+func (branchTransaction *BranchTransaction) String() string {
+	return stringify.Struct("BranchTransaction",
+		stringify.StructField("branchId", branchTransaction.BranchID()),
+		stringify.StructField("transactionId", branchTransaction.TransactionID()),
+	)
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (branchTransaction *BranchTransaction) ObjectStorageKey() []byte {
+	return branchTransaction.storageKey
+}
+
+// ObjectStorageValue is implemented to conform with the StorableObject interface, but it does not really do anything,
+// since all of the information about a BranchTransaction are stored in the "key".
+func (branchTransaction *BranchTransaction) ObjectStorageValue() (data []byte) {
+	return
+}
+
+// UnmarshalObjectStorageValue is implemented to conform with the StorableObject interface, but it does not really do
+// anything, since all of the information about a BranchTransaction are stored in the "key".
+func (branchTransaction *BranchTransaction) UnmarshalObjectStorageValue(data []byte) (consumedBytes int, err error) {
+	return
+}
+
+// Update is disabled - updates are supposed to happen through the setters (if existing).
+func (branchTransaction *BranchTransaction) Update(other objectstorage.StorableObject) {
+	panic("update forbidden")
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ objectstorage.StorableObject = &BranchTransaction{}
+
+// region CachedBranchTransaction //////////////////////////////////////////////////////////////////////////////////////
+
+// CachedBranchTransaction is a wrapper for the generic CachedObject returned by the objectstorage, that overrides the
+// accessor methods, with a type-casted one.
+type CachedBranchTransaction struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (cachedBranchTransaction *CachedBranchTransaction) Unwrap() *BranchTransaction {
+	untypedObject := cachedBranchTransaction.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*BranchTransaction)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (cachedBranchTransaction *CachedBranchTransaction) Consume(consumer func(branchTransaction *BranchTransaction)) (consumed bool) {
+	return cachedBranchTransaction.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*BranchTransaction))
+	})
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
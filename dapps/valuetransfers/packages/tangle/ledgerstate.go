@@ -3,6 +3,7 @@ package tangle
 import (
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
 )
 
 // LedgerState represents a struct, that allows us to read the balances from the UTXODAG by filtering the existing
@@ -32,3 +33,78 @@ func (ledgerState *LedgerState) Balances(address address.Address) (coloredBalanc
 
 	return
 }
+
+// AddressHistory returns the transactions that spent from or created outputs on the given address ordered by the time
+// they were booked (oldest first). It returns at most limit entries starting at the given offset (a limit of 0 returns
+// all remaining entries) together with the total number of entries.
+func (ledgerState *LedgerState) AddressHistory(address address.Address, offset int, limit int) (history []*AddressTransaction, total int) {
+	var cachedAddressTransactions CachedAddressTransactions
+	cachedAddressTransactions, total = ledgerState.tangle.AddressTransactions(address, offset, limit)
+	cachedAddressTransactions.Consume(func(addressTransaction *AddressTransaction) {
+		history = append(history, addressTransaction)
+	})
+
+	return
+}
+
+// AddressHistorySize returns the number of transactions that spent from or created outputs on the given address.
+func (ledgerState *LedgerState) AddressHistorySize(address address.Address) int {
+	return ledgerState.tangle.AddressTransactionCount(address)
+}
+
+// ColorSupply contains the information about the minting and the supply of a Color.
+type ColorSupply struct {
+	Color                balance.Color
+	MintingTransactionID transaction.ID
+	InitialSupply        int64
+	CirculatingSupply    int64
+}
+
+// ColorSupply returns the supply information of the given Color. The circulating supply only contains the supply
+// changes of transactions that are in a liked branch, so rejected mints or burns are not taken into account.
+func (ledgerState *LedgerState) ColorSupply(color balance.Color) (colorSupply *ColorSupply, exists bool) {
+	colorSupplies := ledgerState.colorSupplies(ledgerState.tangle.SupplyChanges(color))
+	colorSupply, exists = colorSupplies[color]
+
+	return
+}
+
+// ColorSupplies returns the supply information of all known colors.
+func (ledgerState *LedgerState) ColorSupplies() map[balance.Color]*ColorSupply {
+	return ledgerState.colorSupplies(ledgerState.tangle.SupplyChanges())
+}
+
+// colorSupplies aggregates the given supply changes into the supply information of their colors.
+func (ledgerState *LedgerState) colorSupplies(cachedSupplyChanges CachedSupplyChanges) (colorSupplies map[balance.Color]*ColorSupply) {
+	colorSupplies = make(map[balance.Color]*ColorSupply)
+	cachedSupplyChanges.Consume(func(supplyChange *SupplyChange) {
+		colorSupply, exists := colorSupplies[supplyChange.Color()]
+		if !exists {
+			colorSupply = &ColorSupply{Color: supplyChange.Color()}
+			colorSupplies[supplyChange.Color()] = colorSupply
+		}
+
+		if supplyChange.Minting() {
+			colorSupply.MintingTransactionID = supplyChange.TransactionID()
+			colorSupply.InitialSupply += supplyChange.Delta()
+		}
+
+		if ledgerState.transactionLiked(supplyChange.TransactionID()) {
+			colorSupply.CirculatingSupply += supplyChange.Delta()
+		}
+	})
+
+	return
+}
+
+// transactionLiked returns true if the given transaction is booked into a liked branch. Transactions of the snapshot
+// (and pruned transactions) have no metadata and are always liked.
+func (ledgerState *LedgerState) transactionLiked(transactionID transaction.ID) (liked bool) {
+	if !ledgerState.tangle.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+		liked = ledgerState.tangle.BranchManager().IsBranchLiked(transactionMetadata.BranchID())
+	}) {
+		liked = true
+	}
+
+	return
+}
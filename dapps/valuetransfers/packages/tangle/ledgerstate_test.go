@@ -0,0 +1,168 @@
+package tangle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func TestLedgerState_ColorSupply(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+	ledgerState := NewLedgerState(valueTangle)
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	minterKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	receiverAddress := address.Random()
+
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1000),
+			},
+		},
+	})
+
+	// mint a new color
+	mintingTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			minterKeyPair.Address(): {
+				balance.New(balance.ColorIOTA, 400),
+				balance.New(balance.ColorNew, 600),
+			},
+		}),
+	)
+	mintingTransaction.Sign(genesisKeyPair)
+	mintingPayload := payload.New(payload.GenesisID, payload.GenesisID, mintingTransaction)
+	valueTangle.AttachPayloadSync(mintingPayload, time.Now())
+
+	mintedColor := balance.Color(mintingTransaction.ID())
+	assert.Equal(t, map[balance.Color]int64{
+		balance.ColorIOTA: 400,
+		mintedColor:       600,
+	}, ledgerState.Balances(minterKeyPair.Address()))
+
+	// burn some of the minted tokens by converting them back to IOTA
+	burningTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(minterKeyPair.Address(), mintingTransaction.ID())),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			receiverAddress: {
+				balance.New(balance.ColorIOTA, 800),
+				balance.New(mintedColor, 200),
+			},
+		}),
+	)
+	burningTransaction.Sign(minterKeyPair)
+	valueTangle.AttachPayloadSync(payload.New(mintingPayload.ID(), payload.GenesisID, burningTransaction), time.Now())
+
+	colorSupply, exists := ledgerState.ColorSupply(mintedColor)
+	require.True(t, exists)
+	assert.Equal(t, &ColorSupply{
+		Color:                mintedColor,
+		MintingTransactionID: mintingTransaction.ID(),
+		InitialSupply:        600,
+		CirculatingSupply:    200,
+	}, colorSupply)
+
+	iotaSupply, exists := ledgerState.ColorSupply(balance.ColorIOTA)
+	require.True(t, exists)
+	assert.Equal(t, &ColorSupply{
+		Color:                balance.ColorIOTA,
+		MintingTransactionID: transaction.GenesisID,
+		InitialSupply:        1000,
+		CirculatingSupply:    800,
+	}, iotaSupply)
+
+	assert.Len(t, ledgerState.ColorSupplies(), 2)
+
+	_, exists = ledgerState.ColorSupply(balance.Color(transaction.RandomID()))
+	assert.False(t, exists)
+}
+
+func TestLedgerState_AddressHistory(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+	ledgerState := NewLedgerState(valueTangle)
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	receiverKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1000),
+			},
+		},
+	})
+
+	// send a part of the funds and the remainder back to the genesis address
+	firstTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			genesisKeyPair.Address():  {balance.New(balance.ColorIOTA, 600)},
+			receiverKeyPair.Address(): {balance.New(balance.ColorIOTA, 400)},
+		}),
+	)
+	firstTransaction.Sign(genesisKeyPair)
+	firstPayload := payload.New(payload.GenesisID, payload.GenesisID, firstTransaction)
+	valueTangle.AttachPayloadSync(firstPayload, time.Now())
+
+	// spend the received funds
+	secondTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(receiverKeyPair.Address(), firstTransaction.ID())),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			address.Random(): {balance.New(balance.ColorIOTA, 400)},
+		}),
+	)
+	secondTransaction.Sign(receiverKeyPair)
+	valueTangle.AttachPayloadSync(payload.New(firstPayload.ID(), payload.GenesisID, secondTransaction), time.Now())
+
+	type entry struct {
+		transactionID transaction.ID
+		spent         bool
+		created       bool
+	}
+	entries := func(history []*AddressTransaction) (result []entry) {
+		for _, addressTransaction := range history {
+			result = append(result, entry{addressTransaction.TransactionID(), addressTransaction.Spent(), addressTransaction.Created()})
+		}
+
+		return
+	}
+
+	history, total := ledgerState.AddressHistory(genesisKeyPair.Address(), 0, 0)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []entry{
+		{transaction.GenesisID, false, true},
+		{firstTransaction.ID(), true, true},
+	}, entries(history))
+
+	history, total = ledgerState.AddressHistory(receiverKeyPair.Address(), 0, 0)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []entry{
+		{firstTransaction.ID(), false, true},
+		{secondTransaction.ID(), true, false},
+	}, entries(history))
+	require.True(t, history[0].Timestamp().Before(history[1].Timestamp()))
+	assert.Equal(t, 2, ledgerState.AddressHistorySize(receiverKeyPair.Address()))
+
+	// page through the history
+	history, total = ledgerState.AddressHistory(receiverKeyPair.Address(), 1, 1)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []entry{{secondTransaction.ID(), true, false}}, entries(history))
+
+	history, total = ledgerState.AddressHistory(receiverKeyPair.Address(), 2, 1)
+	assert.Equal(t, 2, total)
+	assert.Empty(t, history)
+}
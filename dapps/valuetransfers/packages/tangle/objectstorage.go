@@ -23,6 +23,9 @@ const (
 	osAttachment
 	osOutput
 	osConsumer
+	osSupplyChange
+	osAddressTransaction
+	osBranchTransaction
 )
 
 var (
@@ -67,3 +70,15 @@ func osOutputFactory(key []byte) (objectstorage.StorableObject, int, error) {
 func osConsumerFactory(key []byte) (objectstorage.StorableObject, int, error) {
 	return ConsumerFromStorageKey(key)
 }
+
+func osSupplyChangeFactory(key []byte) (objectstorage.StorableObject, int, error) {
+	return SupplyChangeFromStorageKey(key)
+}
+
+func osAddressTransactionFactory(key []byte) (objectstorage.StorableObject, int, error) {
+	return AddressTransactionFromStorageKey(key)
+}
+
+func osBranchTransactionFactory(key []byte) (objectstorage.StorableObject, int, error) {
+	return BranchTransactionFromStorageKey(key)
+}
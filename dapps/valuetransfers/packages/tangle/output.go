@@ -27,6 +27,7 @@ type Output struct {
 	firstConsumer      transaction.ID
 	consumerCount      int
 	balances           []*balance.Balance
+	conditions         *transaction.OutputConditions
 
 	branchIDMutex           sync.RWMutex
 	solidMutex              sync.RWMutex
@@ -37,8 +38,9 @@ type Output struct {
 	storageKey []byte
 }
 
-// NewOutput creates an Output that contains the balances and identifiers of a Transaction.
-func NewOutput(address address.Address, transactionID transaction.ID, branchID branchmanager.BranchID, balances []*balance.Balance) *Output {
+// NewOutput creates an Output that contains the balances, the conditions (nil if the output has none) and identifiers
+// of a Transaction.
+func NewOutput(address address.Address, transactionID transaction.ID, branchID branchmanager.BranchID, balances []*balance.Balance, conditions *transaction.OutputConditions) *Output {
 	return &Output{
 		address:            address,
 		transactionID:      transactionID,
@@ -46,6 +48,7 @@ func NewOutput(address address.Address, transactionID transaction.ID, branchID b
 		solid:              false,
 		solidificationTime: time.Time{},
 		balances:           balances,
+		conditions:         conditions,
 
 		storageKey: marshalutil.New().WriteBytes(address.Bytes()).WriteBytes(transactionID.Bytes()).Bytes(),
 	}
@@ -232,6 +235,11 @@ func (output *Output) Balances() []*balance.Balance {
 	return output.balances
 }
 
+// Conditions returns the conditions that restrict the spending of this output (nil if the output has none).
+func (output *Output) Conditions() *transaction.OutputConditions {
+	return output.conditions
+}
+
 // Bytes marshals the object into a sequence of bytes.
 func (output *Output) Bytes() []byte {
 	return marshalutil.New().
@@ -254,9 +262,10 @@ func (output *Output) ObjectStorageKey() []byte {
 func (output *Output) ObjectStorageValue() []byte {
 	// determine amount of balances in the output
 	balanceCount := len(output.balances)
+	conditionsBytes := output.conditions.Bytes()
 
 	// initialize helper
-	marshalUtil := marshalutil.New(branchmanager.BranchIDLength + marshalutil.BOOL_SIZE + marshalutil.TIME_SIZE + transaction.IDLength + marshalutil.UINT32_SIZE + marshalutil.UINT32_SIZE + balanceCount*balance.Length)
+	marshalUtil := marshalutil.New(branchmanager.BranchIDLength + marshalutil.BOOL_SIZE + marshalutil.TIME_SIZE + transaction.IDLength + marshalutil.UINT32_SIZE + marshalutil.UINT32_SIZE + balanceCount*balance.Length + len(conditionsBytes))
 	marshalUtil.WriteBytes(output.branchID.Bytes())
 	marshalUtil.WriteBool(output.solid)
 	marshalUtil.WriteTime(output.solidificationTime)
@@ -266,6 +275,7 @@ func (output *Output) ObjectStorageValue() []byte {
 	for _, balanceToMarshal := range output.balances {
 		marshalUtil.WriteBytes(balanceToMarshal.Bytes())
 	}
+	marshalUtil.WriteBytes(conditionsBytes)
 
 	return marshalUtil.Bytes()
 }
@@ -302,6 +312,12 @@ func (output *Output) UnmarshalObjectStorageValue(data []byte) (consumedBytes in
 			return
 		}
 	}
+	if output.conditions, err = transaction.ParseOutputConditions(marshalUtil); err != nil {
+		return
+	}
+	if output.conditions.Empty() {
+		output.conditions = nil
+	}
 	consumedBytes = marshalUtil.ReadOffset()
 
 	return
@@ -320,6 +336,7 @@ func (output *Output) String() string {
 		stringify.StructField("solid", output.Solid()),
 		stringify.StructField("solidificationTime", output.SolidificationTime()),
 		stringify.StructField("balances", output.Balances()),
+		stringify.StructField("conditions", output.Conditions()),
 	)
 }
 
@@ -18,6 +18,7 @@ type PayloadMetadata struct {
 	objectstorage.StorableObjectFlags
 
 	payloadID          payload.ID
+	issuingTime        time.Time
 	solid              bool
 	solidificationTime time.Time
 	liked              bool
@@ -29,10 +30,12 @@ type PayloadMetadata struct {
 	branchIDMutex           sync.RWMutex
 }
 
-// NewPayloadMetadata creates an empty container for the metadata of a value transfer payload.
-func NewPayloadMetadata(payloadID payload.ID) *PayloadMetadata {
+// NewPayloadMetadata creates an empty container for the metadata of a value transfer payload. The issuing time is the
+// time of the message that attached the payload.
+func NewPayloadMetadata(payloadID payload.ID, issuingTime time.Time) *PayloadMetadata {
 	return &PayloadMetadata{
-		payloadID: payloadID,
+		payloadID:   payloadID,
+		issuingTime: issuingTime,
 	}
 }
 
@@ -95,6 +98,12 @@ func (payloadMetadata *PayloadMetadata) PayloadID() payload.ID {
 	return payloadMetadata.payloadID
 }
 
+// IssuingTime returns the issuing time of the message that attached the payload. The conditions of the outputs that
+// are spent by the transaction of the payload are evaluated at this time.
+func (payloadMetadata *PayloadMetadata) IssuingTime() time.Time {
+	return payloadMetadata.issuingTime
+}
+
 // IsSolid returns true if the payload has been marked as solid.
 func (payloadMetadata *PayloadMetadata) IsSolid() (result bool) {
 	payloadMetadata.solidMutex.RLock()
@@ -207,7 +216,7 @@ func (payloadMetadata *PayloadMetadata) SetBranchID(branchID branchmanager.Branc
 
 // Bytes marshals the metadata into a sequence of bytes.
 func (payloadMetadata *PayloadMetadata) Bytes() []byte {
-	return marshalutil.New(payload.IDLength + marshalutil.TIME_SIZE + 2*marshalutil.BOOL_SIZE + branchmanager.BranchIDLength).
+	return marshalutil.New(payload.IDLength + 2*marshalutil.TIME_SIZE + 2*marshalutil.BOOL_SIZE + branchmanager.BranchIDLength).
 		WriteBytes(payloadMetadata.ObjectStorageKey()).
 		WriteBytes(payloadMetadata.ObjectStorageValue()).
 		Bytes()
@@ -217,6 +226,7 @@ func (payloadMetadata *PayloadMetadata) Bytes() []byte {
 func (payloadMetadata *PayloadMetadata) String() string {
 	return stringify.Struct("PayloadMetadata",
 		stringify.StructField("payloadId", payloadMetadata.PayloadID()),
+		stringify.StructField("issuingTime", payloadMetadata.IssuingTime()),
 		stringify.StructField("solid", payloadMetadata.IsSolid()),
 		stringify.StructField("solidificationTime", payloadMetadata.SoldificationTime()),
 	)
@@ -236,7 +246,8 @@ func (payloadMetadata *PayloadMetadata) Update(other objectstorage.StorableObjec
 
 // ObjectStorageValue is required to match the encoding.BinaryMarshaler interface.
 func (payloadMetadata *PayloadMetadata) ObjectStorageValue() []byte {
-	return marshalutil.New(marshalutil.TIME_SIZE + 2*marshalutil.BOOL_SIZE).
+	return marshalutil.New(2*marshalutil.TIME_SIZE + 2*marshalutil.BOOL_SIZE + branchmanager.BranchIDLength).
+		WriteTime(payloadMetadata.issuingTime).
 		WriteTime(payloadMetadata.solidificationTime).
 		WriteBool(payloadMetadata.solid).
 		WriteBool(payloadMetadata.liked).
@@ -247,6 +258,9 @@ func (payloadMetadata *PayloadMetadata) ObjectStorageValue() []byte {
 // UnmarshalObjectStorageValue is required to match the encoding.BinaryUnmarshaler interface.
 func (payloadMetadata *PayloadMetadata) UnmarshalObjectStorageValue(data []byte) (consumedBytes int, err error) {
 	marshalUtil := marshalutil.New(data)
+	if payloadMetadata.issuingTime, err = marshalUtil.ReadTime(); err != nil {
+		return
+	}
 	if payloadMetadata.solidificationTime, err = marshalUtil.ReadTime(); err != nil {
 		return
 	}
@@ -10,7 +10,7 @@ import (
 )
 
 func TestMarshalUnmarshal(t *testing.T) {
-	originalMetadata := NewPayloadMetadata(payload.GenesisID)
+	originalMetadata := NewPayloadMetadata(payload.GenesisID, time.Now())
 
 	clonedMetadata, _, err := PayloadMetadataFromBytes(originalMetadata.Bytes())
 	if err != nil {
@@ -18,6 +18,7 @@ func TestMarshalUnmarshal(t *testing.T) {
 	}
 
 	assert.Equal(t, originalMetadata.PayloadID(), clonedMetadata.PayloadID())
+	assert.True(t, originalMetadata.IssuingTime().Equal(clonedMetadata.IssuingTime()))
 	assert.Equal(t, originalMetadata.IsSolid(), clonedMetadata.IsSolid())
 	assert.Equal(t, originalMetadata.SoldificationTime().Round(time.Second), clonedMetadata.SoldificationTime().Round(time.Second))
 
@@ -34,7 +35,7 @@ func TestMarshalUnmarshal(t *testing.T) {
 }
 
 func TestPayloadMetadata_SetSolid(t *testing.T) {
-	originalMetadata := NewPayloadMetadata(payload.GenesisID)
+	originalMetadata := NewPayloadMetadata(payload.GenesisID, time.Now())
 
 	assert.Equal(t, false, originalMetadata.IsSolid())
 	assert.Equal(t, time.Time{}, originalMetadata.SoldificationTime())
@@ -0,0 +1,224 @@
+package tangle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+const (
+	// SnapshotVersion defines the version of the binary snapshot format that is written by this node.
+	SnapshotVersion = byte(1)
+
+	// SnapshotChecksumLength defines the length of the checksum that is appended to a marshaled snapshot.
+	SnapshotChecksumLength = blake2b.Size256
+)
+
+var (
+	// ErrSnapshotVersionUnsupported is returned if a snapshot was written with an unknown version of the format.
+	ErrSnapshotVersionUnsupported = errors.New("unsupported snapshot version")
+
+	// ErrSnapshotChecksumMismatch is returned if the checksum of a snapshot does not match its content.
+	ErrSnapshotChecksumMismatch = errors.New("snapshot checksum mismatch")
+
+	// ErrSnapshotMalformed is returned if the content of a snapshot can not be parsed.
+	ErrSnapshotMalformed = errors.New("malformed snapshot")
+)
+
+// Snapshot defines a set of outputs (grouped by the transaction that created them) that form the genesis of the
+// ledger state.
+type Snapshot map[transaction.ID]map[address.Address][]*balance.Balance
+
+// SnapshotFromBytes unmarshals a Snapshot from a sequence of bytes. The bytes have to contain exactly one snapshot, as
+// its version and the checksum over the raw bytes are verified before its content is parsed.
+func SnapshotFromBytes(snapshotBytes []byte) (result Snapshot, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(snapshotBytes)
+	result, err = ParseSnapshot(marshalUtil)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseSnapshot unmarshals a Snapshot from the remaining bytes of the given marshalUtil (for easier
+// marshaling/unmarshaling). The version and the checksum are verified before the content is parsed and the counts of
+// the content are bounded by the number of remaining bytes, so corrupted snapshots can not cause huge allocations.
+func ParseSnapshot(marshalUtil *marshalutil.MarshalUtil) (result Snapshot, err error) {
+	snapshotBytes := marshalUtil.ReadRemainingBytes()
+	if len(snapshotBytes) < 1+SnapshotChecksumLength {
+		err = fmt.Errorf("%w: %d bytes are too short for a snapshot", ErrSnapshotMalformed, len(snapshotBytes))
+
+		return
+	}
+	if version := snapshotBytes[0]; version != SnapshotVersion {
+		err = fmt.Errorf("%w: %d", ErrSnapshotVersionUnsupported, version)
+
+		return
+	}
+
+	// verify the checksum of the content
+	content := snapshotBytes[:len(snapshotBytes)-SnapshotChecksumLength]
+	if expectedChecksum := blake2b.Sum256(content); !bytes.Equal(snapshotBytes[len(content):], expectedChecksum[:]) {
+		err = ErrSnapshotChecksumMismatch
+
+		return
+	}
+
+	contentUtil := marshalutil.New(content)
+	contentUtil.ReadSeek(1)
+
+	// readCount reads a count and checks that the remaining content can hold the counted elements
+	readCount := func(minElementSize int) (count uint32, countErr error) {
+		if count, countErr = contentUtil.ReadUint32(); countErr != nil {
+			return
+		}
+		if remainingBytes := len(content) - contentUtil.ReadOffset(); uint64(count)*uint64(minElementSize) > uint64(remainingBytes) {
+			countErr = fmt.Errorf("%w: %d elements do not fit into the remaining %d bytes", ErrSnapshotMalformed, count, remainingBytes)
+		}
+
+		return
+	}
+
+	transactionCount, err := readCount(transaction.IDLength + marshalutil.UINT32_SIZE)
+	if err != nil {
+		return
+	}
+
+	result = make(Snapshot, transactionCount)
+	for i := uint32(0); i < transactionCount; i++ {
+		transactionID, transactionIDErr := transaction.ParseID(contentUtil)
+		if transactionIDErr != nil {
+			err = transactionIDErr
+
+			return
+		}
+
+		addressCount, addressCountErr := readCount(address.Length + marshalutil.UINT32_SIZE)
+		if addressCountErr != nil {
+			err = addressCountErr
+
+			return
+		}
+
+		addressBalances := make(map[address.Address][]*balance.Balance, addressCount)
+		for j := uint32(0); j < addressCount; j++ {
+			outputAddress, addressErr := address.Parse(contentUtil)
+			if addressErr != nil {
+				err = addressErr
+
+				return
+			}
+
+			balanceCount, balanceCountErr := readCount(balance.Length)
+			if balanceCountErr != nil {
+				err = balanceCountErr
+
+				return
+			}
+
+			balances := make([]*balance.Balance, balanceCount)
+			for k := uint32(0); k < balanceCount; k++ {
+				if balances[k], err = balance.Parse(contentUtil); err != nil {
+					return
+				}
+			}
+
+			addressBalances[outputAddress] = balances
+		}
+
+		result[transactionID] = addressBalances
+	}
+
+	if contentUtil.ReadOffset() != len(content) {
+		err = fmt.Errorf("%w: %d unexpected bytes after the content", ErrSnapshotMalformed, len(content)-contentUtil.ReadOffset())
+	}
+
+	return
+}
+
+// Bytes returns a marshaled version of the Snapshot. Transactions and addresses are written in a deterministic order,
+// so that the same Snapshot always results in the same bytes (and the same checksum).
+func (snapshot Snapshot) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+
+	marshalUtil.WriteByte(SnapshotVersion)
+	marshalUtil.WriteUint32(uint32(len(snapshot)))
+	for _, transactionID := range snapshot.sortedTransactionIDs() {
+		addressBalances := snapshot[transactionID]
+
+		marshalUtil.WriteBytes(transactionID.Bytes())
+		marshalUtil.WriteUint32(uint32(len(addressBalances)))
+		for _, outputAddress := range sortedAddresses(addressBalances) {
+			balances := addressBalances[outputAddress]
+
+			marshalUtil.WriteBytes(outputAddress.Bytes())
+			marshalUtil.WriteUint32(uint32(len(balances)))
+			for _, coloredBalance := range balances {
+				marshalUtil.WriteBytes(coloredBalance.Bytes())
+			}
+		}
+	}
+
+	checksum := blake2b.Sum256(marshalUtil.Bytes())
+	marshalUtil.WriteBytes(checksum[:])
+
+	return marshalUtil.Bytes()
+}
+
+// WriteTo writes the marshaled Snapshot to the given writer.
+func (snapshot Snapshot) WriteTo(writer io.Writer) (int64, error) {
+	bytesWritten, err := writer.Write(snapshot.Bytes())
+
+	return int64(bytesWritten), err
+}
+
+// ReadFrom reads a marshaled Snapshot from the given reader and replaces the content of the Snapshot with it.
+func (snapshot *Snapshot) ReadFrom(reader io.Reader) (int64, error) {
+	snapshotBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return int64(len(snapshotBytes)), err
+	}
+
+	parsedSnapshot, consumedBytes, err := SnapshotFromBytes(snapshotBytes)
+	if err != nil {
+		return int64(consumedBytes), err
+	}
+	*snapshot = parsedSnapshot
+
+	return int64(consumedBytes), nil
+}
+
+func (snapshot Snapshot) sortedTransactionIDs() (transactionIDs []transaction.ID) {
+	transactionIDs = make([]transaction.ID, 0, len(snapshot))
+	for transactionID := range snapshot {
+		transactionIDs = append(transactionIDs, transactionID)
+	}
+
+	sort.Slice(transactionIDs, func(i, j int) bool {
+		return bytes.Compare(transactionIDs[i][:], transactionIDs[j][:]) < 0
+	})
+
+	return
+}
+
+func sortedAddresses(addressBalances map[address.Address][]*balance.Balance) (addresses []address.Address) {
+	addresses = make([]address.Address, 0, len(addressBalances))
+	for outputAddress := range addressBalances {
+		addresses = append(addresses, outputAddress)
+	}
+
+	sort.Slice(addresses, func(i, j int) bool {
+		return bytes.Compare(addresses[i][:], addresses[j][:]) < 0
+	})
+
+	return
+}
@@ -0,0 +1,84 @@
+package tangle
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func TestSnapshot(t *testing.T) {
+	snapshot := Snapshot{
+		transaction.GenesisID: {
+			address.Random(): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 337),
+			},
+			address.Random(): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1000),
+				balance.New(balance.Color{1}, 1000),
+			},
+		},
+	}
+
+	// the marshaled version needs to be deterministic
+	snapshotBytes := snapshot.Bytes()
+	assert.Equal(t, snapshotBytes, snapshot.Bytes())
+
+	clonedSnapshot, consumedBytes, err := SnapshotFromBytes(snapshotBytes)
+	require.NoError(t, err)
+	assert.Equal(t, len(snapshotBytes), consumedBytes)
+	assert.Equal(t, snapshot, clonedSnapshot)
+
+	// write and read the snapshot using the io interfaces
+	var buffer bytes.Buffer
+	written, err := snapshot.WriteTo(&buffer)
+	require.NoError(t, err)
+	assert.EqualValues(t, len(snapshotBytes), written)
+
+	readSnapshot := Snapshot{}
+	read, err := readSnapshot.ReadFrom(&buffer)
+	require.NoError(t, err)
+	assert.Equal(t, written, read)
+	assert.Equal(t, snapshot, readSnapshot)
+}
+
+func TestSnapshotCorrupted(t *testing.T) {
+	snapshot := Snapshot{
+		transaction.GenesisID: {
+			address.Random(): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1337),
+			},
+		},
+	}
+
+	// modify the balance in the marshaled snapshot
+	tamperedBytes := snapshot.Bytes()
+	tamperedBytes[len(tamperedBytes)-SnapshotChecksumLength-balance.Length]++
+	_, _, err := SnapshotFromBytes(tamperedBytes)
+	assert.True(t, errors.Is(err, ErrSnapshotChecksumMismatch))
+
+	// modify the version of the snapshot
+	unsupportedBytes := snapshot.Bytes()
+	unsupportedBytes[0] = SnapshotVersion + 1
+	_, _, err = SnapshotFromBytes(unsupportedBytes)
+	assert.True(t, errors.Is(err, ErrSnapshotVersionUnsupported))
+
+	// cut off the checksum
+	_, _, err = SnapshotFromBytes(snapshot.Bytes()[:len(tamperedBytes)-1])
+	assert.Error(t, err)
+
+	// counts that exceed the size of the snapshot are rejected even if the checksum is valid
+	oversizedContent := marshalutil.New().WriteByte(SnapshotVersion).WriteUint32(math.MaxUint32).Bytes()
+	oversizedChecksum := blake2b.Sum256(oversizedContent)
+	_, _, err = SnapshotFromBytes(append(oversizedContent, oversizedChecksum[:]...))
+	assert.True(t, errors.Is(err, ErrSnapshotMalformed), "unexpected error: %v", err)
+}
@@ -0,0 +1,221 @@
+package tangle
+
+import (
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// SupplyChangePartitionKeys defines the "layout" of the key. This enables prefix iterations in the objectstorage.
+var SupplyChangePartitionKeys = objectstorage.PartitionKey([]int{balance.ColorLength, transaction.IDLength}...)
+
+// SupplyChange stores by how much a transaction changed the supply of a certain color. Tokens are minted when new
+// colors are created and burned when colored tokens are converted back to IOTA.
+type SupplyChange struct {
+	objectstorage.StorableObjectFlags
+
+	color         balance.Color
+	transactionID transaction.ID
+	delta         int64
+	minting       bool
+
+	storageKey []byte
+}
+
+// NewSupplyChange creates a SupplyChange object with the given information.
+func NewSupplyChange(color balance.Color, transactionID transaction.ID, delta int64, minting bool) *SupplyChange {
+	return &SupplyChange{
+		color:         color,
+		transactionID: transactionID,
+		delta:         delta,
+		minting:       minting,
+
+		storageKey: marshalutil.New(balance.ColorLength + transaction.IDLength).
+			WriteBytes(color.Bytes()).
+			WriteBytes(transactionID.Bytes()).
+			Bytes(),
+	}
+}
+
+// SupplyChangeFromBytes unmarshals a SupplyChange from a sequence of bytes - it either creates a new object or fills the
+// optionally provided one with the parsed information.
+func SupplyChangeFromBytes(bytes []byte, optionalTargetObject ...*SupplyChange) (result *SupplyChange, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(bytes)
+	result, err = ParseSupplyChange(marshalUtil, optionalTargetObject...)
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseSupplyChange unmarshals a SupplyChange using the given marshalUtil (for easier marshaling/unmarshaling).
+func ParseSupplyChange(marshalUtil *marshalutil.MarshalUtil, optionalTargetObject ...*SupplyChange) (result *SupplyChange, err error) {
+	parsedObject, parseErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) {
+		return SupplyChangeFromStorageKey(data, optionalTargetObject...)
+	})
+	if parseErr != nil {
+		err = parseErr
+
+		return
+	}
+
+	result = parsedObject.(*SupplyChange)
+	_, err = marshalUtil.Parse(func(data []byte) (parseResult interface{}, parsedBytes int, parseErr error) {
+		parsedBytes, parseErr = result.UnmarshalObjectStorageValue(data)
+
+		return
+	})
+
+	return
+}
+
+// SupplyChangeFromStorageKey is a factory method that creates a new SupplyChange instance from a storage key of the
+// objectstorage. It is used by the objectstorage, to create new instances of this entity.
+func SupplyChangeFromStorageKey(key []byte, optionalTargetObject ...*SupplyChange) (result *SupplyChange, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &SupplyChange{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to SupplyChangeFromStorageKey")
+	}
+
+	// parse the properties that are stored in the key
+	marshalUtil := marshalutil.New(key)
+	if result.color, err = balance.ParseColor(marshalUtil); err != nil {
+		return
+	}
+	if result.transactionID, err = transaction.ParseID(marshalUtil); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+	result.storageKey = marshalutil.New(key[:consumedBytes]).Bytes(true)
+
+	return
+}
+
+// Color returns the Color whose supply was changed.
+func (supplyChange *SupplyChange) Color() balance.Color {
+	return supplyChange.color
+}
+
+// TransactionID returns the ID of the transaction that changed the supply.
+func (supplyChange *SupplyChange) TransactionID() transaction.ID {
+	return supplyChange.transactionID
+}
+
+// Delta returns the amount of tokens that were added to (positive) or removed from (negative) the supply.
+func (supplyChange *SupplyChange) Delta() int64 {
+	return supplyChange.delta
+}
+
+// Minting returns true if the transaction created the Color (or if it is part of the genesis).
+func (supplyChange *SupplyChange) Minting() bool {
+	return supplyChange.minting
+}
+
+// Bytes marshals the SupplyChange into a sequence of bytes.
+func (supplyChange *SupplyChange) Bytes() []byte {
+	return marshalutil.New().
+		WriteBytes(supplyChange.ObjectStorageKey()).
+		WriteBytes(supplyChange.ObjectStorageValue()).
+		Bytes()
+}
+
+// String returns a human readable version of the SupplyChange.
+func (supplyChange *SupplyChange) String() string {
+	return stringify.Struct("SupplyChange",
+		stringify.StructField("color", supplyChange.Color()),
+		stringify.StructField("transactionId", supplyChange.TransactionID()),
+		stringify.StructField("delta", supplyChange.Delta()),
+		stringify.StructField("minting", supplyChange.Minting()),
+	)
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database.
+func (supplyChange *SupplyChange) ObjectStorageKey() []byte {
+	return supplyChange.storageKey
+}
+
+// ObjectStorageValue marshals the "content part" of a SupplyChange to a sequence of bytes.
+func (supplyChange *SupplyChange) ObjectStorageValue() []byte {
+	return marshalutil.New(marshalutil.INT64_SIZE + marshalutil.BOOL_SIZE).
+		WriteInt64(supplyChange.delta).
+		WriteBool(supplyChange.minting).
+		Bytes()
+}
+
+// UnmarshalObjectStorageValue unmarshals the "content part" of a SupplyChange from a sequence of bytes.
+func (supplyChange *SupplyChange) UnmarshalObjectStorageValue(data []byte) (consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(data)
+	if supplyChange.delta, err = marshalUtil.ReadInt64(); err != nil {
+		return
+	}
+	if supplyChange.minting, err = marshalUtil.ReadBool(); err != nil {
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// Update is disabled - a SupplyChange is written once when the transaction gets booked.
+func (supplyChange *SupplyChange) Update(other objectstorage.StorableObject) {
+	panic("update forbidden")
+}
+
+// Interface contract: make compiler warn if the interface is not implemented correctly.
+var _ objectstorage.StorableObject = &SupplyChange{}
+
+// region CachedSupplyChange ///////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedSupplyChange is a wrapper for the generic CachedObject returned by the objectstorage, that overrides the
+// accessor methods, with a type-casted one.
+type CachedSupplyChange struct {
+	objectstorage.CachedObject
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (cachedSupplyChange *CachedSupplyChange) Unwrap() *SupplyChange {
+	untypedObject := cachedSupplyChange.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*SupplyChange)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer (if the object is not empty - it
+// exists). It automatically releases the object when the consumer finishes.
+func (cachedSupplyChange *CachedSupplyChange) Consume(consumer func(supplyChange *SupplyChange)) (consumed bool) {
+	return cachedSupplyChange.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*SupplyChange))
+	})
+}
+
+// CachedSupplyChanges represents a collection of CachedSupplyChanges.
+type CachedSupplyChanges []*CachedSupplyChange
+
+// Consume iterates over the CachedObjects, unwraps them and passes a type-casted version to the consumer (if the object
+// is not empty - it exists). It automatically releases the object when the consumer finishes. It returns true, if at
+// least one object was consumed.
+func (cachedSupplyChanges CachedSupplyChanges) Consume(consumer func(supplyChange *SupplyChange)) (consumed bool) {
+	for _, cachedSupplyChange := range cachedSupplyChanges {
+		consumed = cachedSupplyChange.Consume(func(supplyChange *SupplyChange) {
+			consumer(supplyChange)
+		}) || consumed
+	}
+
+	return
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -1,10 +1,12 @@
 package tangle
 
 import (
+	"bytes"
 	"container/list"
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/iotaledger/hive.go/async"
@@ -35,6 +37,9 @@ type Tangle struct {
 	attachmentStorage          *objectstorage.ObjectStorage
 	outputStorage              *objectstorage.ObjectStorage
 	consumerStorage            *objectstorage.ObjectStorage
+	supplyChangeStorage        *objectstorage.ObjectStorage
+	addressTransactionStorage  *objectstorage.ObjectStorage
+	branchTransactionStorage   *objectstorage.ObjectStorage
 
 	Events Events
 
@@ -58,6 +63,9 @@ func New(store kvstore.KVStore) (result *Tangle) {
 		attachmentStorage:          osFactory.New(osAttachment, osAttachmentFactory, objectstorage.CacheTime(time.Second), objectstorage.PartitionKey(transaction.IDLength, payload.IDLength), osLeakDetectionOption),
 		outputStorage:              osFactory.New(osOutput, osOutputFactory, OutputKeyPartitions, objectstorage.CacheTime(time.Second), osLeakDetectionOption),
 		consumerStorage:            osFactory.New(osConsumer, osConsumerFactory, ConsumerPartitionKeys, objectstorage.CacheTime(time.Second), osLeakDetectionOption),
+		supplyChangeStorage:        osFactory.New(osSupplyChange, osSupplyChangeFactory, SupplyChangePartitionKeys, objectstorage.CacheTime(time.Second), osLeakDetectionOption),
+		addressTransactionStorage:  osFactory.New(osAddressTransaction, osAddressTransactionFactory, AddressTransactionPartitionKeys, objectstorage.CacheTime(time.Second), osLeakDetectionOption),
+		branchTransactionStorage:   osFactory.New(osBranchTransaction, osBranchTransactionFactory, BranchTransactionPartitionKeys, objectstorage.CacheTime(time.Second), objectstorage.KeysOnly(true), osLeakDetectionOption),
 
 		Events: *newEvents(),
 	}
@@ -107,6 +115,19 @@ func (tangle *Tangle) TransactionMetadata(transactionID transaction.ID) *CachedT
 	return &CachedTransactionMetadata{CachedObject: tangle.transactionMetadataStorage.Load(transactionID.Bytes())}
 }
 
+// TransactionsInBranch returns the transactions that are booked into the given Branch.
+func (tangle *Tangle) TransactionsInBranch(branchID branchmanager.BranchID) (transactionIDs []transaction.ID) {
+	tangle.branchTransactionStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedBranchTransaction{CachedObject: cachedObject}).Consume(func(branchTransaction *BranchTransaction) {
+			transactionIDs = append(transactionIDs, branchTransaction.TransactionID())
+		})
+
+		return true
+	}, branchID.Bytes())
+
+	return
+}
+
 // TransactionOutput loads the given output from the objectstorage.
 func (tangle *Tangle) TransactionOutput(outputID transaction.OutputID) *CachedOutput {
 	return &CachedOutput{CachedObject: tangle.outputStorage.Load(outputID.Bytes())}
@@ -124,6 +145,66 @@ func (tangle *Tangle) Consumers(outputID transaction.OutputID) CachedConsumers {
 	return consumers
 }
 
+// SupplyChanges retrieves the changes of the token supply of the given color (or of all colors if no color is given)
+// from the object storage.
+func (tangle *Tangle) SupplyChanges(optionalColor ...balance.Color) CachedSupplyChanges {
+	var prefixes [][]byte
+	if len(optionalColor) >= 1 {
+		prefixes = append(prefixes, optionalColor[0].Bytes())
+	}
+
+	supplyChanges := make(CachedSupplyChanges, 0)
+	tangle.supplyChangeStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		supplyChanges = append(supplyChanges, &CachedSupplyChange{CachedObject: cachedObject})
+
+		return true
+	}, prefixes...)
+
+	return supplyChanges
+}
+
+// AddressTransactions retrieves the references to the transactions that spent from or created outputs on the given
+// address from the object storage. The references are sorted by time and only the ones in the requested window are
+// loaded (a limit of 0 loads all of them). It returns the total number of references of the address as well.
+func (tangle *Tangle) AddressTransactions(address address.Address, offset int, limit int) (addressTransactions CachedAddressTransactions, total int) {
+	keys := tangle.addressTransactionKeys(address)
+	total = len(keys)
+	if offset >= total {
+		return
+	}
+	keys = keys[offset:]
+	if limit > 0 && limit < len(keys) {
+		keys = keys[:limit]
+	}
+
+	addressTransactions = make(CachedAddressTransactions, len(keys))
+	for i, key := range keys {
+		addressTransactions[i] = &CachedAddressTransaction{CachedObject: tangle.addressTransactionStorage.Load(key)}
+	}
+
+	return
+}
+
+// AddressTransactionCount returns the number of transactions that spent from or created outputs on the given address.
+func (tangle *Tangle) AddressTransactionCount(address address.Address) int {
+	return len(tangle.addressTransactionKeys(address))
+}
+
+// addressTransactionKeys returns the sorted storage keys of the references of the given address. Only the keys are
+// iterated, so the references do not have to be loaded.
+func (tangle *Tangle) addressTransactionKeys(address address.Address) (keys [][]byte) {
+	tangle.addressTransactionStorage.ForEachKeyOnly(func(key []byte) bool {
+		keys = append(keys, key)
+
+		return true
+	}, false, address.Bytes())
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+
+	return
+}
+
 // Attachments retrieves the attachment of a payload from the object storage.
 func (tangle *Tangle) Attachments(transactionID transaction.ID) CachedAttachments {
 	attachments := make(CachedAttachments, 0)
@@ -136,9 +217,10 @@ func (tangle *Tangle) Attachments(transactionID transaction.ID) CachedAttachment
 	return attachments
 }
 
-// AttachPayload adds a new payload to the value tangle.
-func (tangle *Tangle) AttachPayload(payload *payload.Payload) {
-	tangle.workerPool.Submit(func() { tangle.AttachPayloadSync(payload) })
+// AttachPayload adds a new payload to the value tangle. The issuing time is the time of the message that attached the
+// payload and is used to evaluate the conditions of the outputs that the transaction of the payload spends.
+func (tangle *Tangle) AttachPayload(payload *payload.Payload, issuingTime time.Time) {
+	tangle.workerPool.Submit(func() { tangle.AttachPayloadSync(payload, issuingTime) })
 }
 
 // SetTransactionFinalized modifies the finalized flag of a transaction. It updates the transactions metadata and
@@ -351,6 +433,9 @@ func (tangle *Tangle) Shutdown() *Tangle {
 		tangle.attachmentStorage,
 		tangle.outputStorage,
 		tangle.consumerStorage,
+		tangle.supplyChangeStorage,
+		tangle.addressTransactionStorage,
+		tangle.branchTransactionStorage,
 	} {
 		storage.Shutdown()
 	}
@@ -374,6 +459,9 @@ func (tangle *Tangle) Prune() (err error) {
 		tangle.attachmentStorage,
 		tangle.outputStorage,
 		tangle.consumerStorage,
+		tangle.supplyChangeStorage,
+		tangle.addressTransactionStorage,
+		tangle.branchTransactionStorage,
 	} {
 		if err = storage.Prune(); err != nil {
 			return
@@ -383,10 +471,169 @@ func (tangle *Tangle) Prune() (err error) {
 	return
 }
 
+// PruneOlderThan deletes the value objects that were finalized (and liked) and that became solid before the given
+// time. It returns the amount of deleted payloads and transactions. Transactions are only deleted once all of their
+// outputs have been spent by finalized transactions, so the set of unspent outputs and the branch DAG stay untouched.
+func (tangle *Tangle) PruneOlderThan(cutoff time.Time) (prunedPayloads int, prunedTransactions int) {
+	// collect the payloads first, so we do not modify the storage while iterating it
+	var payloadsToDelete []payload.ID
+	tangle.payloadMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedPayloadMetadata{CachedObject: cachedObject}).Consume(func(payloadMetadata *PayloadMetadata) {
+			if payloadMetadata.IsSolid() && payloadMetadata.SoldificationTime().Before(cutoff) && tangle.payloadPrunable(payloadMetadata.PayloadID()) {
+				payloadsToDelete = append(payloadsToDelete, payloadMetadata.PayloadID())
+			}
+		})
+
+		return true
+	})
+	for _, payloadID := range payloadsToDelete {
+		tangle.deletePayload(payloadID)
+	}
+
+	var transactionsToCheck []transaction.ID
+	tangle.transactionMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		(&CachedTransactionMetadata{CachedObject: cachedObject}).Consume(func(transactionMetadata *TransactionMetadata) {
+			if transactionMetadata.Finalized() && transactionMetadata.Preferred() && transactionMetadata.SoldificationTime().Before(cutoff) {
+				transactionsToCheck = append(transactionsToCheck, transactionMetadata.ID())
+			}
+		})
+
+		return true
+	})
+	for _, transactionID := range transactionsToCheck {
+		if tangle.pruneTransaction(transactionID) {
+			prunedTransactions++
+		}
+	}
+
+	return len(payloadsToDelete), prunedTransactions
+}
+
+// payloadPrunable checks if the transaction of the given payload is finalized and liked and if the payload has been
+// approved by solid payloads only (tips and payloads with unsolid approvers are kept for the solidification). Approvers
+// without metadata have been pruned already (the metadata is stored before the approvers are).
+func (tangle *Tangle) payloadPrunable(payloadID payload.ID) (prunable bool) {
+	tangle.Payload(payloadID).Consume(func(payload *payload.Payload) {
+		tangle.TransactionMetadata(payload.Transaction().ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+			prunable = transactionMetadata.Finalized() && transactionMetadata.Preferred()
+		})
+	})
+	if !prunable {
+		return
+	}
+
+	approvers := tangle.Approvers(payloadID)
+	prunable = len(approvers) != 0
+	approvers.Consume(func(approver *PayloadApprover) {
+		cachedApproverMetadata := tangle.PayloadMetadata(approver.ApprovingPayloadID())
+		defer cachedApproverMetadata.Release()
+
+		if approverMetadata := cachedApproverMetadata.Unwrap(); approverMetadata != nil && !approverMetadata.IsSolid() {
+			prunable = false
+		}
+	})
+
+	return
+}
+
+// deletePayload removes the given payload together with its metadata, its approvers and its attachment. The references
+// of its parents to the payload are kept, so the parents are not mistaken for tips - they are removed together with the
+// parents once these are pruned.
+func (tangle *Tangle) deletePayload(payloadID payload.ID) {
+	tangle.Payload(payloadID).Consume(func(payload *payload.Payload) {
+		tangle.attachmentStorage.Delete(NewAttachment(payload.Transaction().ID(), payloadID).ObjectStorageKey())
+	})
+
+	tangle.Approvers(payloadID).Consume(func(approver *PayloadApprover) {
+		tangle.approverStorage.Delete(approver.ObjectStorageKey())
+	})
+	tangle.payloadMetadataStorage.Delete(payloadID.Bytes())
+	tangle.payloadStorage.Delete(payloadID.Bytes())
+}
+
+// pruneTransaction deletes the spent outputs of the given transaction if all of their consumers are finalized. The
+// transaction itself is deleted once it has no attachments and no outputs anymore. It returns true if the transaction
+// was deleted.
+func (tangle *Tangle) pruneTransaction(transactionID transaction.ID) (pruned bool) {
+	if tangle.Attachments(transactionID).Consume(func(*Attachment) {}) {
+		return
+	}
+
+	allOutputsDeleted := true
+	tangle.Transaction(transactionID).Consume(func(tx *transaction.Transaction) {
+		tx.Outputs().ForEach(func(address address.Address, balances []*balance.Balance) bool {
+			outputID := transaction.NewOutputID(address, transactionID)
+			if !tangle.outputPrunable(outputID) {
+				allOutputsDeleted = false
+
+				return true
+			}
+
+			tangle.Consumers(outputID).Consume(func(consumer *Consumer) {
+				tangle.consumerStorage.Delete(consumer.ObjectStorageKey())
+			})
+			tangle.outputStorage.Delete(outputID.Bytes())
+
+			return true
+		})
+	})
+	if !allOutputsDeleted {
+		return
+	}
+
+	tangle.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+		tangle.Transaction(transactionID).Consume(func(tx *transaction.Transaction) {
+			tangle.deleteAddressTransactions(tx, transactionMetadata.SoldificationTime())
+		})
+		tangle.branchTransactionStorage.Delete(NewBranchTransaction(transactionMetadata.BranchID(), transactionID).ObjectStorageKey())
+	})
+
+	tangle.transactionMetadataStorage.Delete(transactionID.Bytes())
+	tangle.transactionStorage.Delete(transactionID.Bytes())
+
+	return true
+}
+
+// deleteAddressTransactions removes the references from the addresses of the inputs and outputs of the given
+// transaction to the transaction. The timestamp has to match the one that the references were stored with.
+func (tangle *Tangle) deleteAddressTransactions(tx *transaction.Transaction, timestamp time.Time) {
+	tx.Inputs().ForEachAddress(func(address address.Address) bool {
+		tangle.addressTransactionStorage.Delete(NewAddressTransaction(address, tx.ID(), timestamp, false, false).ObjectStorageKey())
+
+		return true
+	})
+	tx.Outputs().ForEach(func(address address.Address, balances []*balance.Balance) bool {
+		tangle.addressTransactionStorage.Delete(NewAddressTransaction(address, tx.ID(), timestamp, false, false).ObjectStorageKey())
+
+		return true
+	})
+}
+
+// outputPrunable checks if the given output was spent and if all of its consumers are finalized (or pruned already).
+func (tangle *Tangle) outputPrunable(outputID transaction.OutputID) (prunable bool) {
+	tangle.TransactionOutput(outputID).Consume(func(output *Output) {
+		prunable = output.ConsumerCount() != 0
+	})
+	if !prunable {
+		return
+	}
+
+	tangle.Consumers(outputID).Consume(func(consumer *Consumer) {
+		cachedConsumerMetadata := tangle.TransactionMetadata(consumer.TransactionID())
+		defer cachedConsumerMetadata.Release()
+
+		if consumerMetadata := cachedConsumerMetadata.Unwrap(); consumerMetadata != nil && !consumerMetadata.Finalized() {
+			prunable = false
+		}
+	})
+
+	return
+}
+
 // AttachPayloadSync is the worker function that stores the payload and calls the corresponding storage events.
-func (tangle *Tangle) AttachPayloadSync(payloadToStore *payload.Payload) {
+func (tangle *Tangle) AttachPayloadSync(payloadToStore *payload.Payload, issuingTime time.Time) {
 	// store the payload models or abort if we have seen the payload already
-	cachedPayload, cachedPayloadMetadata, payloadStored := tangle.storePayload(payloadToStore)
+	cachedPayload, cachedPayloadMetadata, payloadStored := tangle.storePayload(payloadToStore, issuingTime)
 	if !payloadStored {
 		return
 	}
@@ -419,14 +666,14 @@ func (tangle *Tangle) AttachPayloadSync(payloadToStore *payload.Payload) {
 	tangle.solidifyPayload(cachedPayload.Retain(), cachedPayloadMetadata.Retain(), cachedTransaction.Retain(), cachedTransactionMetadata.Retain())
 }
 
-func (tangle *Tangle) storePayload(payloadToStore *payload.Payload) (cachedPayload *payload.CachedPayload, cachedMetadata *CachedPayloadMetadata, payloadStored bool) {
+func (tangle *Tangle) storePayload(payloadToStore *payload.Payload, issuingTime time.Time) (cachedPayload *payload.CachedPayload, cachedMetadata *CachedPayloadMetadata, payloadStored bool) {
 	storedTransaction, transactionIsNew := tangle.payloadStorage.StoreIfAbsent(payloadToStore)
 	if !transactionIsNew {
 		return
 	}
 
 	cachedPayload = &payload.CachedPayload{CachedObject: storedTransaction}
-	cachedMetadata = &CachedPayloadMetadata{CachedObject: tangle.payloadMetadataStorage.Store(NewPayloadMetadata(payloadToStore.ID()))}
+	cachedMetadata = &CachedPayloadMetadata{CachedObject: tangle.payloadMetadataStorage.Store(NewPayloadMetadata(payloadToStore.ID(), issuingTime))}
 	payloadStored = true
 
 	return
@@ -511,8 +758,8 @@ func (tangle *Tangle) processSolidificationStackEntry(solidificationStack *list.
 		return
 	}
 
-	// abort if the transaction is not solid or invalid
-	transactionSolid, consumedBranches, transactionSolidityErr := tangle.checkTransactionSolidity(currentTransaction, currentTransactionMetadata)
+	// abort if the transaction is not solid or invalid at the time of this attachment
+	transactionSolid, consumedBranches, transactionSolidityErr := tangle.checkTransactionSolidity(currentTransaction, currentTransactionMetadata, currentPayloadMetadata.IssuingTime())
 	if transactionSolidityErr != nil {
 		// TODO: TRIGGER INVALID TX + REMOVE TXS + PAYLOADS THAT APPROVE IT
 
@@ -566,6 +813,24 @@ func (tangle *Tangle) book(entitiesToBook *valuePayloadPropagationStackEntry) (t
 	return
 }
 
+// setTransactionBranchID books the transaction into the given Branch and moves its reference in the branch index. It
+// returns true if the Branch of the transaction was modified.
+func (tangle *Tangle) setTransactionBranchID(transactionMetadata *TransactionMetadata, branchID branchmanager.BranchID) (modified bool) {
+	previousBranchID := transactionMetadata.BranchID()
+	if !transactionMetadata.SetBranchID(branchID) {
+		return
+	}
+
+	if previousBranchID != branchmanager.UndefinedBranchID {
+		tangle.branchTransactionStorage.Delete(NewBranchTransaction(previousBranchID, transactionMetadata.ID()).ObjectStorageKey())
+	}
+	if cachedBranchTransaction, stored := tangle.branchTransactionStorage.StoreIfAbsent(NewBranchTransaction(branchID, transactionMetadata.ID())); stored {
+		cachedBranchTransaction.Release()
+	}
+
+	return true
+}
+
 func (tangle *Tangle) bookTransaction(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *CachedTransactionMetadata) (transactionBooked bool, decisionPending bool, err error) {
 	defer cachedTransaction.Release()
 	defer cachedTransactionMetadata.Release()
@@ -592,6 +857,7 @@ func (tangle *Tangle) bookTransaction(cachedTransaction *transaction.CachedTrans
 	}
 
 	consumedBranches := make(branchmanager.BranchIds)
+	consumedBalances := make(map[balance.Color]int64)
 	conflictingInputs := make([]transaction.OutputID, 0)
 	conflictingInputsOfFirstConsumers := make(map[transaction.ID][]transaction.OutputID)
 
@@ -608,6 +874,9 @@ func (tangle *Tangle) bookTransaction(cachedTransaction *transaction.CachedTrans
 		}
 
 		consumedBranches[output.BranchID()] = types.Void
+		for _, consumedBalance := range output.Balances() {
+			consumedBalances[consumedBalance.Color()] += consumedBalance.Value()
+		}
 
 		// register the current consumer and check if the input has been consumed before
 		consumerCount, firstConsumerID := output.RegisterConsumer(transactionToBook.ID())
@@ -660,16 +929,24 @@ func (tangle *Tangle) bookTransaction(cachedTransaction *transaction.CachedTrans
 	}
 
 	// book transaction into target branch
-	transactionMetadata.SetBranchID(targetBranch.ID())
+	tangle.setTransactionBranchID(transactionMetadata, targetBranch.ID())
 
 	// book outputs into the target branch
+	createdBalances := make(map[balance.Color]int64)
 	transactionToBook.Outputs().ForEach(func(address address.Address, balances []*balance.Balance) bool {
-		newOutput := NewOutput(address, transactionToBook.ID(), targetBranch.ID(), balances)
+		coloredBalances := recolorBalances(transactionToBook.ID(), balances)
+		for _, coloredBalance := range coloredBalances {
+			createdBalances[coloredBalance.Color()] += coloredBalance.Value()
+		}
+
+		newOutput := NewOutput(address, transactionToBook.ID(), targetBranch.ID(), coloredBalances, transactionToBook.Outputs().Conditions(address))
 		newOutput.SetSolid(true)
 		tangle.outputStorage.Store(newOutput).Release()
 
 		return true
 	})
+	tangle.storeSupplyChanges(transactionToBook.ID(), consumedBalances, createdBalances)
+	tangle.storeAddressTransactions(transactionToBook, transactionMetadata.SoldificationTime())
 
 	// fork the conflicting transactions into their own branch
 	for consumerID, conflictingInputs := range conflictingInputsOfFirstConsumers {
@@ -719,6 +996,10 @@ func (tangle *Tangle) bookPayload(cachedPayload *payload.CachedPayload, cachedPa
 		return
 	}
 
+	// the payload passed the solidity checks before it got booked
+	if valueObjectMetadata.SetSolid(true) {
+		tangle.Events.PayloadSolid.Trigger(cachedPayload, cachedPayloadMetadata)
+	}
 	payloadBooked = valueObjectMetadata.SetBranchID(aggregatedBranch.ID())
 
 	return
@@ -746,8 +1027,6 @@ func (tangle *Tangle) payloadBranchID(payloadID payload.ID) branchmanager.Branch
 
 	payloadMetadata := cachedPayloadMetadata.Unwrap()
 	if payloadMetadata == nil {
-		cachedPayloadMetadata.Release()
-
 		// if transaction is missing and was not reported as missing, yet
 		if cachedMissingPayload, missingPayloadStored := tangle.missingPayloadStorage.StoreIfAbsent(NewMissingPayload(payloadID)); missingPayloadStored {
 			cachedMissingPayload.Consume(func(object objectstorage.StorableObject) {
@@ -802,14 +1081,31 @@ func (tangle *Tangle) checkPayloadSolidity(payload *payload.Payload, payloadMeta
 	return
 }
 
-func (tangle *Tangle) checkTransactionSolidity(tx *transaction.Transaction, metadata *TransactionMetadata) (solid bool, consumedBranches []branchmanager.BranchID, err error) {
+// checkTransactionSolidity checks if the given transaction is solid and valid. The conditions of the consumed outputs
+// are evaluated at the given reference time (the issuing time of the attachment). They are checked for every attachment,
+// even if the transaction is solid already, so that an attachment that was issued too early is never booked - no matter
+// in which order the attachments arrive.
+func (tangle *Tangle) checkTransactionSolidity(tx *transaction.Transaction, metadata *TransactionMetadata, referenceTime time.Time) (solid bool, consumedBranches []branchmanager.BranchID, err error) {
 	// abort if any of the models are nil or has been deleted
 	if tx == nil || tx.IsDeleted() || metadata == nil || metadata.IsDeleted() {
 		return
 	}
 
 	// abort if we have previously determined the solidity status of the transaction already
-	if solid = metadata.Solid(); solid {
+	if metadata.Solid() {
+		cachedInputs := make(CachedOutputs)
+		tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+			cachedInputs[outputID] = tangle.TransactionOutput(outputID)
+
+			return true
+		})
+		defer cachedInputs.Release()
+
+		if err = tangle.checkTransactionInputConditions(tx, cachedInputs, referenceTime); err != nil {
+			return
+		}
+
+		solid = true
 		consumedBranches = []branchmanager.BranchID{metadata.BranchID()}
 
 		return
@@ -822,6 +1118,11 @@ func (tangle *Tangle) checkTransactionSolidity(tx *transaction.Transaction, meta
 	}
 	defer cachedInputs.Release()
 
+	// abort if the conditions of the consumed outputs do not allow the transaction to spend them
+	if err = tangle.checkTransactionInputConditions(tx, cachedInputs, referenceTime); err != nil {
+		return
+	}
+
 	// abort if the outputs are not matching the inputs
 	if !tangle.checkTransactionOutputs(consumedBalances, tx.Outputs()) {
 		err = fmt.Errorf("the outputs do not match the inputs in transaction with id '%s'", tx.ID())
@@ -848,10 +1149,10 @@ func (tangle *Tangle) checkTransactionSolidity(tx *transaction.Transaction, meta
 }
 
 // LoadSnapshot creates a set of outputs in the value tangle, that are forming the genesis for future transactions.
-func (tangle *Tangle) LoadSnapshot(snapshot map[transaction.ID]map[address.Address][]*balance.Balance) {
+func (tangle *Tangle) LoadSnapshot(snapshot Snapshot) {
 	for transactionID, addressBalances := range snapshot {
 		for outputAddress, balances := range addressBalances {
-			input := NewOutput(outputAddress, transactionID, branchmanager.MasterBranchID, balances)
+			input := NewOutput(outputAddress, transactionID, branchmanager.MasterBranchID, balances, nil)
 			input.SetSolid(true)
 			input.SetBranchID(branchmanager.MasterBranchID)
 
@@ -862,8 +1163,87 @@ func (tangle *Tangle) LoadSnapshot(snapshot map[transaction.ID]map[address.Addre
 			}
 
 			cachedOutput.Release()
+
+			tangle.addressTransactionStorage.Store(NewAddressTransaction(outputAddress, transactionID, time.Now(), false, true)).Release()
+		}
+	}
+
+	// register the snapshot as the origin of the supply of its colors
+	for transactionID, addressBalances := range snapshot {
+		createdBalances := make(map[balance.Color]int64)
+		for _, balances := range addressBalances {
+			for _, coloredBalance := range balances {
+				createdBalances[coloredBalance.Color()] += coloredBalance.Value()
+			}
+		}
+
+		for color, amount := range createdBalances {
+			tangle.supplyChangeStorage.Store(NewSupplyChange(color, transactionID, amount, true)).Release()
+		}
+	}
+}
+
+// storeAddressTransactions stores the references from the addresses that the given transaction spent from or created
+// outputs on to the transaction.
+func (tangle *Tangle) storeAddressTransactions(tx *transaction.Transaction, timestamp time.Time) {
+	spentAddresses := make(map[address.Address]bool)
+	tx.Inputs().ForEachAddress(func(address address.Address) bool {
+		spentAddresses[address] = true
+
+		return true
+	})
+
+	createdAddresses := make(map[address.Address]bool)
+	tx.Outputs().ForEach(func(address address.Address, balances []*balance.Balance) bool {
+		createdAddresses[address] = true
+
+		return true
+	})
+
+	for _, addresses := range []map[address.Address]bool{spentAddresses, createdAddresses} {
+		for addr := range addresses {
+			if cachedAddressTransaction, stored := tangle.addressTransactionStorage.StoreIfAbsent(NewAddressTransaction(addr, tx.ID(), timestamp, spentAddresses[addr], createdAddresses[addr])); stored {
+				cachedAddressTransaction.Release()
+			}
+		}
+	}
+}
+
+// storeSupplyChanges records by how much the given transaction changed the supply of the different colors. Colors that
+// are equal to the transaction ID have been minted by the transaction.
+func (tangle *Tangle) storeSupplyChanges(transactionID transaction.ID, consumedBalances map[balance.Color]int64, createdBalances map[balance.Color]int64) {
+	deltas := make(map[balance.Color]int64)
+	for color, amount := range createdBalances {
+		deltas[color] += amount
+	}
+	for color, amount := range consumedBalances {
+		deltas[color] -= amount
+	}
+
+	for color, delta := range deltas {
+		if delta == 0 {
+			continue
+		}
+
+		tangle.supplyChangeStorage.Store(NewSupplyChange(color, transactionID, delta, color == balance.Color(transactionID))).Release()
+	}
+}
+
+// recolorBalances replaces the ColorNew placeholder in the given balances with the color of the newly minted tokens
+// (the ID of the transaction that created them).
+func recolorBalances(transactionID transaction.ID, balances []*balance.Balance) []*balance.Balance {
+	coloredBalances := make([]*balance.Balance, len(balances))
+	for i, outputBalance := range balances {
+		if outputBalance.Color() != balance.ColorNew {
+			coloredBalances[i] = outputBalance
+
+			continue
 		}
+
+		coloredBalances[i] = balance.New(balance.Color(transactionID), outputBalance.Value())
 	}
+
+	return coloredBalances
 }
 
 // OutputsOnAddress retrieves all the Outputs that are associated with an address.
@@ -934,6 +1314,53 @@ func (tangle *Tangle) retrieveConsumedInputDetails(tx *transaction.Transaction)
 	return
 }
 
+// checkTransactionInputConditions is a utility function that returns an error if the given transaction is not allowed
+// to spend one of its conditional inputs at the given reference time: timelocked inputs can not be spent before their
+// timelock ends and expiring inputs need to be signed by the receiving address before their deadline and by the
+// fallback address after it.
+func (tangle *Tangle) checkTransactionInputConditions(tx *transaction.Transaction, cachedInputs CachedOutputs, referenceTime time.Time) error {
+	for outputID, cachedInput := range cachedInputs {
+		input := cachedInput.Unwrap()
+		if input == nil || input.Conditions().Empty() {
+			continue
+		}
+
+		unlockAddress, err := input.Conditions().UnlockAddress(input.Address(), referenceTime)
+		if err != nil {
+			return fmt.Errorf("failed to spend output '%s' in transaction with id '%s': %w", outputID, tx.ID(), err)
+		}
+
+		if !tx.SignatureValid(unlockAddress) {
+			return fmt.Errorf("the transaction with id '%s' is missing a valid signature of %s to spend output '%s'", tx.ID(), unlockAddress, outputID)
+		}
+	}
+
+	return nil
+}
+
+// SignaturesValid returns true if the given transaction contains valid signatures of all addresses that are allowed to
+// spend its inputs at the current time. The conditions of inputs that are unknown to the tangle can not be taken into
+// account, so they need to be signed by their own address.
+func (tangle *Tangle) SignaturesValid(tx *transaction.Transaction) (valid bool) {
+	referenceTime := time.Now()
+
+	valid = true
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		unlockAddress := outputID.Address()
+		tangle.TransactionOutput(outputID).Consume(func(output *Output) {
+			if conditionalUnlockAddress, err := output.Conditions().UnlockAddress(output.Address(), referenceTime); err == nil {
+				unlockAddress = conditionalUnlockAddress
+			}
+		})
+
+		valid = tx.SignatureValid(unlockAddress)
+
+		return valid
+	})
+
+	return
+}
+
 // checkTransactionOutputs is a utility function that returns true, if the outputs are consuming all of the given inputs
 // (the sum of all the balance changes is 0). It also accounts for the ability to "recolor" coins during the creating of
 // outputs. If this function returns false, then the outputs that are defined in the transaction are invalid and the
@@ -945,6 +1372,11 @@ func (tangle *Tangle) checkTransactionOutputs(inputBalances map[balance.Color]in
 
 	// iterate through outputs and check them one by one
 	aborted := !outputs.ForEach(func(address address.Address, balances []*balance.Balance) bool {
+		// abort if the conditions of the output can never be fulfilled
+		if outputs.Conditions(address).Validate(address) != nil {
+			return false
+		}
+
 		for _, outputBalance := range balances {
 			// abort if the output creates a negative or empty output
 			if outputBalance.Value() <= 0 {
@@ -1068,7 +1500,7 @@ func (tangle *Tangle) Fork(transactionID transaction.ID, conflictingInputs []tra
 	}
 
 	// trigger events + set result
-	tangle.Events.Fork.Trigger(cachedTransaction, cachedTransactionMetadata)
+	tangle.Events.Fork.Trigger(cachedTransaction, cachedTransactionMetadata, cachedTargetBranch, conflictingInputs)
 	forked = true
 
 	return
@@ -1153,7 +1585,7 @@ func (tangle *Tangle) moveTransactionToBranch(cachedTransaction *transaction.Cac
 					}
 
 					// abort if we did not modify the branch of the transaction
-					if !currentTransactionMetadata.SetBranchID(targetBranch.ID()) {
+					if !tangle.setTransactionBranchID(currentTransactionMetadata, targetBranch.ID()) {
 						return nil
 					}
 
@@ -4,9 +4,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
@@ -19,7 +24,7 @@ func TestNewOutput(t *testing.T) {
 
 	output := NewOutput(randomAddress, randomTransactionID, branchmanager.MasterBranchID, []*balance.Balance{
 		balance.New(balance.ColorIOTA, 1337),
-	})
+	}, nil)
 
 	assert.Equal(t, randomAddress, output.Address())
 	assert.Equal(t, randomTransactionID, output.TransactionID())
@@ -64,3 +69,352 @@ func TestAttachment(t *testing.T) {
 	assert.Equal(t, transactionID, clonedAttachment.TransactionID())
 	assert.Equal(t, payloadID, clonedAttachment.PayloadID())
 }
+
+func TestTangle_PruneOlderThan(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	intermediateKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	receiverAddress := address.Random()
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{balance.New(balance.ColorIOTA, 1000)},
+		},
+	})
+
+	firstTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			intermediateKeyPair.Address(): {balance.New(balance.ColorIOTA, 1000)},
+		}),
+	)
+	firstTransaction.Sign(genesisKeyPair)
+	firstPayload := payload.New(payload.GenesisID, payload.GenesisID, firstTransaction)
+	valueTangle.AttachPayloadSync(firstPayload, time.Now())
+
+	secondTransaction := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(intermediateKeyPair.Address(), firstTransaction.ID())),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			receiverAddress: {balance.New(balance.ColorIOTA, 1000)},
+		}),
+	)
+	secondTransaction.Sign(intermediateKeyPair)
+	secondPayload := payload.New(firstPayload.ID(), payload.GenesisID, secondTransaction)
+	valueTangle.AttachPayloadSync(secondPayload, time.Now())
+
+	// nothing can be pruned before the transactions are finalized
+	prunedPayloads, prunedTransactions := valueTangle.PruneOlderThan(time.Now().Add(time.Second))
+	assert.Equal(t, 0, prunedPayloads)
+	assert.Equal(t, 0, prunedTransactions)
+
+	for _, transactionID := range []transaction.ID{firstTransaction.ID(), secondTransaction.ID()} {
+		_, err := valueTangle.SetTransactionPreferred(transactionID, true)
+		require.NoError(t, err)
+		valueTangle.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+			transactionMetadata.SetFinalized(true)
+		})
+	}
+
+	// nothing is pruned if the objects are younger than the cutoff
+	prunedPayloads, prunedTransactions = valueTangle.PruneOlderThan(time.Now().Add(-time.Hour))
+	assert.Equal(t, 0, prunedPayloads)
+	assert.Equal(t, 0, prunedTransactions)
+
+	// the tip and the transaction with the unspent output are kept
+	prunedPayloads, prunedTransactions = valueTangle.PruneOlderThan(time.Now().Add(time.Second))
+	assert.Equal(t, 1, prunedPayloads)
+	assert.Equal(t, 1, prunedTransactions)
+
+	assert.False(t, valueTangle.Payload(firstPayload.ID()).Consume(func(*payload.Payload) {}))
+	assert.False(t, valueTangle.Transaction(firstTransaction.ID()).Consume(func(*transaction.Transaction) {}))
+	assert.False(t, valueTangle.TransactionOutput(transaction.NewOutputID(intermediateKeyPair.Address(), firstTransaction.ID())).Consume(func(*Output) {}))
+	assert.True(t, valueTangle.Payload(secondPayload.ID()).Consume(func(*payload.Payload) {}))
+	assert.True(t, valueTangle.Transaction(secondTransaction.ID()).Consume(func(*transaction.Transaction) {}))
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 1000}, NewLedgerState(valueTangle).Balances(receiverAddress))
+
+	// the address history only references the transactions that were not pruned
+	history, total := NewLedgerState(valueTangle).AddressHistory(intermediateKeyPair.Address(), 0, 0)
+	require.Equal(t, 1, total)
+	assert.Equal(t, secondTransaction.ID(), history[0].TransactionID())
+}
+
+func TestTangle_PruneParentFinalizedAfterChild(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	intermediateKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	receiverKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{balance.New(balance.ColorIOTA, 1000)},
+		},
+	})
+
+	// attach a chain of three payloads, so that the payload in the middle is not a tip
+	var payloads []*payload.Payload
+	var transactionIDs []transaction.ID
+	parentPayloadID := payload.GenesisID
+	for _, transfer := range []struct {
+		sender   signaturescheme.SignatureScheme
+		receiver address.Address
+	}{
+		{genesisKeyPair, intermediateKeyPair.Address()},
+		{intermediateKeyPair, receiverKeyPair.Address()},
+		{receiverKeyPair, address.Random()},
+	} {
+		inputTx := transaction.GenesisID
+		if len(transactionIDs) != 0 {
+			inputTx = transactionIDs[len(transactionIDs)-1]
+		}
+		tx := transaction.New(
+			transaction.NewInputs(transaction.NewOutputID(transfer.sender.Address(), inputTx)),
+			transaction.NewOutputs(map[address.Address][]*balance.Balance{
+				transfer.receiver: {balance.New(balance.ColorIOTA, 1000)},
+			}),
+		)
+		tx.Sign(transfer.sender)
+		valuePayload := payload.New(parentPayloadID, payload.GenesisID, tx)
+		valueTangle.AttachPayloadSync(valuePayload, time.Now())
+
+		payloads = append(payloads, valuePayload)
+		transactionIDs = append(transactionIDs, tx.ID())
+		parentPayloadID = valuePayload.ID()
+	}
+
+	finalize := func(transactionID transaction.ID) {
+		_, err := valueTangle.SetTransactionPreferred(transactionID, true)
+		require.NoError(t, err)
+		valueTangle.TransactionMetadata(transactionID).Consume(func(transactionMetadata *TransactionMetadata) {
+			transactionMetadata.SetFinalized(true)
+		})
+	}
+
+	// the child is finalized and pruned before its parent
+	finalize(transactionIDs[1])
+	prunedPayloads, _ := valueTangle.PruneOlderThan(time.Now().Add(time.Second))
+	assert.Equal(t, 1, prunedPayloads)
+	assert.False(t, valueTangle.Payload(payloads[1].ID()).Consume(func(*payload.Payload) {}))
+	assert.True(t, valueTangle.Payload(payloads[0].ID()).Consume(func(*payload.Payload) {}))
+
+	// the parent is still prunable once it is finalized and its references to the pruned child are removed with it
+	finalize(transactionIDs[0])
+	prunedPayloads, _ = valueTangle.PruneOlderThan(time.Now().Add(time.Second))
+	assert.Equal(t, 1, prunedPayloads)
+	assert.False(t, valueTangle.Payload(payloads[0].ID()).Consume(func(*payload.Payload) {}))
+	assert.Empty(t, valueTangle.Approvers(payloads[0].ID()))
+	assert.True(t, valueTangle.Payload(payloads[2].ID()).Consume(func(*payload.Payload) {}))
+}
+
+func TestTangle_TransactionsInBranch(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{balance.New(balance.ColorIOTA, 1000)},
+		},
+	})
+
+	tx := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			address.Random(): {balance.New(balance.ColorIOTA, 1000)},
+		}),
+	)
+	tx.Sign(genesisKeyPair)
+	valueTangle.AttachPayloadSync(payload.New(payload.GenesisID, payload.GenesisID, tx), time.Now())
+
+	assert.Equal(t, []transaction.ID{tx.ID()}, valueTangle.TransactionsInBranch(branchmanager.MasterBranchID))
+	assert.Empty(t, valueTangle.TransactionsInBranch(branchmanager.NewBranchID(tx.ID())))
+
+	// a double spend moves the transaction into its own branch
+	doubleSpend := transaction.New(
+		transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+		transaction.NewOutputs(map[address.Address][]*balance.Balance{
+			address.Random(): {balance.New(balance.ColorIOTA, 1000)},
+		}),
+	)
+	doubleSpend.Sign(genesisKeyPair)
+	valueTangle.AttachPayloadSync(payload.New(payload.GenesisID, payload.GenesisID, doubleSpend), time.Now())
+
+	assert.Empty(t, valueTangle.TransactionsInBranch(branchmanager.MasterBranchID))
+	assert.Equal(t, []transaction.ID{tx.ID()}, valueTangle.TransactionsInBranch(branchmanager.NewBranchID(tx.ID())))
+	assert.Equal(t, []transaction.ID{doubleSpend.ID()}, valueTangle.TransactionsInBranch(branchmanager.NewBranchID(doubleSpend.ID())))
+}
+
+func TestTangle_ForkEvent(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	valueTangle.LoadSnapshot(Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{balance.New(balance.ColorIOTA, 1000)},
+		},
+	})
+
+	forkedBranches := make(map[branchmanager.BranchID][]transaction.OutputID)
+	valueTangle.Events.Fork.Attach(events.NewClosure(func(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *CachedTransactionMetadata, cachedBranch *branchmanager.CachedBranch, forkedInputs []transaction.OutputID) {
+		defer cachedTransaction.Release()
+		defer cachedTransactionMetadata.Release()
+		defer cachedBranch.Release()
+
+		forkedBranches[cachedBranch.Unwrap().ID()] = forkedInputs
+	}))
+
+	spentOutputID := transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)
+	var transactionIDs []transaction.ID
+	for i := 0; i < 2; i++ {
+		tx := transaction.New(
+			transaction.NewInputs(spentOutputID),
+			transaction.NewOutputs(map[address.Address][]*balance.Balance{
+				address.Random(): {balance.New(balance.ColorIOTA, 1000)},
+			}),
+		)
+		tx.Sign(genesisKeyPair)
+		valueTangle.AttachPayloadSync(payload.New(payload.GenesisID, payload.GenesisID, tx), time.Now())
+		transactionIDs = append(transactionIDs, tx.ID())
+	}
+
+	// the first transaction is forked into its own branch once the double spend arrives
+	assert.Equal(t, map[branchmanager.BranchID][]transaction.OutputID{
+		branchmanager.NewBranchID(transactionIDs[0]): {spentOutputID},
+	}, forkedBranches)
+}
+
+func TestOutput_Conditions(t *testing.T) {
+	conditions := transaction.NewOutputConditions().WithTimelock(time.Now()).WithExpiry(time.Now().Add(time.Hour), address.Random())
+	output := NewOutput(address.Random(), transaction.RandomID(), branchmanager.MasterBranchID, []*balance.Balance{
+		balance.New(balance.ColorIOTA, 1337),
+	}, conditions)
+
+	clonedOutput, _, err := OutputFromBytes(output.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, conditions.Bytes(), clonedOutput.Conditions().Bytes())
+
+	// outputs without conditions are restored without conditions
+	clonedOutput, _, err = OutputFromBytes(NewOutput(address.Random(), transaction.RandomID(), branchmanager.MasterBranchID, []*balance.Balance{
+		balance.New(balance.ColorIOTA, 1337),
+	}, nil).Bytes())
+	require.NoError(t, err)
+	assert.Nil(t, clonedOutput.Conditions())
+}
+
+func TestCheckTransactionOutputs_Conditions(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	now := time.Now()
+	receiver := address.Random()
+	for name, testCase := range map[string]struct {
+		conditions *transaction.OutputConditions
+		valid      bool
+	}{
+		"timelock":          {transaction.NewOutputConditions().WithTimelock(now), true},
+		"expiry":            {transaction.NewOutputConditions().WithExpiry(now, address.Random()), true},
+		"timelockAndExpiry": {transaction.NewOutputConditions().WithTimelock(now).WithExpiry(now.Add(time.Hour), address.Random()), true},
+		"receiverFallback":  {transaction.NewOutputConditions().WithExpiry(now, receiver), false},
+		"expiryBeforeLock":  {transaction.NewOutputConditions().WithTimelock(now.Add(time.Hour)).WithExpiry(now, address.Random()), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			outputs := transaction.NewOutputs(map[address.Address][]*balance.Balance{}).AddWithConditions(receiver, []*balance.Balance{balance.New(balance.ColorIOTA, 1000)}, testCase.conditions)
+			assert.Equal(t, testCase.valid, valueTangle.checkTransactionOutputs(map[balance.Color]int64{balance.ColorIOTA: 1000}, outputs))
+		})
+	}
+}
+
+func TestTangle_ConditionalOutputs(t *testing.T) {
+	valueTangle := New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+
+	now := time.Now()
+	fallbackKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	testCases := []struct {
+		name            string
+		conditions      *transaction.OutputConditions
+		signByReceiver  bool
+		signByFallback  bool
+		signaturesValid bool
+		booked          bool
+		// bookedAfterLock is checked for timelocked spends that were not booked when they were issued
+		bookedAfterLock bool
+	}{
+		{"timelocked", transaction.NewOutputConditions().WithTimelock(now.Add(time.Hour)), true, false, true, false, true},
+		{"timelockEnded", transaction.NewOutputConditions().WithTimelock(now.Add(-time.Hour)), true, false, true, true, false},
+		{"timelockEndedUnsigned", transaction.NewOutputConditions().WithTimelock(now.Add(-time.Hour)), false, false, false, false, false},
+		{"notExpiredByReceiver", transaction.NewOutputConditions().WithExpiry(now.Add(time.Hour), fallbackKeyPair.Address()), true, false, true, true, false},
+		{"notExpiredByFallback", transaction.NewOutputConditions().WithExpiry(now.Add(time.Hour), fallbackKeyPair.Address()), false, true, false, false, false},
+		{"expiredByReceiver", transaction.NewOutputConditions().WithExpiry(now.Add(-time.Hour), fallbackKeyPair.Address()), true, false, false, false, false},
+		{"expiredByFallback", transaction.NewOutputConditions().WithExpiry(now.Add(-time.Hour), fallbackKeyPair.Address()), false, true, true, true, false},
+		{"timelockedAndNotExpired", transaction.NewOutputConditions().WithTimelock(now.Add(time.Hour)).WithExpiry(now.Add(2*time.Hour), fallbackKeyPair.Address()), true, false, true, false, true},
+	}
+
+	// every test case spends its own genesis output
+	genesisKeyPairs := make([]signaturescheme.SignatureScheme, len(testCases))
+	snapshot := Snapshot{transaction.GenesisID: make(map[address.Address][]*balance.Balance)}
+	for i := range testCases {
+		genesisKeyPairs[i] = signaturescheme.ED25519(ed25519.GenerateKeyPair())
+		snapshot[transaction.GenesisID][genesisKeyPairs[i].Address()] = []*balance.Balance{balance.New(balance.ColorIOTA, 1000)}
+	}
+	valueTangle.LoadSnapshot(snapshot)
+
+	for i, testCase := range testCases {
+		genesisKeyPair := genesisKeyPairs[i]
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			receiverKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+
+			// send the funds to the receiver with the given conditions
+			conditionalTransaction := transaction.New(
+				transaction.NewInputs(transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)),
+				transaction.NewOutputs(map[address.Address][]*balance.Balance{}).AddWithConditions(receiverKeyPair.Address(), []*balance.Balance{balance.New(balance.ColorIOTA, 1000)}, testCase.conditions),
+			)
+			conditionalTransaction.Sign(genesisKeyPair)
+			conditionalPayload := payload.New(payload.GenesisID, payload.GenesisID, conditionalTransaction)
+			valueTangle.AttachPayloadSync(conditionalPayload, time.Now())
+			require.True(t, valueTangle.TransactionMetadata(conditionalTransaction.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+				assert.True(t, transactionMetadata.Solid())
+			}))
+
+			// spend the conditional output
+			spendingTransaction := transaction.New(
+				transaction.NewInputs(transaction.NewOutputID(receiverKeyPair.Address(), conditionalTransaction.ID())),
+				transaction.NewOutputs(map[address.Address][]*balance.Balance{
+					address.Random(): {balance.New(balance.ColorIOTA, 1000)},
+				}),
+			)
+			if testCase.signByReceiver {
+				spendingTransaction.Sign(receiverKeyPair)
+			}
+			if testCase.signByFallback {
+				spendingTransaction.Sign(fallbackKeyPair)
+			}
+			assert.Equal(t, testCase.signaturesValid, valueTangle.SignaturesValid(spendingTransaction))
+			spendingPayload := payload.New(conditionalPayload.ID(), payload.GenesisID, spendingTransaction)
+			valueTangle.AttachPayloadSync(spendingPayload, now)
+
+			booked := func() (booked bool) {
+				valueTangle.TransactionMetadata(spendingTransaction.ID()).Consume(func(transactionMetadata *TransactionMetadata) {
+					booked = transactionMetadata.Solid()
+				})
+
+				return
+			}
+			assert.Equal(t, testCase.booked, booked())
+
+			if testCase.booked || !testCase.conditions.HasTimelock() {
+				return
+			}
+
+			// a reattachment that is issued once the lock ended is booked, the early attachment stays invalid
+			valueTangle.AttachPayloadSync(payload.New(payload.GenesisID, conditionalPayload.ID(), spendingTransaction), testCase.conditions.Timelock().Add(time.Second))
+			assert.Equal(t, testCase.bookedAfterLock, booked())
+			assert.True(t, valueTangle.PayloadMetadata(spendingPayload.ID()).Consume(func(payloadMetadata *PayloadMetadata) {
+				assert.False(t, payloadMetadata.IsSolid())
+			}))
+		})
+	}
+}
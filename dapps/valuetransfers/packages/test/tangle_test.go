@@ -2,6 +2,7 @@ package test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
@@ -70,7 +71,7 @@ func TestTangle_ValueTransfer(t *testing.T) {
 			},
 		}),
 	))
-	valueTangle.AttachPayloadSync(attachedPayload1)
+	valueTangle.AttachPayloadSync(attachedPayload1, time.Now())
 
 	// check if old addresses are empty and new addresses are filled
 	assert.Equal(t, map[balance.Color]int64{}, ledgerState.Balances(seed.Address(0)))
@@ -94,7 +95,7 @@ func TestTangle_ValueTransfer(t *testing.T) {
 				balance.New(balance.ColorNew, 1337),
 			},
 		}),
-	)))
+	)), time.Now())
 }
 
 func recordLikedPayloads(valueTangle *tangle.Tangle) (recordedLikedPayloads map[payload.ID]types.Empty, resetFunc func()) {
@@ -0,0 +1,235 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+)
+
+const (
+	// conditionTimelock is the flag that marks the presence of a timelock in the marshaled OutputConditions.
+	conditionTimelock byte = 1 << iota
+
+	// conditionExpiry is the flag that marks the presence of an expiry in the marshaled OutputConditions.
+	conditionExpiry
+
+	// knownConditions contains the flags of all conditions that can be parsed.
+	knownConditions = conditionTimelock | conditionExpiry
+)
+
+var (
+	// ErrInvalidOutputConditions is returned if the conditions of an output can never be fulfilled.
+	ErrInvalidOutputConditions = errors.New("invalid output conditions")
+
+	// ErrOutputTimelocked is returned if an output is spent before its timelock expired.
+	ErrOutputTimelocked = errors.New("output is timelocked")
+)
+
+// OutputConditions restricts when and by whom the balances of an output can be spent. An output can be timelocked, so
+// it can not be spent before a given time, and it can expire, so it can only be spent by the receiving address until a
+// deadline and by a fallback address after it. Outputs without conditions can be spent by their address at any time.
+// All times have the precision of seconds, so the conditions survive the round trip through APIs that use unix
+// timestamps unchanged.
+type OutputConditions struct {
+	timelock        time.Time
+	expiry          time.Time
+	fallbackAddress address.Address
+}
+
+// NewOutputConditions creates empty OutputConditions, that can be extended using the With* methods.
+func NewOutputConditions() *OutputConditions {
+	return &OutputConditions{}
+}
+
+// OutputConditionsFromBytes unmarshals OutputConditions from a sequence of bytes.
+// It either creates a new object or fills the optionally provided object with the parsed information.
+func OutputConditionsFromBytes(bytes []byte, optionalTargetObject ...*OutputConditions) (result *OutputConditions, consumedBytes int, err error) {
+	// determine the target object that will hold the unmarshaled information
+	switch len(optionalTargetObject) {
+	case 0:
+		result = &OutputConditions{}
+	case 1:
+		result = optionalTargetObject[0]
+	default:
+		panic("too many arguments in call to OutputConditionsFromBytes")
+	}
+
+	// initialize helper
+	marshalUtil := marshalutil.New(bytes)
+
+	// read the flags of the contained conditions
+	flags, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	}
+	if flags&^knownConditions != 0 {
+		err = fmt.Errorf("%w: unknown condition flags %08b", ErrInvalidOutputConditions, flags)
+
+		return
+	}
+
+	// read the conditions
+	if flags&conditionTimelock != 0 {
+		if result.timelock, err = marshalUtil.ReadTime(); err != nil {
+			return
+		}
+		if result.timelock.Nanosecond() != 0 {
+			err = fmt.Errorf("%w: the timelock is not given in whole seconds", ErrInvalidOutputConditions)
+
+			return
+		}
+	}
+	if flags&conditionExpiry != 0 {
+		if result.expiry, err = marshalUtil.ReadTime(); err != nil {
+			return
+		}
+		if result.expiry.Nanosecond() != 0 {
+			err = fmt.Errorf("%w: the expiry is not given in whole seconds", ErrInvalidOutputConditions)
+
+			return
+		}
+		if result.fallbackAddress, err = address.Parse(marshalUtil); err != nil {
+			return
+		}
+	}
+
+	// return the number of bytes we processed
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseOutputConditions is a wrapper for simplified unmarshaling of a byte stream using the marshalUtil package.
+func ParseOutputConditions(marshalUtil *marshalutil.MarshalUtil) (*OutputConditions, error) {
+	conditions, err := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return OutputConditionsFromBytes(data) })
+	if err != nil {
+		return nil, err
+	}
+
+	return conditions.(*OutputConditions), nil
+}
+
+// WithTimelock prevents the output from being spent before the given time (truncated to seconds).
+func (conditions *OutputConditions) WithTimelock(timelock time.Time) *OutputConditions {
+	conditions.timelock = timelock.Truncate(time.Second)
+
+	return conditions
+}
+
+// WithExpiry restricts the output to be spent by the receiving address before the given deadline (truncated to
+// seconds) and only by the fallback address after it.
+func (conditions *OutputConditions) WithExpiry(deadline time.Time, fallbackAddress address.Address) *OutputConditions {
+	conditions.expiry = deadline.Truncate(time.Second)
+	conditions.fallbackAddress = fallbackAddress
+
+	return conditions
+}
+
+// Timelock returns the time before which the output can not be spent (the zero time if there is no timelock).
+func (conditions *OutputConditions) Timelock() time.Time {
+	return conditions.timelock
+}
+
+// HasTimelock returns true if the output is timelocked.
+func (conditions *OutputConditions) HasTimelock() bool {
+	return !conditions.timelock.IsZero()
+}
+
+// Expiry returns the deadline after which the output can only be spent by the fallback address (the zero time if the
+// output does not expire).
+func (conditions *OutputConditions) Expiry() time.Time {
+	return conditions.expiry
+}
+
+// FallbackAddress returns the address that can spend the output after it expired.
+func (conditions *OutputConditions) FallbackAddress() address.Address {
+	return conditions.fallbackAddress
+}
+
+// HasExpiry returns true if the output expires.
+func (conditions *OutputConditions) HasExpiry() bool {
+	return !conditions.expiry.IsZero()
+}
+
+// Empty returns true if the OutputConditions do not restrict the output in any way.
+func (conditions *OutputConditions) Empty() bool {
+	return conditions == nil || (!conditions.HasTimelock() && !conditions.HasExpiry())
+}
+
+// Validate checks if the conditions of an output that is sent to the given address can be fulfilled.
+func (conditions *OutputConditions) Validate(receiver address.Address) error {
+	if conditions.Empty() || !conditions.HasExpiry() {
+		return nil
+	}
+
+	if conditions.fallbackAddress == receiver {
+		return fmt.Errorf("%w: the fallback address equals the receiving address", ErrInvalidOutputConditions)
+	}
+	if conditions.HasTimelock() && !conditions.timelock.Before(conditions.expiry) {
+		return fmt.Errorf("%w: the output expires before its timelock ends", ErrInvalidOutputConditions)
+	}
+
+	return nil
+}
+
+// UnlockAddress returns the address, that is allowed to spend an output that was sent to the given address at the
+// given time. It returns an ErrOutputTimelocked if the output can not be spent at all yet.
+func (conditions *OutputConditions) UnlockAddress(receiver address.Address, referenceTime time.Time) (address.Address, error) {
+	if conditions.Empty() {
+		return receiver, nil
+	}
+
+	if conditions.HasTimelock() && referenceTime.Before(conditions.timelock) {
+		return address.Address{}, fmt.Errorf("%w until %s", ErrOutputTimelocked, conditions.timelock)
+	}
+	if conditions.HasExpiry() && !referenceTime.Before(conditions.expiry) {
+		return conditions.fallbackAddress, nil
+	}
+
+	return receiver, nil
+}
+
+// Bytes returns a marshaled version of the OutputConditions.
+func (conditions *OutputConditions) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	if conditions.Empty() {
+		return marshalUtil.WriteByte(0).Bytes()
+	}
+
+	var flags byte
+	if conditions.HasTimelock() {
+		flags |= conditionTimelock
+	}
+	if conditions.HasExpiry() {
+		flags |= conditionExpiry
+	}
+
+	marshalUtil.WriteByte(flags)
+	if conditions.HasTimelock() {
+		marshalUtil.WriteTime(conditions.timelock)
+	}
+	if conditions.HasExpiry() {
+		marshalUtil.WriteTime(conditions.expiry)
+		marshalUtil.WriteBytes(conditions.fallbackAddress.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the OutputConditions.
+func (conditions *OutputConditions) String() string {
+	if conditions.Empty() {
+		return "OutputConditions {}"
+	}
+
+	return stringify.Struct("OutputConditions",
+		stringify.StructField("timelock", conditions.timelock),
+		stringify.StructField("expiry", conditions.expiry),
+		stringify.StructField("fallbackAddress", conditions.fallbackAddress),
+	)
+}
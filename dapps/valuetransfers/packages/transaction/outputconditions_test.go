@@ -0,0 +1,136 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+func TestOutputConditions_Marshaling(t *testing.T) {
+	now := time.Now()
+	fallbackAddress := address.Random()
+
+	for name, conditions := range map[string]*OutputConditions{
+		"empty":    NewOutputConditions(),
+		"timelock": NewOutputConditions().WithTimelock(now),
+		"expiry":   NewOutputConditions().WithExpiry(now.Add(time.Hour), fallbackAddress),
+		"both":     NewOutputConditions().WithTimelock(now).WithExpiry(now.Add(time.Hour), fallbackAddress),
+	} {
+		t.Run(name, func(t *testing.T) {
+			clonedConditions, consumedBytes, err := OutputConditionsFromBytes(conditions.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, len(conditions.Bytes()), consumedBytes)
+			assert.Equal(t, conditions.Bytes(), clonedConditions.Bytes())
+			assert.Equal(t, conditions.HasTimelock(), clonedConditions.HasTimelock())
+			assert.True(t, conditions.Timelock().Equal(clonedConditions.Timelock()))
+			assert.Equal(t, conditions.HasExpiry(), clonedConditions.HasExpiry())
+			assert.True(t, conditions.Expiry().Equal(clonedConditions.Expiry()))
+			assert.Equal(t, conditions.FallbackAddress(), clonedConditions.FallbackAddress())
+		})
+	}
+
+	// nil conditions are marshaled like empty ones
+	assert.Equal(t, NewOutputConditions().Bytes(), (*OutputConditions)(nil).Bytes())
+	assert.True(t, (*OutputConditions)(nil).Empty())
+
+	// unknown conditions can not be parsed
+	_, _, err := OutputConditionsFromBytes([]byte{1 << 7})
+	assert.True(t, errors.Is(err, ErrInvalidOutputConditions), "unexpected error: %v", err)
+
+	// truncated conditions can not be parsed
+	_, _, err = OutputConditionsFromBytes(NewOutputConditions().WithTimelock(now).Bytes()[:5])
+	assert.Error(t, err)
+
+	// times are truncated to seconds and conditions with a higher precision can not be parsed
+	assert.Zero(t, NewOutputConditions().WithTimelock(now).Timelock().Nanosecond())
+	assert.Zero(t, NewOutputConditions().WithExpiry(now, fallbackAddress).Expiry().Nanosecond())
+	_, _, err = OutputConditionsFromBytes(marshalutil.New().WriteByte(conditionTimelock).WriteTime(time.Unix(0, 1)).Bytes())
+	assert.True(t, errors.Is(err, ErrInvalidOutputConditions), "unexpected error: %v", err)
+}
+
+func TestOutputConditions_Validate(t *testing.T) {
+	now := time.Now()
+	receiver := address.Random()
+	fallbackAddress := address.Random()
+
+	assert.NoError(t, (*OutputConditions)(nil).Validate(receiver))
+	assert.NoError(t, NewOutputConditions().Validate(receiver))
+	assert.NoError(t, NewOutputConditions().WithTimelock(now).Validate(receiver))
+	assert.NoError(t, NewOutputConditions().WithExpiry(now, fallbackAddress).Validate(receiver))
+	assert.NoError(t, NewOutputConditions().WithTimelock(now).WithExpiry(now.Add(time.Hour), fallbackAddress).Validate(receiver))
+
+	// the receiver can not be its own fallback
+	err := NewOutputConditions().WithExpiry(now, receiver).Validate(receiver)
+	assert.True(t, errors.Is(err, ErrInvalidOutputConditions), "unexpected error: %v", err)
+
+	// the receiver has to be able to spend the output between the end of the timelock and the expiry
+	err = NewOutputConditions().WithTimelock(now).WithExpiry(now, fallbackAddress).Validate(receiver)
+	assert.True(t, errors.Is(err, ErrInvalidOutputConditions), "unexpected error: %v", err)
+	err = NewOutputConditions().WithTimelock(now.Add(time.Hour)).WithExpiry(now, fallbackAddress).Validate(receiver)
+	assert.True(t, errors.Is(err, ErrInvalidOutputConditions), "unexpected error: %v", err)
+}
+
+func TestOutputConditions_UnlockAddress(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	receiver := address.Random()
+	fallbackAddress := address.Random()
+
+	// outputs without conditions can always be spent by the receiver
+	unlockAddress, err := (*OutputConditions)(nil).UnlockAddress(receiver, now)
+	require.NoError(t, err)
+	assert.Equal(t, receiver, unlockAddress)
+
+	// timelocked outputs can only be spent after the timelock
+	timelocked := NewOutputConditions().WithTimelock(now)
+	_, err = timelocked.UnlockAddress(receiver, now.Add(-time.Nanosecond))
+	assert.True(t, errors.Is(err, ErrOutputTimelocked), "unexpected error: %v", err)
+	unlockAddress, err = timelocked.UnlockAddress(receiver, now)
+	require.NoError(t, err)
+	assert.Equal(t, receiver, unlockAddress)
+
+	// expiring outputs can be spent by the receiver before and by the fallback address after the deadline
+	expiring := NewOutputConditions().WithExpiry(now, fallbackAddress)
+	unlockAddress, err = expiring.UnlockAddress(receiver, now.Add(-time.Nanosecond))
+	require.NoError(t, err)
+	assert.Equal(t, receiver, unlockAddress)
+	unlockAddress, err = expiring.UnlockAddress(receiver, now)
+	require.NoError(t, err)
+	assert.Equal(t, fallbackAddress, unlockAddress)
+
+	// combined conditions
+	combined := NewOutputConditions().WithTimelock(now).WithExpiry(now.Add(time.Hour), fallbackAddress)
+	_, err = combined.UnlockAddress(receiver, now.Add(-time.Minute))
+	assert.True(t, errors.Is(err, ErrOutputTimelocked), "unexpected error: %v", err)
+	unlockAddress, err = combined.UnlockAddress(receiver, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, receiver, unlockAddress)
+	unlockAddress, err = combined.UnlockAddress(receiver, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, fallbackAddress, unlockAddress)
+}
+
+func TestOutputs_Conditions(t *testing.T) {
+	plainAddress := address.Random()
+	conditionalAddress := address.Random()
+	conditions := NewOutputConditions().WithTimelock(time.Now()).WithExpiry(time.Now().Add(time.Hour), address.Random())
+
+	outputs := NewOutputs(map[address.Address][]*balance.Balance{
+		plainAddress: {balance.New(balance.ColorIOTA, 1)},
+	}).AddWithConditions(conditionalAddress, []*balance.Balance{balance.New(balance.ColorIOTA, 2)}, conditions)
+	assert.Nil(t, outputs.Conditions(plainAddress))
+	assert.Equal(t, conditions, outputs.Conditions(conditionalAddress))
+
+	clonedOutputs, consumedBytes, err := OutputsFromBytes(outputs.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(outputs.Bytes()), consumedBytes)
+	assert.Equal(t, outputs.Bytes(), clonedOutputs.Bytes())
+	assert.Nil(t, clonedOutputs.Conditions(plainAddress))
+	assert.Equal(t, conditions.Bytes(), clonedOutputs.Conditions(conditionalAddress).Bytes())
+}
@@ -11,9 +11,12 @@ import (
 	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
 )
 
-// Outputs represents a list of Outputs that are part of a transaction.
+// Outputs represents a list of Outputs that are part of a transaction. Outputs can optionally carry OutputConditions
+// that restrict when and by whom they can be spent.
 type Outputs struct {
 	*orderedmap.OrderedMap
+
+	conditions map[address.Address]*OutputConditions
 }
 
 // NewOutputs is the constructor of the Outputs struct and creates the list of Outputs from the given details.
@@ -28,7 +31,7 @@ func NewOutputs(outputs map[address.Address][]*balance.Balance) (result *Outputs
 		return bytes.Compare(toSort[i][:], toSort[j][:]) < 0
 	})
 
-	result = &Outputs{orderedmap.New()}
+	result = &Outputs{OrderedMap: orderedmap.New()}
 	for _, addr := range toSort {
 		result.Add(addr, outputs[addr])
 	}
@@ -41,7 +44,7 @@ func OutputsFromBytes(bytes []byte, optionalTargetObject ...*Outputs) (result *O
 	// determine the target object that will hold the unmarshaled information
 	switch len(optionalTargetObject) {
 	case 0:
-		result = &Outputs{orderedmap.New()}
+		result = &Outputs{OrderedMap: orderedmap.New()}
 	case 1:
 		result = optionalTargetObject[0]
 	default:
@@ -88,8 +91,16 @@ func OutputsFromBytes(bytes []byte, optionalTargetObject ...*Outputs) (result *O
 			coloredBalances[j] = coloredBalance.(*balance.Balance)
 		}
 
+		// read the conditions of the output
+		conditions, conditionsErr := ParseOutputConditions(marshalUtil)
+		if conditionsErr != nil {
+			err = conditionsErr
+
+			return
+		}
+
 		// add the gathered information as an output
-		result.Add(addr, coloredBalances)
+		result.AddWithConditions(addr, coloredBalances, conditions)
 	}
 
 	// return the number of bytes we processed
@@ -100,11 +111,34 @@ func OutputsFromBytes(bytes []byte, optionalTargetObject ...*Outputs) (result *O
 
 // Add adds a new Output to the list of Outputs.
 func (outputs *Outputs) Add(address address.Address, balances []*balance.Balance) *Outputs {
-	outputs.Set(address, balances)
+	return outputs.AddWithConditions(address, balances, nil)
+}
+
+// AddWithConditions adds a new Output with the given OutputConditions to the list of Outputs.
+func (outputs *Outputs) AddWithConditions(addr address.Address, balances []*balance.Balance, conditions *OutputConditions) *Outputs {
+	outputs.Set(addr, balances)
+
+	if conditions.Empty() {
+		return outputs
+	}
+
+	if outputs.conditions == nil {
+		outputs.conditions = make(map[address.Address]*OutputConditions)
+	}
+	outputs.conditions[addr] = conditions
 
 	return outputs
 }
 
+// Conditions returns the OutputConditions of the Output on the given address (nil if the Output has no conditions).
+func (outputs *Outputs) Conditions(addr address.Address) *OutputConditions {
+	if outputs == nil {
+		return nil
+	}
+
+	return outputs.conditions[addr]
+}
+
 // ForEach iterates through the Outputs and calls them consumer for every found one. The iteration can be aborted by
 // returning false in the consumer.
 func (outputs *Outputs) ForEach(consumer func(address address.Address, balances []*balance.Balance) bool) bool {
@@ -131,6 +165,7 @@ func (outputs *Outputs) Bytes() []byte {
 		for _, bal := range balances {
 			marshalUtil.WriteBytes(bal.Bytes())
 		}
+		marshalUtil.WriteBytes(outputs.Conditions(address).Bytes())
 
 		return true
 	})
@@ -162,6 +197,10 @@ func (outputs *Outputs) String() string {
 			result += "        <empty>\n"
 		}
 
+		if conditions := outputs.Conditions(address); !conditions.Empty() {
+			result += "        " + conditions.String() + "\n"
+		}
+
 		result += "    ]\n"
 
 		return true
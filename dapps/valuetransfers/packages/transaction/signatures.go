@@ -69,6 +69,16 @@ func SignaturesFromBytes(bytes []byte, optionalTargetObject ...*Signatures) (res
 				return
 			}
 			typeCastedSignature = signature.(signaturescheme.Signature)
+
+		case address.VersionMultisigED25519:
+			marshalUtil.ReadSeek(-1)
+			signature, signatureErr := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return signaturescheme.MultisigSignatureFromBytes(data) })
+			if signatureErr != nil {
+				err = signatureErr
+
+				return
+			}
+			typeCastedSignature = signature.(signaturescheme.Signature)
 		default:
 			// unknown signature type...
 		}
@@ -151,11 +151,12 @@ func (transaction *Transaction) Outputs() *Outputs {
 	return transaction.outputs
 }
 
-// SignaturesValid returns true if the Signatures in this transaction
+// SignaturesValid returns true if the Signatures in this transaction are valid for all input addresses. Multisig
+// addresses require their threshold of valid partial signatures.
 func (transaction *Transaction) SignaturesValid() bool {
 	signaturesValid := true
 	transaction.inputs.ForEachAddress(func(address address.Address) bool {
-		if signature, exists := transaction.signatures.Get(address); !exists || !signature.IsValid(transaction.EssenceBytes()) {
+		if !transaction.SignatureValid(address) {
 			signaturesValid = false
 
 			return false
@@ -167,6 +168,13 @@ func (transaction *Transaction) SignaturesValid() bool {
 	return signaturesValid
 }
 
+// SignatureValid returns true if the transaction contains a valid signature of the given address.
+func (transaction *Transaction) SignatureValid(addr address.Address) bool {
+	signature, exists := transaction.signatures.Get(addr)
+
+	return exists && signature.Address() == addr && signature.IsValid(transaction.EssenceBytes())
+}
+
 // EssenceBytes return the bytes of the transaction excluding the Signatures. These bytes are later signed and used to
 // generate the Signatures.
 func (transaction *Transaction) EssenceBytes() []byte {
@@ -301,8 +309,16 @@ func (transaction *Transaction) String() string {
 	)
 }
 
-// MaxDataPayloadSize defines the maximum size (in bytes) of the data payload.
-const MaxDataPayloadSize = 64 * 1024
+// MaxDataPayloadSize defines the maximum size (in bytes) of the data payload of new transactions.
+// It leaves room for the inputs, outputs and signatures within the maximum size of a value payload.
+const MaxDataPayloadSize = 2 * 1024
+
+// maxParsedDataPayloadSize defines the maximum size (in bytes) of the data payload of parsed transactions. It is the
+// limit of previous versions, so that the transactions stored by them can still be read.
+const maxParsedDataPayloadSize = 64 * 1024
+
+// ErrMaxDataPayloadSizeExceeded is returned if the data payload of a transaction exceeds MaxDataPayloadSize.
+var ErrMaxDataPayloadSizeExceeded = errors.New("maximum data payload size exceeded")
 
 // SetDataPayload sets yhe dataPayload and its type
 func (transaction *Transaction) SetDataPayload(data []byte) error {
@@ -310,7 +326,7 @@ func (transaction *Transaction) SetDataPayload(data []byte) error {
 	defer transaction.dataPayloadMutex.Unlock()
 
 	if len(data) > MaxDataPayloadSize {
-		return fmt.Errorf("maximum dataPayload size of %d bytes exceeded", MaxDataPayloadSize)
+		return fmt.Errorf("%w: %d bytes exceed %d bytes", ErrMaxDataPayloadSizeExceeded, len(data), MaxDataPayloadSize)
 	}
 	transaction.dataPayload = data
 	return nil
@@ -380,9 +396,8 @@ func (transaction *Transaction) UnmarshalObjectStorageValue(bytes []byte) (consu
 	if err != nil {
 		return
 	}
-	if dataPayloadSize > MaxDataPayloadSize {
-		err = fmt.Errorf("data payload size of %d bytes exceeds maximum limit of %d bytes",
-			dataPayloadSize, MaxDataPayloadSize)
+	if dataPayloadSize > maxParsedDataPayloadSize {
+		err = fmt.Errorf("%w: %d bytes exceed %d bytes", ErrMaxDataPayloadSizeExceeded, dataPayloadSize, maxParsedDataPayloadSize)
 		return
 	}
 
@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
 
@@ -70,7 +71,17 @@ func TestTooLongDataPayload(t *testing.T) {
 
 	dataPayload := []byte(strings.Repeat("1", MaxDataPayloadSize+1))
 	err := tx.SetDataPayload(dataPayload)
-	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMaxDataPayloadSizeExceeded), "unexpected error: %v", err)
+
+	// transactions of previous versions with larger data payloads can still be parsed
+	tx.dataPayload = dataPayload
+	_, err = new(Transaction).UnmarshalObjectStorageValue(tx.ObjectStorageValue())
+	assert.NoError(t, err)
+
+	tooLargeTx := New(inputs, outputs)
+	tooLargeTx.dataPayload = []byte(strings.Repeat("1", maxParsedDataPayloadSize+1))
+	_, err = new(Transaction).UnmarshalObjectStorageValue(tooLargeTx.ObjectStorageValue())
+	assert.True(t, errors.Is(err, ErrMaxDataPayloadSizeExceeded), "unexpected error: %v", err)
 }
 
 func TestMarshalingEmptyDataPayload(t *testing.T) {
@@ -188,3 +199,32 @@ func TestPutSignatureInvalid(t *testing.T) {
 	// valid signatures expected
 	assert.Equal(t, true, tx.SignaturesValid())
 }
+
+func TestMultisigSignaturesValid(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	publicKeys := []ed25519.PublicKey{keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey}
+	addr := address.FromMultisigED25519PubKeys(2, publicKeys)
+
+	inputs := NewInputs(NewOutputID(addr, RandomID()))
+	outputs := NewOutputs(map[address.Address][]*balance.Balance{address.Random(): {balance.New(balance.ColorIOTA, 1)}})
+	tx := New(inputs, outputs)
+
+	// a single partial signature does not reach the threshold
+	sigScheme0, err := signaturescheme.MultisigED25519(2, publicKeys, keyPairs[0])
+	assert.NoError(t, err)
+	tx.Sign(sigScheme0)
+	assert.False(t, tx.SignaturesValid())
+
+	// the aggregated partial signatures of two signers unlock the address
+	sigScheme1, err := signaturescheme.MultisigED25519(2, publicKeys, keyPairs[1])
+	assert.NoError(t, err)
+	aggregated, err := signaturescheme.AggregateMultisigSignatures(sigScheme0.Sign(tx.EssenceBytes()), sigScheme1.Sign(tx.EssenceBytes()))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.PutSignature(aggregated))
+	assert.True(t, tx.SignaturesValid())
+
+	// the signatures survive marshaling
+	tx1, _, err := FromBytes(tx.Bytes())
+	assert.NoError(t, err)
+	assert.True(t, tx1.SignaturesValid())
+}
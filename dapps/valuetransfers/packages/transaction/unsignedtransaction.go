@@ -0,0 +1,459 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/stringify"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+// UnsignedTransactionVersion defines the version of the marshaled UnsignedTransaction format.
+const UnsignedTransactionVersion byte = 1
+
+var (
+	// ErrUnsignedTransactionVersionUnsupported is returned if a marshaled UnsignedTransaction has an unknown version.
+	ErrUnsignedTransactionVersionUnsupported = errors.New("unsupported unsigned transaction version")
+
+	// ErrSpentOutputsMismatch is returned if the spent outputs of an UnsignedTransaction do not match its inputs.
+	ErrSpentOutputsMismatch = errors.New("spent outputs do not match the inputs")
+
+	// ErrUnexpectedSignature is returned if a signature does not belong to any of the addresses that can unlock the
+	// inputs of an UnsignedTransaction.
+	ErrUnexpectedSignature = errors.New("signature does not belong to an input")
+
+	// ErrInvalidSignature is returned if a signature is not valid for the essence of an UnsignedTransaction.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrMissingSignatures is returned if an UnsignedTransaction is finalized before all inputs are unlocked.
+	ErrMissingSignatures = errors.New("missing signatures")
+
+	// ErrEssenceMismatch is returned if UnsignedTransactions with different essences are merged.
+	ErrEssenceMismatch = errors.New("the essences of the transactions differ")
+)
+
+// region UnsignedTransaction //////////////////////////////////////////////////////////////////////////////////////////
+
+// UnsignedTransaction is a portable envelope for a Transaction that still needs to be signed. Besides the essence of
+// the Transaction, it contains the outputs that are spent by its inputs and the signatures that were collected so far.
+// This allows signers without access to the ledger (i.e. cold storage wallets) to inspect what they are signing and
+// multiple parties to sign the same Transaction (i.e. atomic swaps of colored tokens or multisig addresses).
+type UnsignedTransaction struct {
+	essence      *Transaction
+	spentOutputs []*SpentOutput
+	signatures   *Signatures
+	mutex        sync.RWMutex
+}
+
+// NewUnsignedTransaction creates an UnsignedTransaction for the given Transaction. The spent outputs have to match the
+// inputs of the Transaction. Signatures that the Transaction contains already are taken over.
+func NewUnsignedTransaction(tx *Transaction, spentOutputs ...*SpentOutput) (result *UnsignedTransaction, err error) {
+	// collect the spent outputs in the order of the inputs
+	spentOutputsByID := make(map[OutputID]*SpentOutput, len(spentOutputs))
+	for _, spentOutput := range spentOutputs {
+		spentOutputsByID[spentOutput.ID()] = spentOutput
+	}
+	if len(spentOutputsByID) != len(spentOutputs) {
+		return nil, fmt.Errorf("%w: duplicate spent output", ErrSpentOutputsMismatch)
+	}
+
+	result = &UnsignedTransaction{
+		essence:      New(tx.Inputs(), tx.Outputs()),
+		spentOutputs: make([]*SpentOutput, 0, len(spentOutputs)),
+		signatures:   NewSignatures(),
+	}
+	if err = result.essence.SetDataPayload(tx.GetDataPayload()); err != nil {
+		return nil, err
+	}
+
+	tx.Inputs().ForEach(func(outputID OutputID) bool {
+		spentOutput, exists := spentOutputsByID[outputID]
+		if !exists {
+			err = fmt.Errorf("%w: missing spent output %s", ErrSpentOutputsMismatch, outputID)
+
+			return false
+		}
+		result.spentOutputs = append(result.spentOutputs, spentOutput)
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.spentOutputs) != len(spentOutputs) {
+		return nil, fmt.Errorf("%w: spent output without input", ErrSpentOutputsMismatch)
+	}
+
+	// take over the existing signatures
+	tx.signatures.ForEach(func(_ address.Address, signature signaturescheme.Signature) bool {
+		err = result.AddSignature(signature)
+
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// UnsignedTransactionFromBytes unmarshals an UnsignedTransaction from a sequence of bytes.
+func UnsignedTransactionFromBytes(bytes []byte) (result *UnsignedTransaction, consumedBytes int, err error) {
+	// initialize helper
+	marshalUtil := marshalutil.New(bytes)
+
+	// read version
+	version, err := marshalUtil.ReadByte()
+	if err != nil {
+		return
+	}
+	if version != UnsignedTransactionVersion {
+		err = fmt.Errorf("%w: %d", ErrUnsignedTransactionVersionUnsupported, version)
+
+		return
+	}
+
+	// read the essence and the collected signatures (they share the format of a Transaction)
+	tx, err := Parse(marshalUtil)
+	if err != nil {
+		return
+	}
+
+	// read the spent outputs
+	spentOutputsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return
+	}
+	spentOutputs := make([]*SpentOutput, 0, spentOutputsCount)
+	for i := uint32(0); i < spentOutputsCount; i++ {
+		spentOutput, spentOutputErr := ParseSpentOutput(marshalUtil)
+		if spentOutputErr != nil {
+			err = spentOutputErr
+
+			return
+		}
+		spentOutputs = append(spentOutputs, spentOutput)
+	}
+
+	if result, err = NewUnsignedTransaction(tx, spentOutputs...); err != nil {
+		return
+	}
+
+	// return the number of bytes we processed
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// Inputs returns the list of Inputs that are consumed by the Transaction.
+func (unsignedTransaction *UnsignedTransaction) Inputs() *Inputs {
+	return unsignedTransaction.essence.Inputs()
+}
+
+// Outputs returns the list of Outputs that are created by the Transaction.
+func (unsignedTransaction *UnsignedTransaction) Outputs() *Outputs {
+	return unsignedTransaction.essence.Outputs()
+}
+
+// DataPayload returns the data payload of the Transaction.
+func (unsignedTransaction *UnsignedTransaction) DataPayload() []byte {
+	return unsignedTransaction.essence.GetDataPayload()
+}
+
+// EssenceBytes returns the bytes of the Transaction, that are signed.
+func (unsignedTransaction *UnsignedTransaction) EssenceBytes() []byte {
+	return unsignedTransaction.essence.EssenceBytes()
+}
+
+// SpentOutputs returns the outputs that are spent by the Transaction (in the order of its inputs). They are provided by
+// the creator of the UnsignedTransaction and are not part of the signed essence, so signers have to verify them against
+// their own view of the ledger before trusting them.
+func (unsignedTransaction *UnsignedTransaction) SpentOutputs() []*SpentOutput {
+	return unsignedTransaction.spentOutputs
+}
+
+// ConsumedBalances returns the sum of the balances of the spent outputs. Just like the spent outputs, it is untrusted
+// information that has to be verified before it is relied upon.
+func (unsignedTransaction *UnsignedTransaction) ConsumedBalances() map[balance.Color]int64 {
+	consumedBalances := make(map[balance.Color]int64)
+	for _, spentOutput := range unsignedTransaction.spentOutputs {
+		for _, coloredBalance := range spentOutput.Balances() {
+			consumedBalances[coloredBalance.Color()] += coloredBalance.Value()
+		}
+	}
+
+	return consumedBalances
+}
+
+// Signatures returns the signatures that were collected so far.
+func (unsignedTransaction *UnsignedTransaction) Signatures() *Signatures {
+	return unsignedTransaction.signatures
+}
+
+// Sign signs the essence with the given signature scheme and adds the resulting signature.
+func (unsignedTransaction *UnsignedTransaction) Sign(signatureScheme signaturescheme.SignatureScheme) error {
+	return unsignedTransaction.AddSignature(signatureScheme.Sign(unsignedTransaction.EssenceBytes()))
+}
+
+// AddSignature validates the given signature and adds it to the collected signatures. The partial signatures of
+// multisig addresses are aggregated with the ones that were collected before.
+func (unsignedTransaction *UnsignedTransaction) AddSignature(signature signaturescheme.Signature) error {
+	signatureAddress := signature.Address()
+	if !unsignedTransaction.signerAddresses()[signatureAddress] {
+		return fmt.Errorf("%w: %s", ErrUnexpectedSignature, signatureAddress)
+	}
+
+	unsignedTransaction.mutex.Lock()
+	defer unsignedTransaction.mutex.Unlock()
+
+	multisigSignature, isMultisigSignature := signature.(*signaturescheme.MultisigSignature)
+	if !isMultisigSignature {
+		if !signature.IsValid(unsignedTransaction.EssenceBytes()) {
+			return fmt.Errorf("%w: %s", ErrInvalidSignature, signatureAddress)
+		}
+
+		unsignedTransaction.signatures.Add(signatureAddress, signature)
+
+		return nil
+	}
+
+	if !multisigSignature.PartialSignaturesValid(unsignedTransaction.EssenceBytes()) {
+		return fmt.Errorf("%w: %s", ErrInvalidSignature, signatureAddress)
+	}
+
+	if existingSignature, exists := unsignedTransaction.signatures.Get(signatureAddress); exists {
+		aggregatedSignature, err := signaturescheme.AggregateMultisigSignatures(existingSignature, signature)
+		if err != nil {
+			return err
+		}
+		signature = aggregatedSignature
+	}
+	unsignedTransaction.signatures.Add(signatureAddress, signature)
+
+	return nil
+}
+
+// Merge adds the signatures that were collected in the other UnsignedTransaction for the same essence. It allows
+// multiple parties to sign their copies independently.
+func (unsignedTransaction *UnsignedTransaction) Merge(other *UnsignedTransaction) (err error) {
+	if !bytes.Equal(unsignedTransaction.EssenceBytes(), other.EssenceBytes()) {
+		return ErrEssenceMismatch
+	}
+
+	other.signatures.ForEach(func(_ address.Address, signature signaturescheme.Signature) bool {
+		err = unsignedTransaction.AddSignature(signature)
+
+		return err == nil
+	})
+
+	return
+}
+
+// MissingSignatures returns the addresses, that still need to sign the Transaction to unlock its inputs at the given
+// time. It returns an error if one of the inputs can not be spent at all at the given time (i.e. it is timelocked).
+func (unsignedTransaction *UnsignedTransaction) MissingSignatures(referenceTime time.Time) (missingSignatures []address.Address, err error) {
+	seenAddresses := make(map[address.Address]bool)
+	for _, spentOutput := range unsignedTransaction.spentOutputs {
+		unlockAddress, unlockErr := spentOutput.Conditions().UnlockAddress(spentOutput.Address(), referenceTime)
+		if unlockErr != nil {
+			return nil, fmt.Errorf("failed to unlock output %s: %w", spentOutput.ID(), unlockErr)
+		}
+
+		if seenAddresses[unlockAddress] {
+			continue
+		}
+		seenAddresses[unlockAddress] = true
+
+		if signature, exists := unsignedTransaction.signatures.Get(unlockAddress); !exists || !signature.IsValid(unsignedTransaction.EssenceBytes()) {
+			missingSignatures = append(missingSignatures, unlockAddress)
+		}
+	}
+
+	return
+}
+
+// Finalize creates the signed Transaction. It returns an error if the collected signatures do not unlock all inputs at
+// the given time. The value tangle evaluates the conditions of the spent outputs at the issuing time of the message
+// that attaches the Transaction, so the reference time should be the (expected) issuing time of that message.
+func (unsignedTransaction *UnsignedTransaction) Finalize(referenceTime time.Time) (tx *Transaction, err error) {
+	missingSignatures, err := unsignedTransaction.MissingSignatures(referenceTime)
+	if err != nil {
+		return
+	}
+	if len(missingSignatures) != 0 {
+		err = fmt.Errorf("%w: %v", ErrMissingSignatures, missingSignatures)
+
+		return
+	}
+
+	tx = New(unsignedTransaction.Inputs(), unsignedTransaction.Outputs())
+	if err = tx.SetDataPayload(unsignedTransaction.DataPayload()); err != nil {
+		return
+	}
+	unsignedTransaction.signatures.ForEach(func(signatureAddress address.Address, signature signaturescheme.Signature) bool {
+		tx.signatures.Add(signatureAddress, signature)
+
+		return true
+	})
+
+	return
+}
+
+// Bytes returns a marshaled version of the UnsignedTransaction.
+func (unsignedTransaction *UnsignedTransaction) Bytes() []byte {
+	unsignedTransaction.mutex.RLock()
+	defer unsignedTransaction.mutex.RUnlock()
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(UnsignedTransactionVersion)
+	marshalUtil.WriteBytes(unsignedTransaction.EssenceBytes())
+	marshalUtil.WriteBytes(unsignedTransaction.signatures.Bytes())
+	marshalUtil.WriteUint32(uint32(len(unsignedTransaction.spentOutputs)))
+	for _, spentOutput := range unsignedTransaction.spentOutputs {
+		marshalUtil.WriteBytes(spentOutput.Bytes())
+	}
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the UnsignedTransaction.
+func (unsignedTransaction *UnsignedTransaction) String() string {
+	return stringify.Struct("UnsignedTransaction",
+		stringify.StructField("inputs", unsignedTransaction.Inputs()),
+		stringify.StructField("outputs", unsignedTransaction.Outputs()),
+		stringify.StructField("dataPayloadSize", unsignedTransaction.essence.DataPayloadSize()),
+		stringify.StructField("spentOutputs", unsignedTransaction.spentOutputs),
+		stringify.StructField("signatures", unsignedTransaction.signatures),
+	)
+}
+
+// signerAddresses returns the addresses that can unlock the inputs of the Transaction (at any time).
+func (unsignedTransaction *UnsignedTransaction) signerAddresses() map[address.Address]bool {
+	signerAddresses := make(map[address.Address]bool)
+	for _, spentOutput := range unsignedTransaction.spentOutputs {
+		signerAddresses[spentOutput.Address()] = true
+		if conditions := spentOutput.Conditions(); !conditions.Empty() && conditions.HasExpiry() {
+			signerAddresses[conditions.FallbackAddress()] = true
+		}
+	}
+
+	return signerAddresses
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region SpentOutput //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SpentOutput contains the balances and conditions of an output that is spent by an UnsignedTransaction.
+type SpentOutput struct {
+	id         OutputID
+	balances   []*balance.Balance
+	conditions *OutputConditions
+}
+
+// NewSpentOutput creates a SpentOutput from the given details (conditions are nil if the output has none).
+func NewSpentOutput(id OutputID, balances []*balance.Balance, conditions *OutputConditions) *SpentOutput {
+	return &SpentOutput{
+		id:         id,
+		balances:   balances,
+		conditions: conditions,
+	}
+}
+
+// SpentOutputFromBytes unmarshals a SpentOutput from a sequence of bytes.
+func SpentOutputFromBytes(bytes []byte) (result *SpentOutput, consumedBytes int, err error) {
+	// initialize helper
+	marshalUtil := marshalutil.New(bytes)
+	result = &SpentOutput{}
+
+	// read the id
+	if result.id, err = ParseOutputID(marshalUtil); err != nil {
+		return
+	}
+
+	// read the balances
+	balanceCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return
+	}
+	result.balances = make([]*balance.Balance, balanceCount)
+	for i := range result.balances {
+		if result.balances[i], err = balance.Parse(marshalUtil); err != nil {
+			return
+		}
+	}
+
+	// read the conditions
+	if result.conditions, err = ParseOutputConditions(marshalUtil); err != nil {
+		return
+	}
+	if result.conditions.Empty() {
+		result.conditions = nil
+	}
+
+	// return the number of bytes we processed
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// ParseSpentOutput is a wrapper for simplified unmarshaling of a byte stream using the marshalUtil package.
+func ParseSpentOutput(marshalUtil *marshalutil.MarshalUtil) (*SpentOutput, error) {
+	spentOutput, err := marshalUtil.Parse(func(data []byte) (interface{}, int, error) { return SpentOutputFromBytes(data) })
+	if err != nil {
+		return nil, err
+	}
+
+	return spentOutput.(*SpentOutput), nil
+}
+
+// ID returns the identifier of the spent output.
+func (spentOutput *SpentOutput) ID() OutputID {
+	return spentOutput.id
+}
+
+// Address returns the address of the spent output.
+func (spentOutput *SpentOutput) Address() address.Address {
+	return spentOutput.id.Address()
+}
+
+// Balances returns the balances of the spent output.
+func (spentOutput *SpentOutput) Balances() []*balance.Balance {
+	return spentOutput.balances
+}
+
+// Conditions returns the conditions of the spent output (nil if it has none).
+func (spentOutput *SpentOutput) Conditions() *OutputConditions {
+	return spentOutput.conditions
+}
+
+// Bytes returns a marshaled version of the SpentOutput.
+func (spentOutput *SpentOutput) Bytes() []byte {
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteBytes(spentOutput.id.Bytes())
+	marshalUtil.WriteUint32(uint32(len(spentOutput.balances)))
+	for _, coloredBalance := range spentOutput.balances {
+		marshalUtil.WriteBytes(coloredBalance.Bytes())
+	}
+	marshalUtil.WriteBytes(spentOutput.conditions.Bytes())
+
+	return marshalUtil.Bytes()
+}
+
+// String returns a human readable version of the SpentOutput.
+func (spentOutput *SpentOutput) String() string {
+	return stringify.Struct("SpentOutput",
+		stringify.StructField("id", spentOutput.id),
+		stringify.StructField("balances", spentOutput.balances),
+		stringify.StructField("conditions", spentOutput.conditions),
+	)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,181 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+func TestUnsignedTransaction_Marshaling(t *testing.T) {
+	sigScheme := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	fallbackAddress := address.Random()
+
+	plainOutput := NewSpentOutput(NewOutputID(sigScheme.Address(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, nil)
+	conditionalOutput := NewSpentOutput(NewOutputID(sigScheme.Address(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 50), balance.New(balance.Color{1}, 10)}, NewOutputConditions().WithExpiry(time.Now().Add(time.Hour), fallbackAddress))
+
+	tx := New(NewInputs(plainOutput.ID(), conditionalOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		address.Random(): {balance.New(balance.ColorIOTA, 150), balance.New(balance.Color{1}, 10)},
+	}))
+	require.NoError(t, tx.SetDataPayload([]byte("test")))
+
+	unsignedTx, err := NewUnsignedTransaction(tx, conditionalOutput, plainOutput)
+	require.NoError(t, err)
+	require.NoError(t, unsignedTx.Sign(sigScheme))
+
+	clonedUnsignedTx, consumedBytes, err := UnsignedTransactionFromBytes(unsignedTx.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, len(unsignedTx.Bytes()), consumedBytes)
+	assert.Equal(t, unsignedTx.Bytes(), clonedUnsignedTx.Bytes())
+	assert.Equal(t, unsignedTx.EssenceBytes(), clonedUnsignedTx.EssenceBytes())
+	assert.Equal(t, []byte("test"), clonedUnsignedTx.DataPayload())
+	assert.Equal(t, 1, clonedUnsignedTx.Signatures().Size())
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 150, {1}: 10}, clonedUnsignedTx.ConsumedBalances())
+
+	// the spent outputs are ordered like the inputs
+	require.Len(t, clonedUnsignedTx.SpentOutputs(), 2)
+	assert.Equal(t, plainOutput.ID(), clonedUnsignedTx.SpentOutputs()[0].ID())
+	assert.Nil(t, clonedUnsignedTx.SpentOutputs()[0].Conditions())
+	assert.Equal(t, conditionalOutput.ID(), clonedUnsignedTx.SpentOutputs()[1].ID())
+	assert.Equal(t, fallbackAddress, clonedUnsignedTx.SpentOutputs()[1].Conditions().FallbackAddress())
+
+	// unknown versions are rejected
+	marshaledUnsignedTx := unsignedTx.Bytes()
+	marshaledUnsignedTx[0] = UnsignedTransactionVersion + 1
+	_, _, err = UnsignedTransactionFromBytes(marshaledUnsignedTx)
+	assert.True(t, errors.Is(err, ErrUnsignedTransactionVersionUnsupported), "unexpected error: %v", err)
+}
+
+func TestNewUnsignedTransaction_SpentOutputsMismatch(t *testing.T) {
+	spentOutput := NewSpentOutput(NewOutputID(address.Random(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, nil)
+	otherOutput := NewSpentOutput(NewOutputID(address.Random(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, nil)
+	tx := New(NewInputs(spentOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		address.Random(): {balance.New(balance.ColorIOTA, 100)},
+	}))
+
+	for name, spentOutputs := range map[string][]*SpentOutput{
+		"missing":   {},
+		"unknown":   {otherOutput},
+		"redundant": {spentOutput, otherOutput},
+		"duplicate": {spentOutput, spentOutput},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewUnsignedTransaction(tx, spentOutputs...)
+			assert.True(t, errors.Is(err, ErrSpentOutputsMismatch), "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestUnsignedTransaction_AtomicSwap(t *testing.T) {
+	alice := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	bob := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+
+	aliceOutput := NewSpentOutput(NewOutputID(alice.Address(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, nil)
+	bobOutput := NewSpentOutput(NewOutputID(bob.Address(), RandomID()), []*balance.Balance{balance.New(balance.Color{1}, 10)}, nil)
+	tx := New(NewInputs(aliceOutput.ID(), bobOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		alice.Address(): {balance.New(balance.Color{1}, 10)},
+		bob.Address():   {balance.New(balance.ColorIOTA, 100)},
+	}))
+
+	aliceCopy, err := NewUnsignedTransaction(tx, aliceOutput, bobOutput)
+	require.NoError(t, err)
+	bobCopy, _, err := UnsignedTransactionFromBytes(aliceCopy.Bytes())
+	require.NoError(t, err)
+
+	// both parties sign their own copy
+	require.NoError(t, aliceCopy.Sign(alice))
+	require.NoError(t, bobCopy.Sign(bob))
+
+	missingSignatures, err := aliceCopy.MissingSignatures(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{bob.Address()}, missingSignatures)
+	_, err = aliceCopy.Finalize(time.Now())
+	assert.True(t, errors.Is(err, ErrMissingSignatures), "unexpected error: %v", err)
+
+	// signatures of unrelated addresses and invalid signatures are rejected
+	err = aliceCopy.Sign(signaturescheme.ED25519(ed25519.GenerateKeyPair()))
+	assert.True(t, errors.Is(err, ErrUnexpectedSignature), "unexpected error: %v", err)
+	err = aliceCopy.AddSignature(bob.Sign([]byte("something else")))
+	assert.True(t, errors.Is(err, ErrInvalidSignature), "unexpected error: %v", err)
+
+	// copies with a different essence can not be merged
+	otherTx := New(NewInputs(aliceOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		bob.Address(): {balance.New(balance.ColorIOTA, 100)},
+	}))
+	otherCopy, err := NewUnsignedTransaction(otherTx, aliceOutput)
+	require.NoError(t, err)
+	assert.True(t, errors.Is(aliceCopy.Merge(otherCopy), ErrEssenceMismatch))
+
+	// merge the signatures of bob and finalize
+	require.NoError(t, aliceCopy.Merge(bobCopy))
+	signedTx, err := aliceCopy.Finalize(time.Now())
+	require.NoError(t, err)
+	assert.True(t, signedTx.SignaturesValid())
+	assert.Equal(t, aliceCopy.EssenceBytes(), signedTx.EssenceBytes())
+}
+
+func TestUnsignedTransaction_Multisig(t *testing.T) {
+	keyPairs := []ed25519.KeyPair{ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair(), ed25519.GenerateKeyPair()}
+	publicKeys := []ed25519.PublicKey{keyPairs[0].PublicKey, keyPairs[1].PublicKey, keyPairs[2].PublicKey}
+	multisigAddress := address.FromMultisigED25519PubKeys(2, publicKeys)
+
+	spentOutput := NewSpentOutput(NewOutputID(multisigAddress, RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, nil)
+	tx := New(NewInputs(spentOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		address.Random(): {balance.New(balance.ColorIOTA, 100)},
+	}))
+	unsignedTx, err := NewUnsignedTransaction(tx, spentOutput)
+	require.NoError(t, err)
+
+	// each co-signer adds its partial signature separately
+	for i, keyPair := range []ed25519.KeyPair{keyPairs[0], keyPairs[2]} {
+		cosigner, err := signaturescheme.MultisigED25519(2, publicKeys, keyPair)
+		require.NoError(t, err)
+		require.NoError(t, unsignedTx.Sign(cosigner))
+
+		missingSignatures, err := unsignedTx.MissingSignatures(time.Now())
+		require.NoError(t, err)
+		assert.Equal(t, i == 0, len(missingSignatures) == 1)
+	}
+
+	signature, exists := unsignedTx.Signatures().Get(multisigAddress)
+	require.True(t, exists)
+	assert.Equal(t, []ed25519.PublicKey{publicKeys[0], publicKeys[2]}, signature.(*signaturescheme.MultisigSignature).Signers())
+
+	signedTx, err := unsignedTx.Finalize(time.Now())
+	require.NoError(t, err)
+	assert.True(t, signedTx.SignaturesValid())
+}
+
+func TestUnsignedTransaction_Conditions(t *testing.T) {
+	receiver := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	fallback := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	now := time.Now()
+
+	spentOutput := NewSpentOutput(NewOutputID(receiver.Address(), RandomID()), []*balance.Balance{balance.New(balance.ColorIOTA, 100)}, NewOutputConditions().WithTimelock(now.Add(-time.Hour)).WithExpiry(now.Add(time.Hour), fallback.Address()))
+	tx := New(NewInputs(spentOutput.ID()), NewOutputs(map[address.Address][]*balance.Balance{
+		address.Random(): {balance.New(balance.ColorIOTA, 100)},
+	}))
+	unsignedTx, err := NewUnsignedTransaction(tx, spentOutput)
+	require.NoError(t, err)
+
+	// the fallback address can sign ahead of time
+	require.NoError(t, unsignedTx.Sign(fallback))
+
+	_, err = unsignedTx.MissingSignatures(now.Add(-2 * time.Hour))
+	assert.True(t, errors.Is(err, ErrOutputTimelocked), "unexpected error: %v", err)
+
+	missingSignatures, err := unsignedTx.MissingSignatures(now)
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{receiver.Address()}, missingSignatures)
+
+	missingSignatures, err = unsignedTx.MissingSignatures(now.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, missingSignatures)
+}
@@ -0,0 +1,17 @@
+package wallet
+
+import (
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// Connector represents an interface that defines how the wallet interacts with the network. A wallet can either be
+// used locally on a server or it can connect remotely using the web API.
+type Connector interface {
+	// Outputs returns the outputs that are known for the given addresses. Connectors that can not see spent outputs
+	// (i.e. the web API) only return the unspent ones.
+	Outputs(addresses ...address.Address) (outputs map[address.Address]map[transaction.ID]*Output, err error)
+
+	// SendTransaction issues the given transaction to the network.
+	SendTransaction(tx *transaction.Transaction) (err error)
+}
@@ -0,0 +1,55 @@
+package wallet
+
+// DefaultGapLimit defines how many consecutive unused addresses are scanned before the wallet assumes that there are no
+// further used addresses.
+const DefaultGapLimit = 20
+
+// Options holds the options of a wallet.
+type Options struct {
+	seed             *Seed
+	lastAddressIndex uint64
+	gapLimit         int
+	connector        Connector
+}
+
+func newOptions(optionalOptions []Option) *Options {
+	result := &Options{
+		gapLimit: DefaultGapLimit,
+	}
+
+	for _, optionalOption := range optionalOptions {
+		optionalOption(result)
+	}
+
+	if result.seed == nil {
+		result.seed = NewSeed()
+	}
+
+	return result
+}
+
+// Option is a function which inits an option.
+type Option func(*Options)
+
+// Import creates an option which restores a wallet from the given seed and the index of the last address that was
+// handed out by the wallet.
+func Import(seed *Seed, lastAddressIndex uint64) Option {
+	return func(args *Options) {
+		args.seed = seed
+		args.lastAddressIndex = lastAddressIndex
+	}
+}
+
+// GapLimit creates an option which sets the amount of consecutive unused addresses that are scanned.
+func GapLimit(gapLimit int) Option {
+	return func(args *Options) {
+		args.gapLimit = gapLimit
+	}
+}
+
+// GenericConnector creates an option which sets the Connector that is used to interact with the network.
+func GenericConnector(connector Connector) Option {
+	return func(args *Options) {
+		args.connector = connector
+	}
+}
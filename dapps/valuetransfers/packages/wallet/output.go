@@ -0,0 +1,30 @@
+package wallet
+
+import (
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// Output is a wallet specific representation of an output in the IOTA network.
+type Output struct {
+	Address        address.Address
+	TransactionID  transaction.ID
+	Balances       map[balance.Color]int64
+	Conditions     *transaction.OutputConditions
+	InclusionState InclusionState
+}
+
+// ID returns the identifier of the Output.
+func (output *Output) ID() transaction.OutputID {
+	return transaction.NewOutputID(output.Address, output.TransactionID)
+}
+
+// InclusionState is a container for the different flags of an output that define if it was accepted in the network.
+type InclusionState struct {
+	Liked       bool
+	Confirmed   bool
+	Rejected    bool
+	Conflicting bool
+	Spent       bool
+}
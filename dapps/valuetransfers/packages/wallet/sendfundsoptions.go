@@ -0,0 +1,94 @@
+package wallet
+
+import (
+	"errors"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+)
+
+// SendFundsOption is the type for the optional parameters for the SendFunds call.
+type SendFundsOption func(*sendFundsOptions) error
+
+// Destination is an option for the SendFunds call that defines a destination for funds that are supposed to be moved.
+// The color defaults to IOTA. Using balance.ColorNew mints a new color from the consumed IOTA tokens.
+func Destination(addr address.Address, amount int64, optionalColor ...balance.Color) SendFundsOption {
+	// determine optional output color
+	var outputColor balance.Color
+	switch len(optionalColor) {
+	case 0:
+		outputColor = balance.ColorIOTA
+	case 1:
+		outputColor = optionalColor[0]
+	default:
+		return optionError(errors.New("you can only provide one color"))
+	}
+
+	return func(options *sendFundsOptions) error {
+		if amount <= 0 {
+			return errors.New("the amount provided in the destinations needs to be larger than 0")
+		}
+
+		if _, addressExists := options.destinations[addr]; !addressExists {
+			options.destinations[addr] = make(map[balance.Color]int64)
+		}
+		options.destinations[addr][outputColor] += amount
+
+		return nil
+	}
+}
+
+// Remainder is an option for the SendFunds call that allows us to specify the remainder address that is supposed to
+// be used in the corresponding transaction. If it is omitted, a new address of the wallet is used.
+func Remainder(addr address.Address) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		options.remainderAddress = &addr
+
+		return nil
+	}
+}
+
+// Data is an option for the SendFunds call that attaches the given data payload to the transaction.
+func Data(data []byte) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		options.data = data
+
+		return nil
+	}
+}
+
+// sendFundsOptions is a struct that is used to aggregate the optional parameters provided in the SendFunds call.
+type sendFundsOptions struct {
+	destinations     map[address.Address]map[balance.Color]int64
+	remainderAddress *address.Address
+	data             []byte
+}
+
+// buildSendFundsOptions is a utility function that constructs the sendFundsOptions.
+func buildSendFundsOptions(options ...SendFundsOption) (result *sendFundsOptions, err error) {
+	// create options to collect the arguments provided
+	result = &sendFundsOptions{
+		destinations: make(map[address.Address]map[balance.Color]int64),
+	}
+
+	// apply arguments to our options
+	for _, option := range options {
+		if err = option(result); err != nil {
+			return
+		}
+	}
+
+	// sanitize parameters
+	if len(result.destinations) == 0 {
+		err = errors.New("you need to provide at least one Destination for a valid transfer to be issued")
+	}
+
+	return
+}
+
+// optionError is a utility function that returns a SendFundsOption that returns the given error.
+func optionError(err error) SendFundsOption {
+	return func(options *sendFundsOptions) error {
+		return err
+	}
+}
@@ -0,0 +1,77 @@
+package wallet
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// TangleConnector is a Connector that reads the outputs directly from a local value tangle. Transactions are wrapped
+// in a value object by the given factory and attached to the tangle synchronously.
+type TangleConnector struct {
+	tangle             *tangle.Tangle
+	valueObjectFactory *tangle.ValueObjectFactory
+}
+
+// NewTangleConnector is the constructor of the TangleConnector.
+func NewTangleConnector(valueTangle *tangle.Tangle, valueObjectFactory *tangle.ValueObjectFactory) *TangleConnector {
+	return &TangleConnector{
+		tangle:             valueTangle,
+		valueObjectFactory: valueObjectFactory,
+	}
+}
+
+// Outputs returns all outputs (spent and unspent) that were booked on the given addresses.
+func (connector *TangleConnector) Outputs(addresses ...address.Address) (outputs map[address.Address]map[transaction.ID]*Output, err error) {
+	outputs = make(map[address.Address]map[transaction.ID]*Output)
+	for _, addr := range addresses {
+		connector.tangle.OutputsOnAddress(addr).Consume(func(output *tangle.Output) {
+			if _, exists := outputs[addr]; !exists {
+				outputs[addr] = make(map[transaction.ID]*Output)
+			}
+
+			balances := make(map[balance.Color]int64)
+			for _, coloredBalance := range output.Balances() {
+				balances[coloredBalance.Color()] += coloredBalance.Value()
+			}
+
+			outputs[addr][output.TransactionID()] = &Output{
+				Address:        addr,
+				TransactionID:  output.TransactionID(),
+				Balances:       balances,
+				Conditions:     output.Conditions(),
+				InclusionState: connector.inclusionState(output),
+			}
+		})
+	}
+
+	return
+}
+
+// SendTransaction attaches the given transaction to the local value tangle.
+func (connector *TangleConnector) SendTransaction(tx *transaction.Transaction) (err error) {
+	connector.tangle.AttachPayloadSync(connector.valueObjectFactory.IssueTransaction(tx), time.Now())
+
+	return
+}
+
+// inclusionState derives the InclusionState of an output from the metadata of the transaction that created it.
+// Outputs without a transaction metadata were loaded from the snapshot and are confirmed.
+func (connector *TangleConnector) inclusionState(output *tangle.Output) (inclusionState InclusionState) {
+	inclusionState.Spent = output.ConsumerCount() != 0
+
+	if !connector.tangle.TransactionMetadata(output.TransactionID()).Consume(func(metadata *tangle.TransactionMetadata) {
+		inclusionState.Liked = metadata.Preferred() && connector.tangle.BranchManager().IsBranchLiked(metadata.BranchID())
+		inclusionState.Confirmed = metadata.Finalized() && inclusionState.Liked
+		inclusionState.Rejected = metadata.Finalized() && !metadata.Preferred()
+		inclusionState.Conflicting = metadata.Conflicting()
+	}) {
+		inclusionState.Liked = true
+		inclusionState.Confirmed = true
+	}
+
+	return
+}
@@ -1,16 +1,58 @@
 package wallet
 
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var (
+	// ErrNoConnector is returned if the wallet needs to interact with the network but no Connector was configured.
+	ErrNoConnector = errors.New("the wallet has no connector")
+
+	// ErrInsufficientFunds is returned if the unspent outputs of the wallet do not cover the requested transfer.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+
+	// ErrNoSigningKeys is returned if the wallet owns none of the addresses that need to sign a transaction.
+	ErrNoSigningKeys = errors.New("the wallet owns none of the signing addresses")
+
+	// ErrSpentOutputMismatch is returned if an UnsignedTransaction spends an output of the wallet, whose balances or
+	// conditions differ from the ones known to the wallet.
+	ErrSpentOutputMismatch = errors.New("the spent output does not match the output known to the wallet")
+)
+
 // Wallet represents a simple cryptocurrency wallet for the IOTA tangle. It contains the logic to manage the movement of
 // funds.
 type Wallet struct {
-	seed *Seed
+	seed             *Seed
+	connector        Connector
+	gapLimit         int
+	lastAddressIndex uint64
+	addressIndexes   map[address.Address]uint64
+	outputs          map[address.Address]map[transaction.ID]*Output
+	mutex            sync.RWMutex
 }
 
 // New is the factory method of the wallet. It either creates a new wallet or restores the wallet backup that is handed
 // in as an optional parameter.
-func New(optionalRecoveryBytes ...[]byte) *Wallet {
+func New(options ...Option) *Wallet {
+	walletOptions := newOptions(options)
+
 	return &Wallet{
-		seed: NewSeed(optionalRecoveryBytes...),
+		seed:             walletOptions.seed,
+		connector:        walletOptions.connector,
+		gapLimit:         walletOptions.gapLimit,
+		lastAddressIndex: walletOptions.lastAddressIndex,
+		addressIndexes:   make(map[address.Address]uint64),
+		outputs:          make(map[address.Address]map[transaction.ID]*Output),
 	}
 }
 
@@ -18,3 +60,565 @@ func New(optionalRecoveryBytes ...[]byte) *Wallet {
 func (wallet *Wallet) Seed() *Seed {
 	return wallet.seed
 }
+
+// LastAddressIndex returns the index of the last address that was handed out by the wallet.
+func (wallet *Wallet) LastAddressIndex() uint64 {
+	wallet.mutex.RLock()
+	defer wallet.mutex.RUnlock()
+
+	return wallet.lastAddressIndex
+}
+
+// Addresses returns all addresses that were handed out by the wallet so far.
+func (wallet *Wallet) Addresses() (addresses []address.Address) {
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	addresses = make([]address.Address, 0, wallet.lastAddressIndex+1)
+	for i := uint64(0); i <= wallet.lastAddressIndex; i++ {
+		addresses = append(addresses, wallet.address(i))
+	}
+
+	return
+}
+
+// ReceiveAddress returns the current address that should be used to receive funds. A new address is generated as
+// soon as funds have been spent from the current one.
+func (wallet *Wallet) ReceiveAddress() address.Address {
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	currentAddress := wallet.address(wallet.lastAddressIndex)
+	for _, output := range wallet.outputs[currentAddress] {
+		if output.InclusionState.Spent {
+			return wallet.newReceiveAddress()
+		}
+	}
+
+	return currentAddress
+}
+
+// NewReceiveAddress generates and returns a new unused address of the wallet.
+func (wallet *Wallet) NewReceiveAddress() address.Address {
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	return wallet.newReceiveAddress()
+}
+
+// Refresh scans the addresses of the wallet for outputs. It continues to scan new addresses until it has seen the
+// configured gap limit of consecutive addresses without any outputs.
+func (wallet *Wallet) Refresh() (err error) {
+	if wallet.connector == nil {
+		return ErrNoConnector
+	}
+
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	lastUsedIndex := int64(-1)
+	scanStartIndex := uint64(0)
+	for {
+		scanEndIndex := uint64(lastUsedIndex+1) + uint64(wallet.gapLimit)
+		if scanEndIndex <= wallet.lastAddressIndex {
+			scanEndIndex = wallet.lastAddressIndex + 1
+		}
+		if scanEndIndex <= scanStartIndex {
+			break
+		}
+
+		addressesToScan := make([]address.Address, 0, scanEndIndex-scanStartIndex)
+		for i := scanStartIndex; i < scanEndIndex; i++ {
+			addressesToScan = append(addressesToScan, wallet.address(i))
+		}
+
+		outputs, connectorErr := wallet.connector.Outputs(addressesToScan...)
+		if connectorErr != nil {
+			return connectorErr
+		}
+		wallet.updateOutputs(addressesToScan, outputs)
+
+		previousLastUsedIndex := lastUsedIndex
+		for i := scanStartIndex; i < scanEndIndex; i++ {
+			if len(wallet.outputs[wallet.address(i)]) != 0 {
+				lastUsedIndex = int64(i)
+			}
+		}
+
+		// abort if the last scan did not reveal any new used addresses
+		if lastUsedIndex == previousLastUsedIndex {
+			break
+		}
+		scanStartIndex = scanEndIndex
+	}
+
+	if lastUsedIndex > int64(wallet.lastAddressIndex) {
+		wallet.lastAddressIndex = uint64(lastUsedIndex)
+	}
+
+	return
+}
+
+// Outputs returns all outputs of the wallet that were seen during the last Refresh (spent and unspent).
+func (wallet *Wallet) Outputs() (outputs []*Output) {
+	wallet.mutex.RLock()
+	defer wallet.mutex.RUnlock()
+
+	for _, transactionOutputs := range wallet.outputs {
+		for _, output := range transactionOutputs {
+			outputs = append(outputs, output)
+		}
+	}
+
+	return
+}
+
+// UnspentOutputs returns the outputs of the wallet that have not been spent yet and that were not rejected.
+func (wallet *Wallet) UnspentOutputs() (outputs []*Output) {
+	wallet.mutex.RLock()
+	defer wallet.mutex.RUnlock()
+
+	return wallet.unspentOutputs()
+}
+
+// Balances returns the confirmed and the pending (liked but not confirmed yet) balances of the wallet.
+func (wallet *Wallet) Balances() (confirmedBalances map[balance.Color]int64, pendingBalances map[balance.Color]int64) {
+	wallet.mutex.RLock()
+	defer wallet.mutex.RUnlock()
+
+	confirmedBalances = make(map[balance.Color]int64)
+	pendingBalances = make(map[balance.Color]int64)
+	for _, output := range wallet.unspentOutputs() {
+		targetBalances := pendingBalances
+		if output.InclusionState.Confirmed {
+			targetBalances = confirmedBalances
+		}
+
+		for color, value := range output.Balances {
+			targetBalances[color] += value
+		}
+	}
+
+	return
+}
+
+// SendFunds issues a transaction that moves the funds to the destinations given in the options. The inputs are
+// selected from the unspent outputs of the wallet and the remaining funds are sent to the remainder address.
+func (wallet *Wallet) SendFunds(options ...SendFundsOption) (tx *transaction.Transaction, err error) {
+	if wallet.connector == nil {
+		return nil, ErrNoConnector
+	}
+
+	sendOptions, err := buildSendFundsOptions(options...)
+	if err != nil {
+		return
+	}
+
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	referenceTime := time.Now()
+	tx, consumedOutputs, remainderAddressUsed, err := wallet.buildTransaction(sendOptions, referenceTime)
+	if err != nil {
+		return
+	}
+
+	if err = wallet.sign(tx, consumedOutputs, referenceTime); err != nil {
+		return
+	}
+
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return
+	}
+
+	// hand out the remainder address only once the transaction was issued, so failed transfers do not use up addresses
+	if remainderAddressUsed {
+		wallet.lastAddressIndex++
+	}
+
+	// mark the consumed outputs as spent, so they are not used again before the next refresh
+	for _, output := range consumedOutputs {
+		output.InclusionState.Spent = true
+	}
+
+	return
+}
+
+// CreateUnsignedTransaction selects the inputs for the destinations given in the options like SendFunds but returns
+// the transaction as an UnsignedTransaction instead of issuing it. The UnsignedTransaction contains the outputs that
+// are spent, so it can be inspected and signed by wallets without a connection to the network (see
+// SignUnsignedTransaction) and issued later using SendUnsignedTransaction.
+func (wallet *Wallet) CreateUnsignedTransaction(options ...SendFundsOption) (unsignedTx *transaction.UnsignedTransaction, err error) {
+	sendOptions, err := buildSendFundsOptions(options...)
+	if err != nil {
+		return
+	}
+
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	tx, consumedOutputs, remainderAddressUsed, err := wallet.buildTransaction(sendOptions, time.Now())
+	if err != nil {
+		return
+	}
+
+	// the transaction can be issued by anybody later, so the remainder address is handed out right away
+	if remainderAddressUsed {
+		wallet.lastAddressIndex++
+	}
+
+	spentOutputs := make([]*transaction.SpentOutput, len(consumedOutputs))
+	for i, output := range consumedOutputs {
+		spentOutputs[i] = transaction.NewSpentOutput(output.ID(), toColoredBalances(output.Balances), output.Conditions)
+	}
+
+	return transaction.NewUnsignedTransaction(tx, spentOutputs...)
+}
+
+// SignUnsignedTransaction adds the signatures of all addresses of the wallet that can unlock one of the spent outputs
+// of the given UnsignedTransaction. Since the spent outputs are provided by the creator of the UnsignedTransaction,
+// the outputs of the wallet among them have to match the outputs known to the wallet (see Refresh) - otherwise it
+// returns ErrSpentOutputMismatch without signing. It returns ErrNoSigningKeys if none of the addresses belongs to the
+// wallet.
+func (wallet *Wallet) SignUnsignedTransaction(unsignedTx *transaction.UnsignedTransaction) (err error) {
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	// derive the addresses of the wallet, so wallets that were never refreshed (i.e. in cold storage) know them
+	for i := uint64(0); i <= wallet.lastAddressIndex; i++ {
+		wallet.address(i)
+	}
+
+	if err = wallet.verifySpentOutputs(unsignedTx.SpentOutputs()); err != nil {
+		return
+	}
+
+	signedAddresses := make(map[address.Address]bool)
+	for _, spentOutput := range unsignedTx.SpentOutputs() {
+		signerAddresses := []address.Address{spentOutput.Address()}
+		if conditions := spentOutput.Conditions(); !conditions.Empty() && conditions.HasExpiry() {
+			signerAddresses = append(signerAddresses, conditions.FallbackAddress())
+		}
+
+		for _, signerAddress := range signerAddresses {
+			index, exists := wallet.addressIndexes[signerAddress]
+			if !exists || signedAddresses[signerAddress] {
+				continue
+			}
+
+			if err = unsignedTx.Sign(signaturescheme.ED25519(*wallet.seed.KeyPair(index))); err != nil {
+				return
+			}
+			signedAddresses[signerAddress] = true
+		}
+	}
+
+	if len(signedAddresses) == 0 {
+		err = ErrNoSigningKeys
+	}
+
+	return
+}
+
+// SendUnsignedTransaction finalizes the given UnsignedTransaction and issues the resulting transaction. Outputs of the
+// wallet that are spent by it are marked as spent.
+func (wallet *Wallet) SendUnsignedTransaction(unsignedTx *transaction.UnsignedTransaction) (tx *transaction.Transaction, err error) {
+	if wallet.connector == nil {
+		return nil, ErrNoConnector
+	}
+
+	if tx, err = unsignedTx.Finalize(time.Now()); err != nil {
+		return
+	}
+
+	wallet.mutex.Lock()
+	defer wallet.mutex.Unlock()
+
+	if err = wallet.connector.SendTransaction(tx); err != nil {
+		return
+	}
+
+	// mark the consumed outputs as spent, so they are not used again before the next refresh
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		if output, exists := wallet.outputs[outputID.Address()][outputID.TransactionID()]; exists {
+			output.InclusionState.Spent = true
+		}
+
+		return true
+	})
+
+	return
+}
+
+// buildTransaction selects the inputs for the given options and creates the corresponding unsigned transaction. If no
+// remainder address was given, the remaining funds are sent to the next unused address of the wallet, which the caller
+// has to hand out (remainderAddressUsed) once the transaction is issued.
+func (wallet *Wallet) buildTransaction(sendOptions *sendFundsOptions, referenceTime time.Time) (tx *transaction.Transaction, consumedOutputs []*Output, remainderAddressUsed bool, err error) {
+	requiredFunds := make(map[balance.Color]int64)
+	for _, coloredBalances := range sendOptions.destinations {
+		for color, amount := range coloredBalances {
+			// new colors are minted from IOTA tokens
+			if color == balance.ColorNew {
+				color = balance.ColorIOTA
+			}
+
+			requiredFunds[color] += amount
+		}
+	}
+
+	consumedOutputs, consumedFunds, err := wallet.selectInputs(requiredFunds, referenceTime)
+	if err != nil {
+		return
+	}
+
+	outputs := make(map[address.Address]map[balance.Color]int64)
+	for addr, coloredBalances := range sendOptions.destinations {
+		outputs[addr] = make(map[balance.Color]int64)
+		for color, amount := range coloredBalances {
+			outputs[addr][color] += amount
+		}
+	}
+
+	// send the remaining funds to the remainder address
+	remainingFunds := make(map[balance.Color]int64)
+	for color, amount := range consumedFunds {
+		if remainingAmount := amount - requiredFunds[color]; remainingAmount > 0 {
+			remainingFunds[color] = remainingAmount
+		}
+	}
+	if len(remainingFunds) != 0 {
+		var remainderAddress address.Address
+		if sendOptions.remainderAddress != nil {
+			remainderAddress = *sendOptions.remainderAddress
+		} else {
+			remainderAddress = wallet.address(wallet.lastAddressIndex + 1)
+			remainderAddressUsed = true
+		}
+
+		if _, exists := outputs[remainderAddress]; !exists {
+			outputs[remainderAddress] = make(map[balance.Color]int64)
+		}
+		for color, amount := range remainingFunds {
+			outputs[remainderAddress][color] += amount
+		}
+	}
+
+	inputIDs := make([]transaction.OutputID, len(consumedOutputs))
+	for i, output := range consumedOutputs {
+		inputIDs[i] = output.ID()
+	}
+
+	tx = transaction.New(transaction.NewInputs(inputIDs...), transaction.NewOutputs(toBalances(outputs)))
+	if len(sendOptions.data) != 0 {
+		err = tx.SetDataPayload(sendOptions.data)
+	}
+
+	return
+}
+
+// selectInputs selects unspent outputs until the required funds of every color are covered. Outputs with a higher
+// amount of the required color are preferred to keep the amount of inputs low. Outputs that the wallet can not unlock at
+// the given time (i.e. they are timelocked or expired) are skipped.
+func (wallet *Wallet) selectInputs(requiredFunds map[balance.Color]int64, referenceTime time.Time) (consumedOutputs []*Output, consumedFunds map[balance.Color]int64, err error) {
+	unspentOutputs := wallet.spendableOutputs(referenceTime)
+	consumed := make(map[transaction.OutputID]bool)
+	consumedFunds = make(map[balance.Color]int64)
+
+	for _, color := range sortedColors(requiredFunds) {
+		sort.SliceStable(unspentOutputs, func(i, j int) bool {
+			return unspentOutputs[i].Balances[color] > unspentOutputs[j].Balances[color]
+		})
+
+		for _, output := range unspentOutputs {
+			if consumedFunds[color] >= requiredFunds[color] {
+				break
+			}
+
+			if output.Balances[color] == 0 || consumed[output.ID()] {
+				continue
+			}
+
+			consumed[output.ID()] = true
+			consumedOutputs = append(consumedOutputs, output)
+			for outputColor, amount := range output.Balances {
+				consumedFunds[outputColor] += amount
+			}
+		}
+
+		if consumedFunds[color] < requiredFunds[color] {
+			err = fmt.Errorf("%w: %d of %d %s available", ErrInsufficientFunds, consumedFunds[color], requiredFunds[color], color)
+
+			return
+		}
+	}
+
+	return
+}
+
+// sign signs the given transaction with the keys of the addresses that unlock the consumed outputs at the given time
+// (the fallback address of expired outputs instead of the receiving address).
+func (wallet *Wallet) sign(tx *transaction.Transaction, consumedOutputs []*Output, referenceTime time.Time) error {
+	signedAddresses := make(map[address.Address]bool)
+	for _, output := range consumedOutputs {
+		unlockAddress, err := output.Conditions.UnlockAddress(output.Address, referenceTime)
+		if err != nil {
+			return fmt.Errorf("failed to unlock output %s: %w", output.ID(), err)
+		}
+		if signedAddresses[unlockAddress] {
+			continue
+		}
+
+		if unlockAddress.Version() != address.VersionED25519 {
+			return fmt.Errorf("unsupported address version %d of input %s", unlockAddress.Version(), unlockAddress)
+		}
+
+		index, exists := wallet.addressIndexes[unlockAddress]
+		if !exists {
+			return fmt.Errorf("the address %s does not belong to the wallet", unlockAddress)
+		}
+
+		tx.Sign(signaturescheme.ED25519(*wallet.seed.KeyPair(index)))
+		signedAddresses[unlockAddress] = true
+	}
+
+	return nil
+}
+
+// verifySpentOutputs checks that the given spent outputs, that belong to the wallet, match the balances and conditions
+// of the outputs known to the wallet.
+func (wallet *Wallet) verifySpentOutputs(spentOutputs []*transaction.SpentOutput) error {
+	for _, spentOutput := range spentOutputs {
+		if _, exists := wallet.addressIndexes[spentOutput.Address()]; !exists {
+			continue
+		}
+
+		knownOutput, exists := wallet.outputs[spentOutput.Address()][spentOutput.ID().TransactionID()]
+		if !exists {
+			return fmt.Errorf("%w: the output %s is unknown", ErrSpentOutputMismatch, spentOutput.ID())
+		}
+
+		spentBalances := make(map[balance.Color]int64)
+		for _, coloredBalance := range spentOutput.Balances() {
+			spentBalances[coloredBalance.Color()] += coloredBalance.Value()
+		}
+		if len(spentBalances) != len(knownOutput.Balances) {
+			return fmt.Errorf("%w: the balances of the output %s differ", ErrSpentOutputMismatch, spentOutput.ID())
+		}
+		for color, value := range knownOutput.Balances {
+			if spentBalances[color] != value {
+				return fmt.Errorf("%w: the balances of the output %s differ", ErrSpentOutputMismatch, spentOutput.ID())
+			}
+		}
+
+		if !bytes.Equal(spentOutput.Conditions().Bytes(), knownOutput.Conditions.Bytes()) {
+			return fmt.Errorf("%w: the conditions of the output %s differ", ErrSpentOutputMismatch, spentOutput.ID())
+		}
+	}
+
+	return nil
+}
+
+// updateOutputs merges the outputs returned by the Connector into the known outputs of the wallet. Outputs that were
+// known before but that are no longer returned by the Connector have been spent.
+func (wallet *Wallet) updateOutputs(scannedAddresses []address.Address, outputs map[address.Address]map[transaction.ID]*Output) {
+	for _, scannedAddress := range scannedAddresses {
+		for transactionID, knownOutput := range wallet.outputs[scannedAddress] {
+			if _, exists := outputs[scannedAddress][transactionID]; !exists {
+				knownOutput.InclusionState.Spent = true
+			}
+		}
+
+		for transactionID, output := range outputs[scannedAddress] {
+			if _, exists := wallet.outputs[scannedAddress]; !exists {
+				wallet.outputs[scannedAddress] = make(map[transaction.ID]*Output)
+			}
+
+			// keep the spent flag of outputs that we spent ourselves but that the network has not seen yet
+			if knownOutput, exists := wallet.outputs[scannedAddress][transactionID]; exists && knownOutput.InclusionState.Spent {
+				output.InclusionState.Spent = true
+			}
+
+			wallet.outputs[scannedAddress][transactionID] = output
+		}
+	}
+}
+
+func (wallet *Wallet) unspentOutputs() (outputs []*Output) {
+	for _, transactionOutputs := range wallet.outputs {
+		for _, output := range transactionOutputs {
+			if output.InclusionState.Spent || output.InclusionState.Rejected || !output.InclusionState.Liked {
+				continue
+			}
+
+			outputs = append(outputs, output)
+		}
+	}
+
+	// sort the outputs to make the input selection deterministic
+	sort.Slice(outputs, func(i, j int) bool {
+		return bytes.Compare(outputs[i].ID().Bytes(), outputs[j].ID().Bytes()) < 0
+	})
+
+	return
+}
+
+// spendableOutputs returns the unspent outputs, that the wallet can unlock at the given time.
+func (wallet *Wallet) spendableOutputs(referenceTime time.Time) (outputs []*Output) {
+	for _, output := range wallet.unspentOutputs() {
+		unlockAddress, err := output.Conditions.UnlockAddress(output.Address, referenceTime)
+		if err != nil {
+			continue
+		}
+		if _, exists := wallet.addressIndexes[unlockAddress]; !exists {
+			continue
+		}
+
+		outputs = append(outputs, output)
+	}
+
+	return
+}
+
+func (wallet *Wallet) newReceiveAddress() address.Address {
+	wallet.lastAddressIndex++
+
+	return wallet.address(wallet.lastAddressIndex)
+}
+
+// address derives the address with the given index and remembers its index for signing.
+func (wallet *Wallet) address(index uint64) address.Address {
+	addr := wallet.seed.Address(index)
+	wallet.addressIndexes[addr] = index
+
+	return addr
+}
+
+func toBalances(coloredAmounts map[address.Address]map[balance.Color]int64) (result map[address.Address][]*balance.Balance) {
+	result = make(map[address.Address][]*balance.Balance)
+	for addr, amounts := range coloredAmounts {
+		result[addr] = toColoredBalances(amounts)
+	}
+
+	return
+}
+
+func toColoredBalances(amounts map[balance.Color]int64) (result []*balance.Balance) {
+	for _, color := range sortedColors(amounts) {
+		result = append(result, balance.New(color, amounts[color]))
+	}
+
+	return
+}
+
+func sortedColors(coloredAmounts map[balance.Color]int64) (colors []balance.Color) {
+	colors = make([]balance.Color, 0, len(coloredAmounts))
+	for color := range coloredAmounts {
+		colors = append(colors, color)
+	}
+
+	sort.Slice(colors, func(i, j int) bool {
+		return bytes.Compare(colors[i][:], colors[j][:]) < 0
+	})
+
+	return
+}
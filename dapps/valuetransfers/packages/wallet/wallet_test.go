@@ -0,0 +1,204 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/consensus"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tipmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func TestWallet_SendFunds(t *testing.T) {
+	valueTangle := tangle.New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+	consensus.NewFCOB(valueTangle, 0)
+
+	seed := NewSeed()
+	valueTangle.LoadSnapshot(tangle.Snapshot{
+		transaction.GenesisID: {
+			seed.Address(0): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1000),
+			},
+			// this address lies behind a gap of unused addresses
+			seed.Address(5): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 337),
+			},
+		},
+	})
+
+	connector := NewTangleConnector(valueTangle, tangle.NewValueObjectFactory(tipmanager.New()))
+	wallet := New(Import(seed, 0), GapLimit(10), GenericConnector(connector))
+
+	// scan the addresses of the wallet
+	require.NoError(t, wallet.Refresh())
+	assert.Equal(t, uint64(5), wallet.LastAddressIndex())
+	confirmedBalances, pendingBalances := wallet.Balances()
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 1337}, confirmedBalances)
+	assert.Empty(t, pendingBalances)
+
+	// send funds and mint a new color
+	destination := address.Random()
+	tx, err := wallet.SendFunds(
+		Destination(destination, 1100),
+		Destination(destination, 100, balance.ColorNew),
+	)
+	require.NoError(t, err)
+	assert.True(t, tx.SignaturesValid())
+
+	// the remainder was sent to a new address of the wallet
+	assert.Equal(t, uint64(6), wallet.LastAddressIndex())
+	require.NoError(t, wallet.Refresh())
+	confirmedBalances, pendingBalances = wallet.Balances()
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 137}, confirmedBalances)
+	assert.Empty(t, pendingBalances)
+
+	destinationBalances := tangle.NewLedgerState(valueTangle).Balances(destination)
+	assert.Equal(t, map[balance.Color]int64{
+		balance.ColorIOTA:      1100,
+		balance.Color(tx.ID()): 100,
+	}, destinationBalances)
+
+	// the wallet can not spend more than it owns
+	_, err = wallet.SendFunds(Destination(destination, 138))
+	assert.True(t, errors.Is(err, ErrInsufficientFunds))
+}
+
+func TestWallet_UnsignedTransaction(t *testing.T) {
+	valueTangle := tangle.New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+	consensus.NewFCOB(valueTangle, 0)
+
+	seed := NewSeed()
+	valueTangle.LoadSnapshot(tangle.Snapshot{
+		transaction.GenesisID: {
+			seed.Address(0): []*balance.Balance{
+				balance.New(balance.ColorIOTA, 1000),
+			},
+		},
+	})
+
+	// the online wallet knows the outputs but does not need to sign
+	connector := NewTangleConnector(valueTangle, tangle.NewValueObjectFactory(tipmanager.New()))
+	onlineWallet := New(Import(seed, 0), GenericConnector(connector))
+	require.NoError(t, onlineWallet.Refresh())
+
+	destination := address.Random()
+	unsignedTx, err := onlineWallet.CreateUnsignedTransaction(Destination(destination, 400))
+	require.NoError(t, err)
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 1000}, unsignedTx.ConsumedBalances())
+	missingSignatures, err := unsignedTx.MissingSignatures(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, []address.Address{seed.Address(0)}, missingSignatures)
+
+	// the transaction can not be issued before it is signed
+	_, err = onlineWallet.SendUnsignedTransaction(unsignedTx)
+	assert.True(t, errors.Is(err, transaction.ErrMissingSignatures))
+
+	// the offline wallet signs a copy of the transaction, if the spent outputs match the outputs it knows
+	offlineCopy, _, err := transaction.UnsignedTransactionFromBytes(unsignedTx.Bytes())
+	require.NoError(t, err)
+	assert.True(t, errors.Is(New().SignUnsignedTransaction(offlineCopy), ErrNoSigningKeys))
+	assert.True(t, errors.Is(New(Import(seed, 0)).SignUnsignedTransaction(offlineCopy), ErrSpentOutputMismatch))
+
+	genesisOutput := &Output{Address: seed.Address(0), TransactionID: transaction.GenesisID, Balances: map[balance.Color]int64{balance.ColorIOTA: 1000}}
+	tamperedOutput := &Output{Address: seed.Address(0), TransactionID: transaction.GenesisID, Balances: map[balance.Color]int64{balance.ColorIOTA: 2000}}
+	lockedOutput := &Output{Address: seed.Address(0), TransactionID: transaction.GenesisID, Balances: map[balance.Color]int64{balance.ColorIOTA: 1000}, Conditions: transaction.NewOutputConditions().WithTimelock(time.Now().Add(time.Hour))}
+	for _, mismatchingOutput := range []*Output{tamperedOutput, lockedOutput} {
+		mismatchingWallet := New(Import(seed, 0), GenericConnector(&mockConnector{outputs: []*Output{mismatchingOutput}}))
+		require.NoError(t, mismatchingWallet.Refresh())
+		assert.True(t, errors.Is(mismatchingWallet.SignUnsignedTransaction(offlineCopy), ErrSpentOutputMismatch))
+	}
+	assert.Zero(t, offlineCopy.Signatures().Size())
+
+	offlineWallet := New(Import(seed, 0), GenericConnector(&mockConnector{outputs: []*Output{genesisOutput}}))
+	require.NoError(t, offlineWallet.Refresh())
+	require.NoError(t, offlineWallet.SignUnsignedTransaction(offlineCopy))
+
+	signedCopy, _, err := transaction.UnsignedTransactionFromBytes(offlineCopy.Bytes())
+	require.NoError(t, err)
+	tx, err := onlineWallet.SendUnsignedTransaction(signedCopy)
+	require.NoError(t, err)
+	assert.True(t, tx.SignaturesValid())
+
+	require.NoError(t, onlineWallet.Refresh())
+	confirmedBalances, _ := onlineWallet.Balances()
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 600}, confirmedBalances)
+	assert.Equal(t, map[balance.Color]int64{balance.ColorIOTA: 400}, tangle.NewLedgerState(valueTangle).Balances(destination))
+}
+
+func TestWallet_OutputConditions(t *testing.T) {
+	seed := NewSeed()
+	now := time.Now()
+
+	timelockedOutput := &Output{Address: seed.Address(0), TransactionID: transaction.RandomID(), Balances: map[balance.Color]int64{balance.ColorIOTA: 1000}, Conditions: transaction.NewOutputConditions().WithTimelock(now.Add(time.Hour))}
+	lostOutput := &Output{Address: seed.Address(1), TransactionID: transaction.RandomID(), Balances: map[balance.Color]int64{balance.ColorIOTA: 500}, Conditions: transaction.NewOutputConditions().WithExpiry(now.Add(-time.Hour), address.Random())}
+	reclaimedOutput := &Output{Address: seed.Address(1), TransactionID: transaction.RandomID(), Balances: map[balance.Color]int64{balance.ColorIOTA: 100}, Conditions: transaction.NewOutputConditions().WithExpiry(now.Add(-time.Hour), seed.Address(0))}
+
+	connector := &mockConnector{outputs: []*Output{timelockedOutput, lostOutput, reclaimedOutput}}
+	wallet := New(Import(seed, 1), GenericConnector(connector))
+	require.NoError(t, wallet.Refresh())
+
+	// only the expired output with the wallet as fallback can be spent
+	destination := address.Random()
+	_, err := wallet.SendFunds(Destination(destination, 200))
+	assert.True(t, errors.Is(err, ErrInsufficientFunds), "unexpected error: %v", err)
+
+	// failed transfers do not use up the remainder address
+	connector.sendErr = errors.New("node unavailable")
+	_, err = wallet.SendFunds(Destination(destination, 50))
+	assert.Error(t, err)
+	assert.Equal(t, uint64(1), wallet.LastAddressIndex())
+
+	connector.sendErr = nil
+	tx, err := wallet.SendFunds(Destination(destination, 50))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), wallet.LastAddressIndex())
+	assert.Equal(t, 1, tx.Inputs().Size())
+	assert.True(t, tx.SignatureValid(seed.Address(0)))
+	assert.Equal(t, []*transaction.Transaction{tx}, connector.sentTransactions)
+}
+
+// mockConnector is a Connector that serves a static list of outputs and records the sent transactions.
+type mockConnector struct {
+	outputs          []*Output
+	sentTransactions []*transaction.Transaction
+	sendErr          error
+}
+
+func (connector *mockConnector) Outputs(addresses ...address.Address) (outputs map[address.Address]map[transaction.ID]*Output, err error) {
+	outputs = make(map[address.Address]map[transaction.ID]*Output)
+	for _, addr := range addresses {
+		for _, output := range connector.outputs {
+			if output.Address != addr {
+				continue
+			}
+
+			if _, exists := outputs[addr]; !exists {
+				outputs[addr] = make(map[transaction.ID]*Output)
+			}
+			clonedOutput := *output
+			clonedOutput.InclusionState = InclusionState{Liked: true, Confirmed: true}
+			outputs[addr][output.TransactionID] = &clonedOutput
+		}
+	}
+
+	return
+}
+
+func (connector *mockConnector) SendTransaction(tx *transaction.Transaction) error {
+	if connector.sendErr != nil {
+		return connector.sendErr
+	}
+	connector.sentTransactions = append(connector.sentTransactions, tx)
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package wallet
+
+import (
+	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// WebConnector is a Connector that uses the web API of a node to interact with the network.
+type WebConnector struct {
+	client *client.GoShimmerAPI
+}
+
+// NewWebConnector is the constructor of the WebConnector.
+func NewWebConnector(api *client.GoShimmerAPI) *WebConnector {
+	return &WebConnector{
+		client: api,
+	}
+}
+
+// Outputs returns the unspent outputs of the given addresses (the web API does not expose spent outputs).
+func (connector *WebConnector) Outputs(addresses ...address.Address) (outputs map[address.Address]map[transaction.ID]*Output, err error) {
+	base58EncodedAddresses := make([]string, len(addresses))
+	for i, addr := range addresses {
+		base58EncodedAddresses[i] = addr.String()
+	}
+
+	response, err := connector.client.GetUnspentOutputs(base58EncodedAddresses)
+	if err != nil {
+		return
+	}
+
+	outputs = make(map[address.Address]map[transaction.ID]*Output)
+	for _, unspentOutput := range response.UnspentOutputs {
+		addr, addressErr := address.FromBase58(unspentOutput.Address)
+		if addressErr != nil {
+			return nil, addressErr
+		}
+
+		for _, outputID := range unspentOutput.OutputIDs {
+			id, idErr := transaction.OutputIDFromBase58(outputID.ID)
+			if idErr != nil {
+				return nil, idErr
+			}
+
+			balances := make(map[balance.Color]int64)
+			for _, coloredBalance := range outputID.Balances {
+				color, colorErr := balance.ColorFromBase58(coloredBalance.Color)
+				if colorErr != nil {
+					return nil, colorErr
+				}
+				balances[color] += coloredBalance.Value
+			}
+
+			conditions, conditionsErr := outputID.Conditions.ToOutputConditions()
+			if conditionsErr != nil {
+				return nil, conditionsErr
+			}
+
+			if _, exists := outputs[addr]; !exists {
+				outputs[addr] = make(map[transaction.ID]*Output)
+			}
+			outputs[addr][id.TransactionID()] = &Output{
+				Address:       addr,
+				TransactionID: id.TransactionID(),
+				Balances:      balances,
+				Conditions:    conditions,
+				InclusionState: InclusionState{
+					Liked:       outputID.InclusionState.Liked && outputID.InclusionState.Preferred,
+					Confirmed:   outputID.InclusionState.Finalized && outputID.InclusionState.Preferred,
+					Rejected:    outputID.InclusionState.Finalized && !outputID.InclusionState.Preferred,
+					Conflicting: outputID.InclusionState.Conflicting,
+				},
+			}
+		}
+	}
+
+	return
+}
+
+// SendTransaction sends the given transaction to the node.
+func (connector *WebConnector) SendTransaction(tx *transaction.Transaction) (err error) {
+	_, err = connector.client.SendTransaction(tx.Bytes())
+
+	return
+}
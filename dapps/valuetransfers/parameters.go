@@ -0,0 +1,44 @@
+package valuetransfers
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgValueTransfersAverageNetworkDelay defines the average time (in seconds) it takes for a transaction to propagate
+	// through gossip. It is used by the FCOB consensus rules to decide when to form an opinion.
+	CfgValueTransfersAverageNetworkDelay = "valuetransfers.fcob.averageNetworkDelay"
+
+	// CfgValueTransfersSnapshotFile defines the path to the genesis snapshot file that is loaded into the value tangle.
+	CfgValueTransfersSnapshotFile = "valuetransfers.snapshot.file"
+
+	// CfgFPCQuerySampleSize defines the size of the voting quorum (k).
+	CfgFPCQuerySampleSize = "fpc.querySampleSize"
+
+	// CfgFPCRoundInterval defines the FPC round interval in seconds.
+	CfgFPCRoundInterval = "fpc.roundInterval"
+
+	// CfgFPCBindAddress defines the bind address on which the FPC vote server binds to.
+	CfgFPCBindAddress = "fpc.bindAddress"
+
+	// CfgFPCWeighting defines the strategy used to weight the opinion givers when sampling them.
+	CfgFPCWeighting = "fpc.weighting"
+)
+
+const (
+	// WeightingUniform samples all opinion givers with the same probability.
+	WeightingUniform = "uniform"
+	// WeightingBalance samples opinion givers with a probability proportional to the funds they hold in the ledger.
+	// Opinion givers without funds are never queried, so it should only be used if the funds are spread across the
+	// network.
+	WeightingBalance = "balance"
+)
+
+func init() {
+	flag.Int(CfgValueTransfersAverageNetworkDelay, 5, "the average time (in seconds) it takes for a transaction to propagate through gossip")
+	flag.String(CfgValueTransfersSnapshotFile, "", "the path to the genesis snapshot file of the value tangle")
+	flag.Int(CfgFPCQuerySampleSize, 3, "Size of the voting quorum (k)")
+	flag.Int(CfgFPCRoundInterval, 5, "FPC round interval [s]")
+	flag.String(CfgFPCBindAddress, "0.0.0.0:10895", "the bind address on which the FPC vote server binds to")
+	flag.String(CfgFPCWeighting, WeightingUniform, "the strategy used to weight the opinion givers when sampling them ('uniform' or 'balance')")
+}
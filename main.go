@@ -4,6 +4,7 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/iotaledger/goshimmer/pluginmgr/core"
+	"github.com/iotaledger/goshimmer/pluginmgr/dapps"
 	"github.com/iotaledger/goshimmer/pluginmgr/research"
 	"github.com/iotaledger/goshimmer/pluginmgr/ui"
 	"github.com/iotaledger/goshimmer/pluginmgr/webapi"
@@ -17,5 +18,6 @@ func main() {
 		research.PLUGINS,
 		ui.PLUGINS,
 		webapi.PLUGINS,
+		dapps.PLUGINS,
 	)
 }
@@ -127,3 +127,15 @@ func (rmap *RandomMap) RandomEntry() (result interface{}) {
 
 	return
 }
+
+// ForEach calls the consumer for every entry of the map until the consumer returns false.
+func (rmap *RandomMap) ForEach(consumer func(key interface{}, value interface{}) bool) {
+	rmap.mutex.RLock()
+	defer rmap.mutex.RUnlock()
+
+	for _, key := range rmap.keys {
+		if !consumer(key, rmap.rawMap[key].value) {
+			return
+		}
+	}
+}
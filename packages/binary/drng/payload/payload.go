@@ -9,6 +9,9 @@ import (
 	"github.com/iotaledger/hive.go/stringify"
 )
 
+// MaxSize defines the maximum size of a DRNG payload in bytes, which leaves enough room for a collective beacon.
+const MaxSize = 512
+
 // Payload defines a DRNG payload.
 type Payload struct {
 	header.Header
@@ -145,7 +148,7 @@ func init() {
 		err = payload.Unmarshal(data)
 
 		return
-	})
+	}, MaxSize)
 }
 
 // // endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -26,6 +26,7 @@ type Message struct {
 	issuingTime     time.Time
 	sequenceNumber  uint64
 	payload         payload.Payload
+	nonce           uint64
 	bytes           []byte
 	bytesMutex      sync.RWMutex
 	signature       ed25519.Signature
@@ -41,8 +42,11 @@ type Message struct {
 	issuerLocalIdentity *identity.LocalIdentity
 }
 
+// NonceSize is the size of the proof-of-work nonce in bytes.
+const NonceSize = marshalutil.UINT64_SIZE
+
 // New creates a new message with the details provided by the issuer.
-func New(trunkMessageId Id, branchMessageId Id, localIdentity *identity.LocalIdentity, issuingTime time.Time, sequenceNumber uint64, payload payload.Payload) (result *Message) {
+func New(trunkMessageId Id, branchMessageId Id, localIdentity *identity.LocalIdentity, issuingTime time.Time, sequenceNumber uint64, payload payload.Payload, nonce uint64) (result *Message) {
 	return &Message{
 		trunkId:         trunkMessageId,
 		branchId:        branchMessageId,
@@ -50,6 +54,7 @@ func New(trunkMessageId Id, branchMessageId Id, localIdentity *identity.LocalIde
 		issuingTime:     issuingTime,
 		sequenceNumber:  sequenceNumber,
 		payload:         payload,
+		nonce:           nonce,
 
 		issuerLocalIdentity: localIdentity,
 	}
@@ -168,6 +173,11 @@ func (message *Message) SequenceNumber() uint64 {
 	return message.sequenceNumber
 }
 
+// Nonce returns the proof-of-work nonce of the message.
+func (message *Message) Nonce() uint64 {
+	return message.nonce
+}
+
 // Signature returns the signature of the message.
 func (message *Message) Signature() ed25519.Signature {
 	message.signatureMutex.RLock()
@@ -253,6 +263,7 @@ func (message *Message) Bytes() []byte {
 	marshalUtil.WriteTime(message.issuingTime)
 	marshalUtil.WriteUint64(message.sequenceNumber)
 	marshalUtil.WriteBytes(message.payload.Bytes())
+	marshalUtil.WriteUint64(message.nonce)
 
 	message.signatureMutex.Lock()
 	message.signature = message.issuerLocalIdentity.Sign(marshalUtil.Bytes())
@@ -288,6 +299,9 @@ func (message *Message) UnmarshalObjectStorageValue(data []byte) (consumedBytes
 	if message.payload, err = payload.Parse(marshalUtil); err != nil {
 		return
 	}
+	if message.nonce, err = marshalUtil.ReadUint64(); err != nil {
+		return
+	}
 	if message.signature, err = ed25519.ParseSignature(marshalUtil); err != nil {
 		return
 	}
@@ -324,6 +338,7 @@ func (message *Message) String() string {
 		stringify.StructField("issuingTime", message.IssuingTime()),
 		stringify.StructField("sequenceNumber", message.SequenceNumber()),
 		stringify.StructField("payload", message.Payload()),
+		stringify.StructField("nonce", message.Nonce()),
 		stringify.StructField("signature", message.Signature()),
 	)
 }
@@ -0,0 +1,54 @@
+package message
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/marshalutil"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+const (
+	// payloadOffset is the position of the payload within the serialized message.
+	payloadOffset = 2*IdLength + ed25519.PublicKeySize + marshalutil.TIME_SIZE + marshalutil.UINT64_SIZE
+
+	// MaxMessageSize defines the maximum size of a message in bytes.
+	MaxMessageSize = payloadOffset + payload.MaxSize + NonceSize + ed25519.SignatureSize
+)
+
+// ErrMessageTooLarge is returned if a message exceeds the maximum message size.
+var ErrMessageTooLarge = errors.New("message exceeds the maximum size")
+
+// CheckSize checks the size of the given serialized message without parsing it. It returns an ErrMessageTooLarge error
+// if the message exceeds MaxMessageSize and a payload.ErrPayloadTooLarge error if the contained payload exceeds the
+// maximum size of its type.
+func CheckSize(messageBytes []byte) error {
+	if err := checkPayloadSize(messageBytes); err != nil {
+		return err
+	}
+	if len(messageBytes) > MaxMessageSize {
+		return fmt.Errorf("%w: %d bytes exceed %d bytes", ErrMessageTooLarge, len(messageBytes), MaxMessageSize)
+	}
+	return nil
+}
+
+// checkPayloadSize reads the type and the size of the payload, which precede its content, and checks them against the
+// maximum size of the payload type. Malformed messages are left to the parser.
+func checkPayloadSize(messageBytes []byte) error {
+	if len(messageBytes) < payloadOffset {
+		return nil
+	}
+	marshalUtil := marshalutil.New(messageBytes[payloadOffset:])
+	payloadType, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil
+	}
+	payloadSize, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil
+	}
+
+	return payload.CheckSize(payloadType, 2*marshalutil.UINT32_SIZE+int(payloadSize))
+}
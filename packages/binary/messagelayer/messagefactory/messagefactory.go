@@ -2,23 +2,45 @@ package messagefactory
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tipselector"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore"
 )
 
 const storeSequenceInterval = 100
 
+// A Worker performs the proof-of-work of a message issued at the given time.
+// The message is given in serialized byte form without the nonce and the signature.
+type Worker interface {
+	DoPOW(issuingTime time.Time, msg []byte) (nonce uint64, err error)
+}
+
+// The WorkerFunc type is an adapter to allow the use of ordinary functions as a PoW performer.
+type WorkerFunc func(issuingTime time.Time, msg []byte) (uint64, error)
+
+// DoPOW calls f(issuingTime, msg).
+func (f WorkerFunc) DoPOW(issuingTime time.Time, msg []byte) (uint64, error) {
+	return f(issuingTime, msg)
+}
+
+// ZeroWorker is a PoW worker that always returns 0 as the nonce.
+var ZeroWorker = WorkerFunc(func(time.Time, []byte) (uint64, error) { return 0, nil })
+
 // MessageFactory acts as a factory to create new messages.
 type MessageFactory struct {
 	Events        *Events
 	sequence      *kvstore.Sequence
 	localIdentity *identity.LocalIdentity
 	tipSelector   *tipselector.TipSelector
+
+	worker        Worker
+	issuanceMutex sync.Mutex
 }
 
 // New creates a new message factory.
@@ -33,13 +55,22 @@ func New(store kvstore.KVStore, localIdentity *identity.LocalIdentity, tipSelect
 		sequence:      sequence,
 		localIdentity: localIdentity,
 		tipSelector:   tipSelector,
+		worker:        ZeroWorker,
 	}
 }
 
+// SetWorker sets the PoW worker to be used for the messages.
+func (m *MessageFactory) SetWorker(worker Worker) {
+	m.issuanceMutex.Lock()
+	defer m.issuanceMutex.Unlock()
+
+	m.worker = worker
+}
+
 // IssuePayload creates a new message including sequence number and tip selection and returns it.
 // It also triggers the MessageConstructed event once it's done, which is for example used by the plugins to listen for
 // messages that shall be attached to the tangle.
-func (m *MessageFactory) IssuePayload(payload payload.Payload) *message.Message {
+func (m *MessageFactory) IssuePayload(p payload.Payload) *message.Message {
 	sequenceNumber, err := m.sequence.Next()
 	if err != nil {
 		m.Events.Error.Trigger(fmt.Errorf("could not create sequence number: %w", err))
@@ -47,19 +78,37 @@ func (m *MessageFactory) IssuePayload(payload payload.Payload) *message.Message
 	}
 
 	trunkMessageId, branchMessageId := m.tipSelector.Tips()
+
+	// the issuances are serialized, so that the PoW worker can take all previous messages into account
+	m.issuanceMutex.Lock()
+	issuingTime := time.Now()
+	nonce, err := m.doPOW(trunkMessageId, branchMessageId, issuingTime, sequenceNumber, p)
+	m.issuanceMutex.Unlock()
+	if err != nil {
+		m.Events.Error.Trigger(fmt.Errorf("could not do PoW: %w", err))
+		return nil
+	}
+
 	msg := message.New(
 		trunkMessageId,
 		branchMessageId,
 		m.localIdentity,
-		time.Now(),
+		issuingTime,
 		sequenceNumber,
-		payload,
+		p,
+		nonce,
 	)
 
 	m.Events.MessageConstructed.Trigger(msg)
 	return msg
 }
 
+func (m *MessageFactory) doPOW(trunkID message.Id, branchID message.Id, issuingTime time.Time, sequenceNumber uint64, payload payload.Payload) (uint64, error) {
+	// create a dummy message to get the bytes covered by the PoW
+	dummy := message.New(trunkID, branchID, m.localIdentity, issuingTime, sequenceNumber, payload, 0).Bytes()
+	return m.worker.DoPOW(issuingTime, dummy[:len(dummy)-message.NonceSize-ed25519.SignatureSize])
+}
+
 // Shutdown closes the messageFactory and persists the sequence number.
 func (m *MessageFactory) Shutdown() {
 	if err := m.sequence.Release(); err != nil {
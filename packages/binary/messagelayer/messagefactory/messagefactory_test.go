@@ -2,6 +2,7 @@ package messagefactory
 
 import (
 	"encoding"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tipselector"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
 
 	"github.com/iotaledger/hive.go/events"
@@ -104,6 +106,44 @@ func TestMessageFactory_BuildMessage(t *testing.T) {
 	assert.EqualValues(t, totalMessages, countSequence)
 }
 
+func TestMessageFactory_POW(t *testing.T) {
+	msgFactory := New(mapdb.NewMapDB(), identity.GenerateLocalIdentity(), tipselector.New(), []byte(sequenceKey))
+	defer msgFactory.Shutdown()
+
+	var powBytes []byte
+	msgFactory.SetWorker(WorkerFunc(func(issuingTime time.Time, msgBytes []byte) (uint64, error) {
+		powBytes = msgBytes
+		return 42, nil
+	}))
+
+	msg := msgFactory.IssuePayload(NewMockPayload([]byte("TestPOW")))
+	assert.EqualValues(t, 42, msg.Nonce())
+
+	// the PoW covers the complete message except for the nonce and the signature
+	msgBytes := msg.Bytes()
+	assert.Equal(t, msgBytes[:len(msgBytes)-message.NonceSize-ed25519.SignatureSize], powBytes)
+
+	// a failing PoW is reported as an error
+	msgFactory.SetWorker(WorkerFunc(func(time.Time, []byte) (uint64, error) {
+		return 0, errors.New("failed")
+	}))
+	errorTriggered := false
+	msgFactory.Events.Error.Attach(events.NewClosure(func(error) { errorTriggered = true }))
+	assert.Nil(t, msgFactory.IssuePayload(NewMockPayload([]byte("TestPOW"))))
+	assert.True(t, errorTriggered)
+}
+
+func TestMessageFactory_PayloadSize(t *testing.T) {
+	msgFactory := New(mapdb.NewMapDB(), identity.GenerateLocalIdentity(), tipselector.New(), []byte(sequenceKey))
+	defer msgFactory.Shutdown()
+
+	// a payload of the maximum size results in a message of the maximum size, the data payload header takes 8 bytes
+	msg := msgFactory.IssuePayload(payload.NewData(make([]byte, payload.MaxSize-8)))
+	assert.NotNil(t, msg)
+	assert.Len(t, msg.Bytes(), message.MaxMessageSize)
+	assert.NoError(t, message.CheckSize(msg.Bytes()))
+}
+
 type MockPayload struct {
 	data []byte
 	encoding.BinaryMarshaler
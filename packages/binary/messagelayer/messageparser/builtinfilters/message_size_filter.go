@@ -0,0 +1,60 @@
+package builtinfilters
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+)
+
+// MessageSizeFilter filters bytes that exceed the maximum message size or the maximum payload size of their type.
+// As the check is cheap and does not require parsing the message, it is executed synchronously.
+type MessageSizeFilter struct {
+	onAcceptCallback func(bytes []byte, peer *peer.Peer)
+	onRejectCallback func(bytes []byte, err error, peer *peer.Peer)
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewMessageSizeFilter creates a new message size filter.
+func NewMessageSizeFilter() *MessageSizeFilter {
+	return &MessageSizeFilter{}
+}
+
+func (filter *MessageSizeFilter) Filter(bytes []byte, peer *peer.Peer) {
+	if err := message.CheckSize(bytes); err != nil {
+		filter.getRejectCallback()(bytes, err, peer)
+		return
+	}
+	filter.getAcceptCallback()(bytes, peer)
+}
+
+func (filter *MessageSizeFilter) OnAccept(callback func(bytes []byte, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.Lock()
+	filter.onAcceptCallback = callback
+	filter.onAcceptCallbackMutex.Unlock()
+}
+
+func (filter *MessageSizeFilter) OnReject(callback func(bytes []byte, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.Lock()
+	filter.onRejectCallback = callback
+	filter.onRejectCallbackMutex.Unlock()
+}
+
+func (filter *MessageSizeFilter) Shutdown() {}
+
+func (filter *MessageSizeFilter) getAcceptCallback() (result func(bytes []byte, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.RLock()
+	result = filter.onAcceptCallback
+	filter.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (filter *MessageSizeFilter) getRejectCallback() (result func(bytes []byte, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.RLock()
+	result = filter.onRejectCallback
+	filter.onRejectCallbackMutex.RUnlock()
+	return
+}
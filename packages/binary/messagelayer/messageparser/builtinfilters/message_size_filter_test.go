@@ -0,0 +1,62 @@
+package builtinfilters
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+func TestMessageSizeFilter(t *testing.T) {
+	filter := NewMessageSizeFilter()
+	defer filter.Shutdown()
+
+	var result error
+	filter.OnAccept(func([]byte, *peer.Peer) { result = nil })
+	filter.OnReject(func(_ []byte, err error, _ *peer.Peer) { result = err })
+
+	localIdentity := identity.GenerateLocalIdentity()
+	newMessageBytes := func(p payload.Payload) []byte {
+		return message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, p, 0).Bytes()
+	}
+
+	// a data payload of the maximum size is accepted
+	maxData := make([]byte, payload.MaxSize-8)
+	msgBytes := newMessageBytes(payload.NewData(maxData))
+	assert.Equal(t, message.MaxMessageSize, len(msgBytes))
+	filter.Filter(msgBytes, nil)
+	assert.NoError(t, result)
+
+	// exceeding the maximum payload size is rejected
+	filter.Filter(newMessageBytes(payload.NewData(append(maxData, 0))), nil)
+	assert.True(t, errors.Is(result, payload.ErrPayloadTooLarge), "unexpected error: %v", result)
+
+	// payload types with a smaller maximum size are rejected earlier
+	const smallType = payload.Type(1 << 30)
+	payload.RegisterType(smallType, payload.GenericPayloadUnmarshalerFactory(smallType), 16)
+	newSmallPayload := func(size int) payload.Payload {
+		p, err := payload.GenericPayloadUnmarshalerFactory(smallType)(marshalutil.New().
+			WriteUint32(smallType).
+			WriteUint32(uint32(size)).
+			WriteBytes(make([]byte, size)).
+			Bytes())
+		require.NoError(t, err)
+		return p
+	}
+	filter.Filter(newMessageBytes(newSmallPayload(8)), nil)
+	assert.NoError(t, result)
+	filter.Filter(newMessageBytes(newSmallPayload(9)), nil)
+	assert.True(t, errors.Is(result, payload.ErrPayloadTooLarge), "unexpected error: %v", result)
+
+	// exceeding the maximum message size is rejected
+	filter.Filter(make([]byte, message.MaxMessageSize+1), nil)
+	assert.True(t, errors.Is(result, message.ErrMessageTooLarge), "unexpected error: %v", result)
+}
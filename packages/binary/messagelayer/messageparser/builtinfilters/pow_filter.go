@@ -0,0 +1,101 @@
+package builtinfilters
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/async"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/pow"
+)
+
+// ErrInvalidPOWDifficulty is returned when the nonce of a message does not fulfill the PoW difficulty.
+var ErrInvalidPOWDifficulty = errors.New("invalid PoW")
+
+// PowFilter filters messages whose proof-of-work does not fulfill the difficulty of their issuer.
+type PowFilter struct {
+	worker           *pow.Worker
+	difficulty       *pow.AdaptiveDifficulty
+	onAcceptCallback func(msg *message.Message, peer *peer.Peer)
+	onRejectCallback func(msg *message.Message, err error, peer *peer.Peer)
+	workerPool       async.WorkerPool
+
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// NewPowFilter creates a new PoW filter that checks the messages against the given adaptive difficulty.
+func NewPowFilter(worker *pow.Worker, difficulty *pow.AdaptiveDifficulty) *PowFilter {
+	return &PowFilter{
+		worker:     worker,
+		difficulty: difficulty,
+	}
+}
+
+func (filter *PowFilter) Filter(msg *message.Message, peer *peer.Peer) {
+	filter.workerPool.Submit(func() {
+		if err := filter.validate(msg); err != nil {
+			filter.getRejectCallback()(msg, err, peer)
+			return
+		}
+		filter.getAcceptCallback()(msg, peer)
+	})
+}
+
+func (filter *PowFilter) OnAccept(callback func(msg *message.Message, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.Lock()
+	filter.onAcceptCallback = callback
+	filter.onAcceptCallbackMutex.Unlock()
+}
+
+func (filter *PowFilter) OnReject(callback func(msg *message.Message, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.Lock()
+	filter.onRejectCallback = callback
+	filter.onRejectCallbackMutex.Unlock()
+}
+
+func (filter *PowFilter) Shutdown() {
+	filter.workerPool.ShutdownGracefully()
+}
+
+// validate checks the PoW of the given message and records it for the difficulty of its issuer. Messages that are
+// received more than once or that are older than the difficulty window (i.e. requested ones) are only counted once
+// or not at all, so that the difficulty matches the one the issuer computed.
+func (filter *PowFilter) validate(msg *message.Message) error {
+	msgBytes := msg.Bytes()
+	if len(msgBytes) < ed25519.SignatureSize {
+		return fmt.Errorf("%w: message too short", ErrInvalidPOWDifficulty)
+	}
+
+	// the PoW covers the complete message except for the signature
+	zeros, err := filter.worker.LeadingZeros(msgBytes[:len(msgBytes)-ed25519.SignatureSize])
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidPOWDifficulty, err)
+	}
+
+	issuer, issuingTime := msg.IssuerPublicKey(), msg.IssuingTime()
+	if difficulty := filter.difficulty.Difficulty(issuer, issuingTime); zeros < difficulty {
+		return fmt.Errorf("%w: %d leading zeros, difficulty %d", ErrInvalidPOWDifficulty, zeros, difficulty)
+	}
+	filter.difficulty.Record(issuer, issuingTime)
+
+	return nil
+}
+
+func (filter *PowFilter) getAcceptCallback() (result func(msg *message.Message, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.RLock()
+	result = filter.onAcceptCallback
+	filter.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (filter *PowFilter) getRejectCallback() (result func(msg *message.Message, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.RLock()
+	result = filter.onRejectCallback
+	filter.onRejectCallbackMutex.RUnlock()
+	return
+}
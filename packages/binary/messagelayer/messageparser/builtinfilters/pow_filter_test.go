@@ -0,0 +1,75 @@
+package builtinfilters
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+	"github.com/iotaledger/goshimmer/packages/pow"
+)
+
+const testDifficulty = 8
+
+func TestPowFilter(t *testing.T) {
+	worker := pow.New(2)
+	// every message raises the difficulty by one bit
+	filter := NewPowFilter(worker, pow.NewAdaptiveDifficulty(testDifficulty, time.Minute, 1))
+	defer filter.Shutdown()
+
+	result := make(chan error, 1)
+	filter.OnAccept(func(*message.Message, *peer.Peer) { result <- nil })
+	filter.OnReject(func(_ *message.Message, err error, _ *peer.Peer) { result <- err })
+
+	localIdentity := identity.GenerateLocalIdentity()
+	issuingTime := time.Now()
+
+	firstMsg := newTestMessage(t, worker, localIdentity, issuingTime, testDifficulty)
+	filter.Filter(firstMsg, nil)
+	assert.NoError(t, <-result)
+
+	// receiving the same message again does not raise the difficulty
+	filter.Filter(firstMsg, nil)
+	assert.NoError(t, <-result)
+	assert.Equal(t, testDifficulty+1, filter.difficulty.Difficulty(localIdentity.PublicKey(), issuingTime.Add(time.Second)))
+
+	// the second message needs to fulfill a higher difficulty
+	filter.Filter(newTestMessage(t, worker, localIdentity, issuingTime.Add(time.Second), 0), nil)
+	err := <-result
+	assert.True(t, errors.Is(err, ErrInvalidPOWDifficulty), "unexpected error: %v", err)
+
+	filter.Filter(newTestMessage(t, worker, localIdentity, issuingTime.Add(time.Second), testDifficulty+1), nil)
+	assert.NoError(t, <-result)
+}
+
+// newTestMessage creates a message whose nonce fulfills the given difficulty.
+func newTestMessage(t *testing.T, worker *pow.Worker, localIdentity *identity.LocalIdentity, issuingTime time.Time, difficulty int) *message.Message {
+	data := payload.NewData([]byte("test"))
+	msgBytes := message.New(message.EmptyId, message.EmptyId, localIdentity, issuingTime, 0, data, 0).Bytes()
+	content := msgBytes[:len(msgBytes)-message.NonceSize-ed25519.SignatureSize]
+
+	// search for a nonce that does not fulfill the test difficulty, if no PoW is requested
+	if difficulty == 0 {
+		for nonce := uint64(0); ; nonce++ {
+			msg := message.New(message.EmptyId, message.EmptyId, localIdentity, issuingTime, 0, data, nonce)
+			msgBytes := msg.Bytes()
+			zeros, err := worker.LeadingZeros(msgBytes[:len(msgBytes)-ed25519.SignatureSize])
+			require.NoError(t, err)
+			if zeros <= testDifficulty {
+				return msg
+			}
+		}
+	}
+
+	nonce, err := worker.Mine(context.Background(), content, difficulty)
+	require.NoError(t, err)
+	return message.New(message.EmptyId, message.EmptyId, localIdentity, issuingTime, 0, data, nonce)
+}
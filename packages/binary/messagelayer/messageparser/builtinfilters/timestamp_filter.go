@@ -0,0 +1,357 @@
+package builtinfilters
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/async"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/timeutil"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+)
+
+const (
+	// DefaultTimestampPastWindow is the default maximum age of a received message.
+	DefaultTimestampPastWindow = 30 * time.Minute
+	// DefaultTimestampFutureWindow is the default maximum time a received message may be issued ahead of the local time.
+	DefaultTimestampFutureWindow = time.Minute
+	// DefaultTimestampParkingDuration is the default time a message with unknown or newer parents is parked.
+	DefaultTimestampParkingDuration = time.Minute
+	// DefaultTimestampMaxParkedMessages is the default maximum number of parked messages.
+	DefaultTimestampMaxParkedMessages = 10000
+
+	timestampRecheckInterval = time.Second
+)
+
+var (
+	// ErrTimestampTooOld is returned when a message was issued before the allowed time window.
+	ErrTimestampTooOld = errors.New("message timestamp too old")
+	// ErrTimestampTooNew is returned when a message was issued after the allowed time window.
+	ErrTimestampTooNew = errors.New("message timestamp too far in the future")
+	// ErrTimestampBelowParent is returned when a message was issued before one of its parents.
+	ErrTimestampBelowParent = errors.New("message timestamp below parent timestamp")
+	// ErrParentUnknown is returned when the parents of a parked message did not arrive in time.
+	ErrParentUnknown = errors.New("message parent unknown")
+	// ErrTooManyParkedMessages is returned when a message with unknown or newer parents can not be parked.
+	ErrTooManyParkedMessages = errors.New("too many parked messages")
+)
+
+// ParentTimestampFunc returns the issuing time of the given message and whether the message is known.
+type ParentTimestampFunc func(messageId message.Id) (issuingTime time.Time, exists bool)
+
+// TimestampFilterOption is a function setting an option of the timestamp filter.
+type TimestampFilterOption func(filter *TimestampFilter)
+
+// TimestampWindow sets the time window around the local time in which messages are accepted.
+func TimestampWindow(past time.Duration, future time.Duration) TimestampFilterOption {
+	return func(filter *TimestampFilter) {
+		filter.pastWindow = past
+		filter.futureWindow = future
+	}
+}
+
+// TimestampParkingDuration sets the time a message with unknown or newer parents is parked before it gets rejected. If
+// it is zero, messages are not parked, messages with newer parents are rejected immediately and unknown parents are not
+// checked.
+func TimestampParkingDuration(parkingDuration time.Duration) TimestampFilterOption {
+	return func(filter *TimestampFilter) {
+		filter.parkingDuration = parkingDuration
+	}
+}
+
+// TimestampMaxParkedMessages sets the maximum number of parked messages. Messages with unknown or newer parents that
+// arrive while the limit is reached get rejected.
+func TimestampMaxParkedMessages(maxParkedMessages int) TimestampFilterOption {
+	return func(filter *TimestampFilter) {
+		filter.maxParkedMessages = maxParkedMessages
+	}
+}
+
+// TimestampRequestParents sets the function used to request the unknown parents of a parked message.
+func TimestampRequestParents(requestParent func(messageId message.Id)) TimestampFilterOption {
+	return func(filter *TimestampFilter) {
+		filter.requestParent = requestParent
+	}
+}
+
+// TimestampRequestedMessages sets the function used to determine whether a message was requested. Requested messages
+// are exempt from the past window, as missing messages of the past need to be solidified as well.
+func TimestampRequestedMessages(isRequested func(messageId message.Id) bool) TimestampFilterOption {
+	return func(filter *TimestampFilter) {
+		filter.isRequested = isRequested
+	}
+}
+
+// TimestampFilter filters messages whose issuing time lies outside of a time window around the local time.
+// Messages with unknown parents and messages issued before one of their parents are parked and re-checked until the
+// parking duration expires. Parked messages are re-checked as soon as one of their missing parents is stored (see
+// MessageStored) and periodically, so that they get rejected once their parking expired.
+type TimestampFilter struct {
+	parentTimestamp   ParentTimestampFunc
+	isRequested       func(messageId message.Id) bool
+	requestParent     func(messageId message.Id)
+	pastWindow        time.Duration
+	futureWindow      time.Duration
+	parkingDuration   time.Duration
+	maxParkedMessages int
+	parkedMessages    map[message.Id]*parkedMessage
+	parkedChildren    map[message.Id]map[message.Id]struct{}
+	shutdownSignal    chan struct{}
+	shutdownOnce      sync.Once
+	onAcceptCallback  func(msg *message.Message, peer *peer.Peer)
+	onRejectCallback  func(msg *message.Message, err error, peer *peer.Peer)
+	workerPool        async.WorkerPool
+
+	parkedMessagesMutex   sync.Mutex
+	onAcceptCallbackMutex sync.RWMutex
+	onRejectCallbackMutex sync.RWMutex
+}
+
+// parkedMessage is a message waiting to be re-checked by the timestamp filter.
+type parkedMessage struct {
+	msg         *message.Message
+	peer        *peer.Peer
+	parkedSince time.Time
+	err         error
+}
+
+// NewTimestampFilter creates a new timestamp filter which looks up the timestamps of parents with the given function.
+func NewTimestampFilter(parentTimestamp ParentTimestampFunc, optionalOptions ...TimestampFilterOption) *TimestampFilter {
+	filter := &TimestampFilter{
+		parentTimestamp:   parentTimestamp,
+		isRequested:       func(message.Id) bool { return false },
+		requestParent:     func(message.Id) {},
+		pastWindow:        DefaultTimestampPastWindow,
+		futureWindow:      DefaultTimestampFutureWindow,
+		parkingDuration:   DefaultTimestampParkingDuration,
+		maxParkedMessages: DefaultTimestampMaxParkedMessages,
+		parkedMessages:    make(map[message.Id]*parkedMessage),
+		parkedChildren:    make(map[message.Id]map[message.Id]struct{}),
+		shutdownSignal:    make(chan struct{}),
+	}
+	for _, optionalOption := range optionalOptions {
+		optionalOption(filter)
+	}
+
+	go timeutil.Ticker(filter.recheckParkedMessages, timestampRecheckInterval, filter.shutdownSignal)
+
+	return filter
+}
+
+func (filter *TimestampFilter) Filter(msg *message.Message, peer *peer.Peer) {
+	filter.workerPool.Submit(func() {
+		err := filter.validate(msg)
+		switch {
+		case err == nil:
+			filter.getAcceptCallback()(msg, peer)
+		case errors.Is(err, ErrParentUnknown) || (errors.Is(err, ErrTimestampBelowParent) && filter.parkingDuration > 0):
+			if err := filter.park(msg, peer, err); err != nil {
+				filter.getRejectCallback()(msg, err, peer)
+			}
+		default:
+			filter.getRejectCallback()(msg, err, peer)
+		}
+	})
+}
+
+func (filter *TimestampFilter) OnAccept(callback func(msg *message.Message, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.Lock()
+	filter.onAcceptCallback = callback
+	filter.onAcceptCallbackMutex.Unlock()
+}
+
+func (filter *TimestampFilter) OnReject(callback func(msg *message.Message, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.Lock()
+	filter.onRejectCallback = callback
+	filter.onRejectCallbackMutex.Unlock()
+}
+
+func (filter *TimestampFilter) Shutdown() {
+	filter.shutdownOnce.Do(func() { close(filter.shutdownSignal) })
+	filter.workerPool.ShutdownGracefully()
+}
+
+// MessageStored re-checks the parked messages that are waiting for the given message to arrive. It is attached to the
+// event of the tangle that is fired when a message is stored, so that a chain of parked messages is released as soon as
+// its ancestors arrive.
+func (filter *TimestampFilter) MessageStored(messageId message.Id) {
+	filter.parkedMessagesMutex.Lock()
+	now := time.Now()
+	var released []*parkedMessage
+	for childId := range filter.parkedChildren[messageId] {
+		if parked, exists := filter.parkedMessages[childId]; exists && filter.recheck(parked, now) {
+			released = append(released, parked)
+		}
+	}
+	delete(filter.parkedChildren, messageId)
+	filter.parkedMessagesMutex.Unlock()
+
+	filter.release(released)
+}
+
+// ParkedMessageCount returns the number of messages that are currently parked.
+func (filter *TimestampFilter) ParkedMessageCount() int {
+	filter.parkedMessagesMutex.Lock()
+	defer filter.parkedMessagesMutex.Unlock()
+
+	return len(filter.parkedMessages)
+}
+
+// validate checks the issuing time of the given message against the local time and the timestamps of its parents.
+func (filter *TimestampFilter) validate(msg *message.Message) error {
+	now := time.Now()
+	issuingTime := msg.IssuingTime()
+
+	if issuingTime.After(now.Add(filter.futureWindow)) {
+		return fmt.Errorf("%w: issued at %s", ErrTimestampTooNew, issuingTime)
+	}
+	if issuingTime.Before(now.Add(-filter.pastWindow)) && !filter.isRequested(msg.Id()) {
+		return fmt.Errorf("%w: issued at %s", ErrTimestampTooOld, issuingTime)
+	}
+
+	var unknownParentId *message.Id
+	for _, parentId := range filter.parentIds(msg) {
+		parentTime, exists := filter.parentTimestamp(parentId)
+		if !exists {
+			parentId := parentId
+			unknownParentId = &parentId
+			continue
+		}
+		if issuingTime.Before(parentTime) {
+			return fmt.Errorf("%w: issued at %s, parent %s issued at %s", ErrTimestampBelowParent, issuingTime, parentId, parentTime)
+		}
+	}
+
+	// unknown parents are only checked if the message can be parked until they arrive
+	if unknownParentId != nil && filter.parkingDuration > 0 {
+		return fmt.Errorf("%w: %s", ErrParentUnknown, *unknownParentId)
+	}
+
+	return nil
+}
+
+// parentIds returns the distinct parents of the given message, except for the genesis.
+func (filter *TimestampFilter) parentIds(msg *message.Message) (parentIds []message.Id) {
+	if trunkId := msg.TrunkId(); trunkId != message.EmptyId {
+		parentIds = append(parentIds, trunkId)
+	}
+	if branchId := msg.BranchId(); branchId != message.EmptyId && branchId != msg.TrunkId() {
+		parentIds = append(parentIds, branchId)
+	}
+
+	return
+}
+
+// park parks the given message for the given reason and requests its unknown parents. It returns an error if too many
+// messages are parked.
+func (filter *TimestampFilter) park(msg *message.Message, peer *peer.Peer, reason error) error {
+	filter.parkedMessagesMutex.Lock()
+	if _, parked := filter.parkedMessages[msg.Id()]; parked {
+		filter.parkedMessagesMutex.Unlock()
+		return nil
+	}
+	if len(filter.parkedMessages) >= filter.maxParkedMessages {
+		filter.parkedMessagesMutex.Unlock()
+		return fmt.Errorf("%w: limit of %d reached", ErrTooManyParkedMessages, filter.maxParkedMessages)
+	}
+	filter.parkedMessages[msg.Id()] = &parkedMessage{msg: msg, peer: peer, parkedSince: time.Now(), err: reason}
+
+	// the parents are looked up while holding the lock, so parents that are stored in the meantime wake the message
+	var unknownParentIds []message.Id
+	for _, parentId := range filter.parentIds(msg) {
+		if _, exists := filter.parentTimestamp(parentId); exists {
+			continue
+		}
+		if _, exists := filter.parkedChildren[parentId]; !exists {
+			filter.parkedChildren[parentId] = make(map[message.Id]struct{})
+		}
+		filter.parkedChildren[parentId][msg.Id()] = struct{}{}
+		unknownParentIds = append(unknownParentIds, parentId)
+	}
+	filter.parkedMessagesMutex.Unlock()
+
+	for _, parentId := range unknownParentIds {
+		filter.requestParent(parentId)
+	}
+
+	// the missing parents might have been stored between the validation and the parking
+	if len(unknownParentIds) == 0 && errors.Is(reason, ErrParentUnknown) {
+		filter.parkedMessagesMutex.Lock()
+		var released []*parkedMessage
+		if parked, exists := filter.parkedMessages[msg.Id()]; exists && filter.recheck(parked, time.Now()) {
+			released = append(released, parked)
+		}
+		filter.parkedMessagesMutex.Unlock()
+
+		filter.release(released)
+	}
+
+	return nil
+}
+
+// recheckParkedMessages re-checks all parked messages, so that the ones whose parking expired get rejected.
+func (filter *TimestampFilter) recheckParkedMessages() {
+	// collect the released messages first, as the workers might be waiting for the lock to park messages
+	var released []*parkedMessage
+	filter.parkedMessagesMutex.Lock()
+	now := time.Now()
+	for _, parked := range filter.parkedMessages {
+		if filter.recheck(parked, now) {
+			released = append(released, parked)
+		}
+	}
+	filter.parkedMessagesMutex.Unlock()
+
+	filter.release(released)
+}
+
+// recheck validates the given parked message again and removes it from the parking if it is valid now or if its
+// parking expired. It returns true if the message was removed. The parkedMessagesMutex needs to be held.
+func (filter *TimestampFilter) recheck(parked *parkedMessage, now time.Time) bool {
+	parked.err = filter.validate(parked.msg)
+	if parked.err != nil && now.Sub(parked.parkedSince) < filter.parkingDuration &&
+		(errors.Is(parked.err, ErrParentUnknown) || errors.Is(parked.err, ErrTimestampBelowParent)) {
+		return false
+	}
+
+	delete(filter.parkedMessages, parked.msg.Id())
+	for _, parentId := range filter.parentIds(parked.msg) {
+		if children, exists := filter.parkedChildren[parentId]; exists {
+			delete(children, parked.msg.Id())
+			if len(children) == 0 {
+				delete(filter.parkedChildren, parentId)
+			}
+		}
+	}
+
+	return true
+}
+
+// release accepts or rejects the given messages that were removed from the parking.
+func (filter *TimestampFilter) release(released []*parkedMessage) {
+	for _, parked := range released {
+		parked := parked
+		if parked.err == nil {
+			filter.workerPool.Submit(func() { filter.getAcceptCallback()(parked.msg, parked.peer) })
+			continue
+		}
+		filter.workerPool.Submit(func() { filter.getRejectCallback()(parked.msg, parked.err, parked.peer) })
+	}
+}
+
+func (filter *TimestampFilter) getAcceptCallback() (result func(msg *message.Message, peer *peer.Peer)) {
+	filter.onAcceptCallbackMutex.RLock()
+	result = filter.onAcceptCallback
+	filter.onAcceptCallbackMutex.RUnlock()
+	return
+}
+
+func (filter *TimestampFilter) getRejectCallback() (result func(msg *message.Message, err error, peer *peer.Peer)) {
+	filter.onRejectCallbackMutex.RLock()
+	result = filter.onRejectCallback
+	filter.onRejectCallbackMutex.RUnlock()
+	return
+}
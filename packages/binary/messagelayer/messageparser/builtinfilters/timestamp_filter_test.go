@@ -0,0 +1,245 @@
+package builtinfilters
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+func TestTimestampFilter(t *testing.T) {
+	localIdentity := identity.GenerateLocalIdentity()
+	parent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("parent")), 0)
+	parentTimestamp := func(messageId message.Id) (time.Time, bool) {
+		if messageId == parent.Id() {
+			return parent.IssuingTime(), true
+		}
+		return time.Time{}, false
+	}
+	requested := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(-time.Hour), 1, payload.NewData([]byte("requested")), 0)
+	isRequested := func(messageId message.Id) bool { return messageId == requested.Id() }
+
+	filter := NewTimestampFilter(parentTimestamp, TimestampWindow(time.Minute, time.Minute), TimestampParkingDuration(0), TimestampRequestedMessages(isRequested))
+	defer filter.Shutdown()
+
+	tests := []struct {
+		msg *message.Message
+		err error
+	}{
+		{msg: message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 2, payload.NewData([]byte("valid")), 0)},
+		{msg: message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(-time.Hour), 3, payload.NewData([]byte("old")), 0), err: ErrTimestampTooOld},
+		{msg: message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(time.Hour), 4, payload.NewData([]byte("new")), 0), err: ErrTimestampTooNew},
+		{msg: message.New(parent.Id(), message.EmptyId, localIdentity, parent.IssuingTime().Add(-time.Second), 5, payload.NewData([]byte("below")), 0), err: ErrTimestampBelowParent},
+		{msg: requested},
+	}
+
+	for _, test := range tests {
+		result := make(chan error, 1)
+		filter.OnAccept(func(*message.Message, *peer.Peer) { result <- nil })
+		filter.OnReject(func(_ *message.Message, err error, _ *peer.Peer) { result <- err })
+
+		filter.Filter(test.msg, nil)
+		select {
+		case err := <-result:
+			if test.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, test.err), "expected %v, got %v", test.err, err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("message was neither accepted nor rejected")
+		}
+	}
+}
+
+// filterResult is the outcome of the timestamp filter for a single message.
+type filterResult struct {
+	msg *message.Message
+	err error
+}
+
+func newParkingTestFilter(parentTimestamp ParentTimestampFunc, optionalOptions ...TimestampFilterOption) (*TimestampFilter, chan filterResult) {
+	filter := NewTimestampFilter(parentTimestamp, optionalOptions...)
+	results := make(chan filterResult, 10)
+	filter.OnAccept(func(msg *message.Message, _ *peer.Peer) { results <- filterResult{msg: msg} })
+	filter.OnReject(func(msg *message.Message, err error, _ *peer.Peer) { results <- filterResult{msg: msg, err: err} })
+
+	return filter, results
+}
+
+func awaitFilterResult(t *testing.T, results chan filterResult, timeout time.Duration) filterResult {
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(timeout):
+		t.Fatal("message was neither accepted nor rejected")
+		return filterResult{}
+	}
+}
+
+func TestTimestampFilter_Parking(t *testing.T) {
+	localIdentity := identity.GenerateLocalIdentity()
+	parent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("parent")), 0)
+	newerParent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(time.Second), 1, payload.NewData([]byte("newer parent")), 0)
+
+	// the parents are unknown until they are added to the map
+	var knownMessagesMutex sync.Mutex
+	knownMessages := make(map[message.Id]time.Time)
+	arrive := func(msg *message.Message) {
+		knownMessagesMutex.Lock()
+		defer knownMessagesMutex.Unlock()
+		knownMessages[msg.Id()] = msg.IssuingTime()
+	}
+	parentTimestamp := func(messageId message.Id) (issuingTime time.Time, exists bool) {
+		knownMessagesMutex.Lock()
+		defer knownMessagesMutex.Unlock()
+		issuingTime, exists = knownMessages[messageId]
+		return
+	}
+
+	var requestedParentsMutex sync.Mutex
+	var requestedParents []message.Id
+	requestParent := func(messageId message.Id) {
+		requestedParentsMutex.Lock()
+		defer requestedParentsMutex.Unlock()
+		requestedParents = append(requestedParents, messageId)
+	}
+
+	filter, results := newParkingTestFilter(parentTimestamp, TimestampParkingDuration(time.Minute), TimestampRequestParents(requestParent))
+	defer filter.Shutdown()
+
+	t.Run("unknown parent arrives", func(t *testing.T) {
+		child := message.New(parent.Id(), parent.Id(), localIdentity, parent.IssuingTime().Add(time.Second), 2, payload.NewData([]byte("child")), 0)
+
+		// the child is parked and its parent requested
+		filter.Filter(child, nil)
+		require.Eventually(t, func() bool { return filter.ParkedMessageCount() == 1 }, time.Second, 10*time.Millisecond)
+		requestedParentsMutex.Lock()
+		assert.Equal(t, []message.Id{parent.Id()}, requestedParents)
+		requestedParentsMutex.Unlock()
+
+		// the child is accepted once the parent was stored
+		arrive(parent)
+		filter.MessageStored(parent.Id())
+		result := awaitFilterResult(t, results, timestampRecheckInterval/2)
+		assert.Equal(t, child.Id(), result.msg.Id())
+		assert.NoError(t, result.err)
+		assert.Equal(t, 0, filter.ParkedMessageCount())
+	})
+
+	// the parents of the following messages are newer, so they stay parked until their parking expires
+	shortFilter, shortResults := newParkingTestFilter(parentTimestamp, TimestampParkingDuration(timestampRecheckInterval))
+	defer shortFilter.Shutdown()
+
+	t.Run("known parent is newer", func(t *testing.T) {
+		child := message.New(parent.Id(), parent.Id(), localIdentity, parent.IssuingTime().Add(-time.Second), 3, payload.NewData([]byte("child")), 0)
+
+		// the child is parked and rejected once its parking expired
+		shortFilter.Filter(child, nil)
+		require.Eventually(t, func() bool { return shortFilter.ParkedMessageCount() == 1 }, time.Second, 10*time.Millisecond)
+		result := awaitFilterResult(t, shortResults, 3*timestampRecheckInterval)
+		assert.Equal(t, child.Id(), result.msg.Id())
+		assert.True(t, errors.Is(result.err, ErrTimestampBelowParent), "unexpected error: %v", result.err)
+		assert.Equal(t, 0, shortFilter.ParkedMessageCount())
+	})
+
+	t.Run("unknown parent arrives and is newer", func(t *testing.T) {
+		child := message.New(parent.Id(), newerParent.Id(), localIdentity, parent.IssuingTime(), 4, payload.NewData([]byte("child")), 0)
+
+		shortFilter.Filter(child, nil)
+		require.Eventually(t, func() bool { return shortFilter.ParkedMessageCount() == 1 }, time.Second, 10*time.Millisecond)
+
+		// the child stays parked once the parent arrived and is rejected once its parking expired
+		arrive(newerParent)
+		shortFilter.MessageStored(newerParent.Id())
+		assert.Equal(t, 1, shortFilter.ParkedMessageCount())
+		result := awaitFilterResult(t, shortResults, 3*timestampRecheckInterval)
+		assert.Equal(t, child.Id(), result.msg.Id())
+		assert.True(t, errors.Is(result.err, ErrTimestampBelowParent), "unexpected error: %v", result.err)
+		assert.Equal(t, 0, shortFilter.ParkedMessageCount())
+	})
+}
+
+func TestTimestampFilter_ParkedChain(t *testing.T) {
+	const chainLength = 20
+	parkingDuration := 2 * timestampRecheckInterval
+
+	localIdentity := identity.GenerateLocalIdentity()
+	issuingTime := time.Now()
+	chain := []*message.Message{message.New(message.EmptyId, message.EmptyId, localIdentity, issuingTime, 0, payload.NewData([]byte("root")), 0)}
+	for i := 1; i <= chainLength; i++ {
+		parentId := chain[i-1].Id()
+		chain = append(chain, message.New(parentId, parentId, localIdentity, issuingTime.Add(time.Duration(i)*time.Millisecond), uint64(i), payload.NewData([]byte("child")), 0))
+	}
+
+	var knownMessagesMutex sync.Mutex
+	knownMessages := make(map[message.Id]time.Time)
+	parentTimestamp := func(messageId message.Id) (issuingTime time.Time, exists bool) {
+		knownMessagesMutex.Lock()
+		defer knownMessagesMutex.Unlock()
+		issuingTime, exists = knownMessages[messageId]
+		return
+	}
+
+	filter := NewTimestampFilter(parentTimestamp, TimestampParkingDuration(parkingDuration))
+	defer filter.Shutdown()
+	results := make(chan filterResult, chainLength)
+	filter.OnAccept(func(msg *message.Message, _ *peer.Peer) { results <- filterResult{msg: msg} })
+	filter.OnReject(func(msg *message.Message, err error, _ *peer.Peer) { results <- filterResult{msg: msg, err: err} })
+
+	// the chain arrives in reverse order, so every message is parked
+	for i := chainLength; i > 0; i-- {
+		filter.Filter(chain[i], nil)
+	}
+	require.Eventually(t, func() bool { return filter.ParkedMessageCount() == chainLength }, time.Second, 10*time.Millisecond)
+
+	// storing the root releases the whole chain, as every accepted message is stored and wakes its child
+	store := func(msg *message.Message) {
+		knownMessagesMutex.Lock()
+		knownMessages[msg.Id()] = msg.IssuingTime()
+		knownMessagesMutex.Unlock()
+		filter.MessageStored(msg.Id())
+	}
+	store(chain[0])
+	for i := 1; i <= chainLength; i++ {
+		result := awaitFilterResult(t, results, parkingDuration)
+		require.NoError(t, result.err)
+		assert.Equal(t, chain[i].Id(), result.msg.Id())
+		store(result.msg)
+	}
+	assert.Equal(t, 0, filter.ParkedMessageCount())
+}
+
+func TestTimestampFilter_ParkingLimits(t *testing.T) {
+	localIdentity := identity.GenerateLocalIdentity()
+	unknownParentId := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("unknown")), 0).Id()
+	parentTimestamp := func(message.Id) (time.Time, bool) { return time.Time{}, false }
+
+	filter, results := newParkingTestFilter(parentTimestamp, TimestampParkingDuration(timestampRecheckInterval), TimestampMaxParkedMessages(1))
+	defer filter.Shutdown()
+
+	first := message.New(unknownParentId, unknownParentId, localIdentity, time.Now(), 1, payload.NewData([]byte("first")), 0)
+	second := message.New(unknownParentId, unknownParentId, localIdentity, time.Now(), 2, payload.NewData([]byte("second")), 0)
+
+	// the second message is rejected as the first one already occupies the parking
+	filter.Filter(first, nil)
+	require.Eventually(t, func() bool { return filter.ParkedMessageCount() == 1 }, time.Second, 10*time.Millisecond)
+	filter.Filter(second, nil)
+	result := awaitFilterResult(t, results, time.Second)
+	assert.Equal(t, second.Id(), result.msg.Id())
+	assert.True(t, errors.Is(result.err, ErrTooManyParkedMessages), "unexpected error: %v", result.err)
+
+	// the first message is rejected once its parking expired without the parent arriving
+	result = awaitFilterResult(t, results, 3*timestampRecheckInterval)
+	assert.Equal(t, first.Id(), result.msg.Id())
+	assert.True(t, errors.Is(result.err, ErrParentUnknown), "unexpected error: %v", result.err)
+	assert.Equal(t, 0, filter.ParkedMessageCount())
+}
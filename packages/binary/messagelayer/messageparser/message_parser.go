@@ -42,6 +42,7 @@ func New() (result *MessageParser) {
 	}
 
 	// add builtin filters
+	result.AddBytesFilter(builtinfilters.NewMessageSizeFilter())
 	result.AddBytesFilter(builtinfilters.NewRecentlySeenBytesFilter())
 	result.AddMessageFilter(builtinfilters.NewMessageSignatureFilter())
 	return
@@ -15,7 +15,7 @@ import (
 
 func BenchmarkMessageParser_ParseBytesSame(b *testing.B) {
 	localIdentity := identity.GenerateLocalIdentity()
-	msgBytes := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test"))).Bytes()
+	msgBytes := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test")), 0).Bytes()
 	msgParser := New()
 
 	b.ResetTimer()
@@ -31,7 +31,7 @@ func BenchmarkMessageParser_ParseBytesDifferent(b *testing.B) {
 	messageBytes := make([][]byte, b.N)
 	localIdentity := identity.GenerateLocalIdentity()
 	for i := 0; i < b.N; i++ {
-		messageBytes[i] = message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test"+strconv.Itoa(i)))).Bytes()
+		messageBytes[i] = message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test"+strconv.Itoa(i))), 0).Bytes()
 	}
 
 	msgParser := New()
@@ -47,7 +47,7 @@ func BenchmarkMessageParser_ParseBytesDifferent(b *testing.B) {
 
 func TestMessageParser_ParseMessage(t *testing.T) {
 	localIdentity := identity.GenerateLocalIdentity()
-	msg := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test")))
+	msg := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("Test")), 0)
 
 	msgParser := New()
 	msgParser.Parse(msg.Bytes(), nil)
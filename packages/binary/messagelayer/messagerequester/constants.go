@@ -5,6 +5,10 @@ import (
 )
 
 const (
+	// DefaultRequestWorkerCount defines the default amount of workers that send the requests.
 	DefaultRequestWorkerCount = 1024
-	DefaultRetryInterval      = 10 * time.Second
+	// DefaultRetryInterval defines the default interval before the first retry (it doubles with every retry).
+	DefaultRetryInterval = 10 * time.Second
+	// DefaultMaxRetries defines the default amount of retries before a request is given up.
+	DefaultMaxRetries = 5
 )
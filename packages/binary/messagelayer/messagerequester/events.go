@@ -2,10 +2,25 @@ package messagerequester
 
 import (
 	"github.com/iotaledger/hive.go/events"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 )
 
 // Events represents events happening on a message requester.
 type Events struct {
-	// Fired when a request for a given message should be sent.
+	// Fired when a request for a given message should be sent. The handler has the signature
+	// func(messageId message.Id, retryCount int) and receives the number of retries that were already made for this
+	// message (0 for the first request), so that it can choose a different peer for every retry.
 	SendRequest *events.Event
+	// Fired when the maximum amount of retries was reached and the request for a given message is given up. The
+	// handler has the signature func(messageId message.Id).
+	RequestFailed *events.Event
+}
+
+func sendRequestEvent(handler interface{}, params ...interface{}) {
+	handler.(func(message.Id, int))(params[0].(message.Id), params[1].(int))
+}
+
+func messageIdEvent(handler interface{}, params ...interface{}) {
+	handler.(func(message.Id))(params[0].(message.Id))
 }
@@ -26,9 +26,8 @@ func New(optionalOptions ...Option) *MessageRequester {
 		scheduledRequests: make(map[message.Id]*time.Timer),
 		options:           newOptions(optionalOptions),
 		Events: Events{
-			SendRequest: events.NewEvent(func(handler interface{}, params ...interface{}) {
-				handler.(func(message.Id))(params[0].(message.Id))
-			}),
+			SendRequest:   events.NewEvent(sendRequestEvent),
+			RequestFailed: events.NewEvent(messageIdEvent),
 		},
 	}
 
@@ -36,27 +35,35 @@ func New(optionalOptions ...Option) *MessageRequester {
 	return requester
 }
 
-// ScheduleRequest schedules a request for the given message.
+// ScheduleRequest schedules a request for the given message. The request is retried with an exponentially growing
+// interval until it is stopped or the maximum amount of retries is reached.
 func (requester *MessageRequester) ScheduleRequest(messageId message.Id) {
-	var retryRequest func(bool)
-	retryRequest = func(initialRequest bool) {
+	var retryRequest func(int)
+	retryRequest = func(retryCount int) {
 		requester.requestWorker.Submit(func() {
-			requester.scheduledRequestsMutex.RLock()
-			if _, requestExists := requester.scheduledRequests[messageId]; !initialRequest && !requestExists {
-				requester.scheduledRequestsMutex.RUnlock()
+			requester.scheduledRequestsMutex.Lock()
+			if _, requestExists := requester.scheduledRequests[messageId]; retryCount != 0 && !requestExists {
+				requester.scheduledRequestsMutex.Unlock()
 				return
 			}
-			requester.scheduledRequestsMutex.RUnlock()
 
-			requester.Events.SendRequest.Trigger(messageId)
+			if retryCount > requester.options.maxRetries {
+				delete(requester.scheduledRequests, messageId)
+				requester.scheduledRequestsMutex.Unlock()
 
-			requester.scheduledRequestsMutex.Lock()
-			requester.scheduledRequests[messageId] = time.AfterFunc(requester.options.retryInterval, func() { retryRequest(false) })
+				requester.Events.RequestFailed.Trigger(messageId)
+				return
+			}
+
+			retryInterval := requester.options.retryInterval << uint(retryCount)
+			requester.scheduledRequests[messageId] = time.AfterFunc(retryInterval, func() { retryRequest(retryCount + 1) })
 			requester.scheduledRequestsMutex.Unlock()
+
+			requester.Events.SendRequest.Trigger(messageId, retryCount)
 		})
 	}
 
-	retryRequest(true)
+	retryRequest(0)
 }
 
 // StopRequest stops requests for the given message to further happen.
@@ -74,3 +81,20 @@ func (requester *MessageRequester) StopRequest(messageId message.Id) {
 	}
 	requester.scheduledRequestsMutex.RUnlock()
 }
+
+// RequestQueueSize returns the number of messages that are currently being requested.
+func (requester *MessageRequester) RequestQueueSize() int {
+	requester.scheduledRequestsMutex.RLock()
+	defer requester.scheduledRequestsMutex.RUnlock()
+
+	return len(requester.scheduledRequests)
+}
+
+// IsRequested returns true if the given message is currently being requested.
+func (requester *MessageRequester) IsRequested(messageId message.Id) bool {
+	requester.scheduledRequestsMutex.RLock()
+	defer requester.scheduledRequestsMutex.RUnlock()
+
+	_, requested := requester.scheduledRequests[messageId]
+	return requested
+}
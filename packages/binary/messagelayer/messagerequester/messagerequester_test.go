@@ -0,0 +1,90 @@
+package messagerequester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+)
+
+const retryInterval = 20 * time.Millisecond
+
+func TestMessageRequester_RetryWithBackoff(t *testing.T) {
+	requester := New(RetryInterval(retryInterval), MaxRetries(3))
+
+	var (
+		retryCounts []int
+		sendTimes   []time.Time
+		mutex       sync.Mutex
+	)
+	requester.Events.SendRequest.Attach(events.NewClosure(func(messageId message.Id, retryCount int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		retryCounts = append(retryCounts, retryCount)
+		sendTimes = append(sendTimes, time.Now())
+	}))
+	failed := make(chan message.Id, 1)
+	requester.Events.RequestFailed.Attach(events.NewClosure(func(messageId message.Id) {
+		failed <- messageId
+	}))
+
+	messageID := message.Id{1}
+	requester.ScheduleRequest(messageID)
+	assert.True(t, requester.IsRequested(messageID))
+
+	// the request is given up after the initial request and 3 retries
+	select {
+	case failedID := <-failed:
+		assert.Equal(t, messageID, failedID)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request was not given up")
+	}
+	assert.False(t, requester.IsRequested(messageID))
+	assert.Equal(t, 0, requester.RequestQueueSize())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	require.Equal(t, []int{0, 1, 2, 3}, retryCounts)
+
+	// the interval doubles with every retry
+	for i := 1; i < len(sendTimes); i++ {
+		assert.GreaterOrEqual(t, int64(sendTimes[i].Sub(sendTimes[i-1])), int64(retryInterval<<uint(i-1)))
+	}
+}
+
+func TestMessageRequester_StopRequest(t *testing.T) {
+	// use a longer interval, so the first retry can not happen before the request is stopped
+	requester := New(RetryInterval(10*retryInterval), MaxRetries(3))
+
+	requests := make(chan int, 10)
+	requester.Events.SendRequest.Attach(events.NewClosure(func(messageId message.Id, retryCount int) {
+		requests <- retryCount
+	}))
+	failed := make(chan message.Id, 1)
+	requester.Events.RequestFailed.Attach(events.NewClosure(func(messageId message.Id) {
+		failed <- messageId
+	}))
+
+	messageID := message.Id{2}
+	requester.ScheduleRequest(messageID)
+	select {
+	case retryCount := <-requests:
+		assert.Equal(t, 0, retryCount)
+	case <-time.After(time.Second):
+		t.Fatal("the request was not sent")
+	}
+
+	// stopped requests are neither retried nor reported as failed
+	requester.StopRequest(messageID)
+	assert.False(t, requester.IsRequested(messageID))
+
+	time.Sleep(30 * retryInterval)
+	assert.Empty(t, requests)
+	assert.Empty(t, failed)
+}
@@ -7,12 +7,14 @@ import (
 // Options holds options for a message requester.
 type Options struct {
 	retryInterval time.Duration
+	maxRetries    int
 	workerCount   int
 }
 
 func newOptions(optionalOptions []Option) *Options {
 	result := &Options{
-		retryInterval: 10 * time.Second,
+		retryInterval: DefaultRetryInterval,
+		maxRetries:    DefaultMaxRetries,
 		workerCount:   DefaultRequestWorkerCount,
 	}
 
@@ -27,12 +29,20 @@ func newOptions(optionalOptions []Option) *Options {
 type Option func(*Options)
 
 // RetryInterval creates an option which sets the retry interval to the given value.
+// The interval is doubled after every retry.
 func RetryInterval(interval time.Duration) Option {
 	return func(args *Options) {
 		args.retryInterval = interval
 	}
 }
 
+// MaxRetries creates an option which sets the maximum amount of retries before a request is given up.
+func MaxRetries(maxRetries int) Option {
+	return func(args *Options) {
+		args.maxRetries = maxRetries
+	}
+}
+
 // WorkerCount  creates an option which sets the worker count to the given value.
 func WorkerCount(workerCount int) Option {
 	return func(args *Options) {
@@ -8,7 +8,7 @@ func init() {
 	// register the generic unmarshaler
 	SetGenericUnmarshalerFactory(GenericPayloadUnmarshalerFactory)
 	// register the generic data payload type
-	RegisterType(DataType, GenericPayloadUnmarshalerFactory(DataType))
+	RegisterType(DataType, GenericPayloadUnmarshalerFactory(DataType), MaxSize)
 }
 
 // Payload represents some kind of payload of data which only gains meaning by having
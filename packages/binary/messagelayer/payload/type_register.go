@@ -1,22 +1,38 @@
 package payload
 
 import (
+	"errors"
+	"fmt"
 	"sync"
 )
 
+// MaxSize defines the maximum size of a payload in bytes, including its type and size header.
+// It is chosen so that a message carrying the payload still fits into a single gossip packet.
+const MaxSize = 3*1024 + 512
+
+// ErrPayloadTooLarge is returned if a payload exceeds the maximum size of its type.
+var ErrPayloadTooLarge = errors.New("payload exceeds the maximum size")
+
 // Unmarshaler takes some data and unmarshals it into a payload.
 type Unmarshaler func(data []byte) (Payload, error)
 
 var (
 	typeRegister              = make(map[Type]Unmarshaler)
+	maxSizeRegister           = make(map[Type]int)
 	typeRegisterMutex         sync.RWMutex
 	genericUnmarshalerFactory func(payloadType Type) Unmarshaler
 )
 
-// RegisterType registers a payload type with the given unmarshaler.
-func RegisterType(payloadType Type, unmarshaler Unmarshaler) {
+// RegisterType registers a payload type with the given unmarshaler and the maximum size of its payloads.
+// The maximum size is capped at MaxSize.
+func RegisterType(payloadType Type, unmarshaler Unmarshaler, maxSize int) {
+	if maxSize > MaxSize {
+		maxSize = MaxSize
+	}
+
 	typeRegisterMutex.Lock()
 	typeRegister[payloadType] = unmarshaler
+	maxSizeRegister[payloadType] = maxSize
 	typeRegisterMutex.Unlock()
 }
 
@@ -31,6 +47,25 @@ func GetUnmarshaler(payloadType Type) Unmarshaler {
 	return genericUnmarshalerFactory(payloadType)
 }
 
+// GetMaxSize returns the maximum size in bytes of payloads of the given type or MaxSize if the type is not registered.
+func GetMaxSize(payloadType Type) int {
+	typeRegisterMutex.RLock()
+	defer typeRegisterMutex.RUnlock()
+	if maxSize, exists := maxSizeRegister[payloadType]; exists {
+		return maxSize
+	}
+	return MaxSize
+}
+
+// CheckSize returns an ErrPayloadTooLarge error if a payload of the given type and size in bytes exceeds the maximum
+// size of its type.
+func CheckSize(payloadType Type, size int) error {
+	if maxSize := GetMaxSize(payloadType); size > maxSize {
+		return fmt.Errorf("%w: %d bytes of type %d exceed %d bytes", ErrPayloadTooLarge, size, payloadType, maxSize)
+	}
+	return nil
+}
+
 // SetGenericUnmarshalerFactory sets the generic unmarshaler.
 func SetGenericUnmarshalerFactory(unmarshalerFactory func(payloadType Type) Unmarshaler) {
 	genericUnmarshalerFactory = unmarshalerFactory
@@ -92,6 +92,19 @@ func (tangle *Tangle) Approvers(messageId message.Id) CachedApprovers {
 	return approvers
 }
 
+// MissingMessageCount returns the number of messages that are currently marked as missing.
+func (tangle *Tangle) MissingMessageCount() (count int) {
+	tangle.missingMessageStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(objectstorage.StorableObject) {
+			count++
+		})
+
+		return true
+	})
+
+	return
+}
+
 // DeleteMessage deletes a message and its association to approvees by un-marking the given
 // message as an approver.
 func (tangle *Tangle) DeleteMessage(messageId message.Id) {
@@ -141,6 +154,61 @@ func (tangle *Tangle) Prune() error {
 	return nil
 }
 
+// PruneOlderThan deletes the finalized messages that were received before the given time and returns the amount of
+// deleted messages. The given function decides if a solid message is finalized (i.e. the transaction of its value
+// payload is final); if it is nil, all solid messages count as finalized. Tips and messages with approvers that are not
+// solid yet are kept, so the solidification of recent messages is not affected.
+func (tangle *Tangle) PruneOlderThan(cutoff time.Time, finalized func(msg *message.Message) bool) (prunedMessages int) {
+	var toDelete []message.Id
+	tangle.messageMetadataStorage.ForEach(func(key []byte, cachedObject objectstorage.CachedObject) bool {
+		cachedObject.Consume(func(object objectstorage.StorableObject) {
+			msgMetadata := object.(*MessageMetadata)
+			if msgMetadata.IsSolid() && msgMetadata.ReceivedTime().Before(cutoff) && tangle.approversSolid(msgMetadata.messageId) && tangle.messageFinalized(msgMetadata.messageId, finalized) {
+				toDelete = append(toDelete, msgMetadata.messageId)
+			}
+		})
+
+		return true
+	})
+
+	for _, messageID := range toDelete {
+		tangle.Approvers(messageID).Consume(func(approver *Approver) {
+			tangle.deleteApprover(messageID, approver.ApproverMessageId())
+		})
+		tangle.DeleteMessage(messageID)
+	}
+
+	return len(toDelete)
+}
+
+// checks whether the given message is finalized according to the given function.
+func (tangle *Tangle) messageFinalized(messageId message.Id, finalized func(msg *message.Message) bool) (isFinalized bool) {
+	if finalized == nil {
+		return true
+	}
+
+	tangle.Message(messageId).Consume(func(msg *message.Message) {
+		isFinalized = finalized(msg)
+	})
+
+	return
+}
+
+// checks whether the given message has at least one approver and all of its approvers are solid.
+func (tangle *Tangle) approversSolid(messageId message.Id) (solid bool) {
+	approvers := tangle.Approvers(messageId)
+	solid = len(approvers) != 0
+	approvers.Consume(func(approver *Approver) {
+		cachedApproverMetadata := tangle.MessageMetadata(approver.ApproverMessageId())
+		defer cachedApproverMetadata.Release()
+
+		approverMetadata := cachedApproverMetadata.Unwrap()
+		solid = solid && approverMetadata != nil && approverMetadata.IsSolid()
+	})
+
+	return
+}
+
 // worker that stores the message and calls the corresponding storage events.
 func (tangle *Tangle) storeMessageWorker(msg *message.Message) {
 	// store message
@@ -188,18 +256,32 @@ func (tangle *Tangle) isMessageMarkedAsSolid(messageId message.Id) bool {
 
 	// mark message as missing
 	if msgMetadata == nil {
-		missingMessage := NewMissingMessage(messageId)
-		if cachedMissingMessage, stored := tangle.missingMessageStorage.StoreIfAbsent(missingMessage); stored {
-			cachedMissingMessage.Consume(func(object objectstorage.StorableObject) {
-				tangle.Events.MessageMissing.Trigger(messageId)
-			})
-		}
+		tangle.markMessageMissing(messageId)
 		return false
 	}
 
 	return msgMetadata.IsSolid()
 }
 
+// MarkMessageMissing marks the given message as missing if it is not known, so that it gets requested like the missing
+// parents of attached messages.
+func (tangle *Tangle) MarkMessageMissing(messageId message.Id) {
+	if messageId == message.EmptyId || tangle.MessageMetadata(messageId).Consume(func(objectstorage.StorableObject) {}) {
+		return
+	}
+
+	tangle.markMessageMissing(messageId)
+}
+
+// stores the given message in the missing message storage and triggers the MessageMissing event if it was not missing yet.
+func (tangle *Tangle) markMessageMissing(messageId message.Id) {
+	if cachedMissingMessage, stored := tangle.missingMessageStorage.StoreIfAbsent(NewMissingMessage(messageId)); stored {
+		cachedMissingMessage.Consume(func(object objectstorage.StorableObject) {
+			tangle.Events.MessageMissing.Trigger(messageId)
+		})
+	}
+}
+
 // checks whether the given message is solid by examining whether its trunk and
 // branch messages are solid.
 func (tangle *Tangle) isMessageSolid(msg *message.Message, msgMetadata *MessageMetadata) bool {
@@ -289,6 +371,7 @@ func (tangle *Tangle) MonitorMissingMessages(shutdownSignal <-chan struct{}) {
 				tangle.Events.MessageUnsolidifiable.Trigger(msgID)
 				// TODO: obvious race condition between receiving the message and it getting deleted here
 				tangle.deleteFutureCone(msgID)
+				tangle.missingMessageStorage.Delete(msgID[:])
 			}
 		case <-shutdownSignal:
 			return
@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
 )
 
 func BenchmarkTangle_AttachMessage(b *testing.B) {
@@ -24,7 +25,7 @@ func BenchmarkTangle_AttachMessage(b *testing.B) {
 
 	messageBytes := make([]*message.Message, b.N)
 	for i := 0; i < b.N; i++ {
-		messageBytes[i] = message.New(message.EmptyId, message.EmptyId, testIdentity, time.Now(), 0, payload.NewData([]byte("some data")))
+		messageBytes[i] = message.New(message.EmptyId, message.EmptyId, testIdentity, time.Now(), 0, payload.NewData([]byte("some data")), 0)
 		messageBytes[i].Bytes()
 	}
 
@@ -75,8 +76,8 @@ func TestTangle_AttachMessage(t *testing.T) {
 
 	localIdentity1 := identity.GenerateLocalIdentity()
 	localIdentity2 := identity.GenerateLocalIdentity()
-	newMessageOne := message.New(message.EmptyId, message.EmptyId, localIdentity1, time.Now(), 0, payload.NewData([]byte("some data")))
-	newMessageTwo := message.New(newMessageOne.Id(), newMessageOne.Id(), localIdentity2, time.Now(), 0, payload.NewData([]byte("some other data")))
+	newMessageOne := message.New(message.EmptyId, message.EmptyId, localIdentity1, time.Now(), 0, payload.NewData([]byte("some data")), 0)
+	newMessageTwo := message.New(newMessageOne.Id(), newMessageOne.Id(), localIdentity2, time.Now(), 0, payload.NewData([]byte("some other data")), 0)
 
 	messageTangle.AttachMessage(newMessageTwo)
 
@@ -86,3 +87,77 @@ func TestTangle_AttachMessage(t *testing.T) {
 
 	messageTangle.Shutdown()
 }
+
+func TestTangle_PruneOlderThan(t *testing.T) {
+	messageTangle := New(mapdb.NewMapDB())
+	defer messageTangle.Shutdown()
+
+	solidMessages := make(chan message.Id, 3)
+	messageTangle.Events.MessageSolid.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *CachedMessageMetadata) {
+		cachedMessageMetadata.Release()
+		cachedMessage.Consume(func(msg *message.Message) {
+			solidMessages <- msg.Id()
+		})
+	}))
+
+	localIdentity := identity.GenerateLocalIdentity()
+	firstMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("first")), 0)
+	secondMessage := message.New(firstMessage.Id(), firstMessage.Id(), localIdentity, time.Now(), 1, payload.NewData([]byte("second")), 0)
+	tipMessage := message.New(secondMessage.Id(), firstMessage.Id(), localIdentity, time.Now(), 2, payload.NewData([]byte("tip")), 0)
+
+	for _, msg := range []*message.Message{firstMessage, secondMessage, tipMessage} {
+		messageTangle.AttachMessage(msg)
+		select {
+		case <-solidMessages:
+		case <-time.After(time.Second):
+			t.Fatal("message did not become solid")
+		}
+	}
+
+	// nothing is pruned if the messages are younger than the cutoff
+	assert.Equal(t, 0, messageTangle.PruneOlderThan(time.Now().Add(-time.Hour), nil))
+
+	// messages that are not finalized are kept
+	notFinalized := func(msg *message.Message) bool { return msg.Id() != secondMessage.Id() }
+	assert.Equal(t, 1, messageTangle.PruneOlderThan(time.Now().Add(time.Second), notFinalized))
+	assert.False(t, messageTangle.Message(firstMessage.Id()).Consume(func(*message.Message) {}))
+	assert.True(t, messageTangle.Message(secondMessage.Id()).Consume(func(*message.Message) {}))
+
+	// the tip is kept even if it is old enough
+	assert.Equal(t, 1, messageTangle.PruneOlderThan(time.Now().Add(time.Second), nil))
+	assert.False(t, messageTangle.Message(firstMessage.Id()).Consume(func(*message.Message) {}))
+	assert.False(t, messageTangle.Message(secondMessage.Id()).Consume(func(*message.Message) {}))
+	assert.True(t, messageTangle.Message(tipMessage.Id()).Consume(func(*message.Message) {}))
+	assert.Empty(t, messageTangle.Approvers(firstMessage.Id()))
+	assert.Empty(t, messageTangle.Approvers(secondMessage.Id()))
+}
+
+func TestTangle_MarkMessageMissing(t *testing.T) {
+	messageTangle := New(mapdb.NewMapDB())
+	defer messageTangle.Shutdown()
+
+	var missingMessages []message.Id
+	messageTangle.Events.MessageMissing.Attach(events.NewClosure(func(messageId message.Id) {
+		missingMessages = append(missingMessages, messageId)
+	}))
+	attached := make(chan struct{}, 1)
+	messageTangle.Events.MessageAttached.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *CachedMessageMetadata) {
+		cachedMessage.Release()
+		cachedMessageMetadata.Release()
+		attached <- struct{}{}
+	}))
+
+	localIdentity := identity.GenerateLocalIdentity()
+	knownMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("known")), 0)
+	unknownMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 1, payload.NewData([]byte("unknown")), 0)
+	messageTangle.AttachMessage(knownMessage)
+	<-attached
+
+	// known messages and the genesis are never missing, unknown ones only trigger the event once
+	messageTangle.MarkMessageMissing(message.EmptyId)
+	messageTangle.MarkMessageMissing(knownMessage.Id())
+	messageTangle.MarkMessageMissing(unknownMessage.Id())
+	messageTangle.MarkMessageMissing(unknownMessage.Id())
+	assert.Equal(t, []message.Id{unknownMessage.Id()}, missingMessages)
+	assert.Equal(t, 1, messageTangle.MissingMessageCount())
+}
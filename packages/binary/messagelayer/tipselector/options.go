@@ -0,0 +1,39 @@
+package tipselector
+
+import (
+	"time"
+)
+
+// Options holds options for a tip-selector.
+type Options struct {
+	tipExpiry time.Duration
+	strategy  Strategy
+}
+
+func newOptions(optionalOptions []Option) *Options {
+	result := &Options{}
+
+	for _, optionalOption := range optionalOptions {
+		optionalOption(result)
+	}
+
+	return result
+}
+
+// Option is a function which inits an option.
+type Option func(*Options)
+
+// TipExpiry creates an option which sets the age after which a tip is removed. An expiry of 0 keeps the tips forever.
+func TipExpiry(expiry time.Duration) Option {
+	return func(args *Options) {
+		args.tipExpiry = expiry
+	}
+}
+
+// SelectionStrategy creates an option which sets the strategy used to select tips.
+// By default the tips are selected uniformly at random.
+func SelectionStrategy(strategy Strategy) Option {
+	return func(args *Options) {
+		args.strategy = strategy
+	}
+}
@@ -0,0 +1,81 @@
+package tipselector
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// Strategy assigns a weight to a tip. Tips are selected with a probability proportional to their weight and tips
+// with a weight of 0 are only selected if all tips have a weight of 0.
+type Strategy func(tip *Tip, now time.Time) float64
+
+// AgeWeighted returns a strategy which prefers young tips. The weight of a tip halves every half-life.
+func AgeWeighted(halfLife time.Duration) Strategy {
+	return func(tip *Tip, now time.Time) float64 {
+		if halfLife <= 0 {
+			return 1
+		}
+		age := now.Sub(tip.IssuingTime)
+		if age < 0 {
+			age = 0
+		}
+		return math.Exp2(-float64(age) / float64(halfLife))
+	}
+}
+
+// ApprovalWeighted returns a strategy which weights the tips by the approval weight of their issuer. As the approval
+// weight is usually expensive to compute, the weight of an issuer is cached for the given duration.
+func ApprovalWeighted(approvalWeight func(issuer ed25519.PublicKey) float64, cacheTime time.Duration) Strategy {
+	cache := &weightCache{
+		weight:    approvalWeight,
+		cacheTime: cacheTime,
+		entries:   make(map[ed25519.PublicKey]cachedWeight),
+	}
+
+	return func(tip *Tip, now time.Time) float64 {
+		return cache.get(tip.IssuerPublicKey, now)
+	}
+}
+
+// weightCache caches the weights of the issuers.
+type weightCache struct {
+	weight      func(issuer ed25519.PublicKey) float64
+	cacheTime   time.Duration
+	entries     map[ed25519.PublicKey]cachedWeight
+	nextCleanup time.Time
+	mutex       sync.Mutex
+}
+
+// cachedWeight is the weight of an issuer together with the time it is valid until.
+type cachedWeight struct {
+	weight     float64
+	validUntil time.Time
+}
+
+// get returns the cached weight of the given issuer or computes it if it is not cached or outdated.
+func (cache *weightCache) get(issuer ed25519.PublicKey, now time.Time) float64 {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if entry, exists := cache.entries[issuer]; exists && now.Before(entry.validUntil) {
+		return entry.weight
+	}
+
+	// remove the outdated entries of issuers that did not issue tips for a while
+	if now.After(cache.nextCleanup) {
+		for cachedIssuer, entry := range cache.entries {
+			if !now.Before(entry.validUntil) {
+				delete(cache.entries, cachedIssuer)
+			}
+		}
+		cache.nextCleanup = now.Add(cache.cacheTime)
+	}
+
+	weight := cache.weight(issuer)
+	cache.entries[issuer] = cachedWeight{weight: weight, validUntil: now.Add(cache.cacheTime)}
+
+	return weight
+}
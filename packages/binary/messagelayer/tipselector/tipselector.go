@@ -1,22 +1,36 @@
 package tipselector
 
 import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
 	"github.com/iotaledger/hive.go/events"
 
 	"github.com/iotaledger/goshimmer/packages/binary/datastructure"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 )
 
+// Tip is a message which is not approved by any other message yet.
+type Tip struct {
+	Id              message.Id
+	IssuerPublicKey ed25519.PublicKey
+	IssuingTime     time.Time
+}
+
 // TipSelector manages a map of tips and emits events for their removal and addition.
 type TipSelector struct {
-	tips   *datastructure.RandomMap
-	Events Events
+	tips    *datastructure.RandomMap
+	options *Options
+	Events  Events
 }
 
 // New creates a new tip-selector.
-func New() *TipSelector {
+func New(optionalOptions ...Option) *TipSelector {
 	return &TipSelector{
-		tips: datastructure.NewRandomMap(),
+		tips:    datastructure.NewRandomMap(),
+		options: newOptions(optionalOptions),
 		Events: Events{
 			TipAdded:   events.NewEvent(messageIdEvent),
 			TipRemoved: events.NewEvent(messageIdEvent),
@@ -24,50 +38,204 @@ func New() *TipSelector {
 	}
 }
 
-// AddTip adds the given message as a tip.
+// AddTip adds the given message as a tip. Messages that are already expired become tips as well, but they are removed
+// again by the next expiry check unless they are the last remaining tips.
 func (tipSelector *TipSelector) AddTip(msg *message.Message) {
 	messageId := msg.Id()
-	if tipSelector.tips.Set(messageId, messageId) {
+	tip := &Tip{
+		Id:              messageId,
+		IssuerPublicKey: msg.IssuerPublicKey(),
+		IssuingTime:     msg.IssuingTime(),
+	}
+	if _, exists := tipSelector.tips.Get(messageId); !exists && tipSelector.tips.Set(messageId, tip) {
 		tipSelector.Events.TipAdded.Trigger(messageId)
 	}
 
-	trunkMessageId := msg.TrunkId()
-	if _, deleted := tipSelector.tips.Delete(trunkMessageId); deleted {
-		tipSelector.Events.TipRemoved.Trigger(trunkMessageId)
+	tipSelector.removeTip(msg.TrunkId())
+	tipSelector.removeTip(msg.BranchId())
+}
+
+// Tips returns two tips. Expired tips are only selected if there are no other tips left.
+func (tipSelector *TipSelector) Tips() (trunkMessageId, branchMessageId message.Id) {
+	tipSelector.RemoveExpiredTips()
+
+	var trunk, branch *Tip
+	if tipSelector.options.strategy == nil {
+		trunk, branch = tipSelector.uniformTips()
+	} else {
+		trunk, branch = tipSelector.weightedTips()
 	}
 
-	branchMessageId := msg.BranchId()
-	if _, deleted := tipSelector.tips.Delete(branchMessageId); deleted {
-		tipSelector.Events.TipRemoved.Trigger(branchMessageId)
+	if branch == nil {
+		return message.EmptyId, message.EmptyId
 	}
+	if trunk == nil {
+		return branch.Id, branch.Id
+	}
+
+	return trunk.Id, branch.Id
 }
 
-// Tips returns two tips.
-func (tipSelector *TipSelector) Tips() (trunkMessageId, branchMessageId message.Id) {
-	tip := tipSelector.tips.RandomEntry()
-	if tip == nil {
-		trunkMessageId = message.EmptyId
-		branchMessageId = message.EmptyId
+// TipCount the amount of current tips.
+func (tipSelector *TipSelector) TipCount() int {
+	return tipSelector.tips.Size()
+}
 
+// RecentTips returns the ids of at most count tips, starting with the most recently issued ones.
+func (tipSelector *TipSelector) RecentTips(count int) []message.Id {
+	var tips []*Tip
+	tipSelector.tips.ForEach(func(_ interface{}, value interface{}) bool {
+		tips = append(tips, value.(*Tip))
+		return true
+	})
+
+	sort.Slice(tips, func(i, j int) bool {
+		return tips[i].IssuingTime.After(tips[j].IssuingTime)
+	})
+	if len(tips) > count {
+		tips = tips[:count]
+	}
+
+	result := make([]message.Id, len(tips))
+	for i, tip := range tips {
+		result[i] = tip.Id
+	}
+
+	return result
+}
+
+// RemoveExpiredTips removes all tips that are older than the tip expiry and returns the amount of removed tips. If all
+// tips are expired, they are kept, so that new messages do not attach to the genesis while the network is quiet.
+func (tipSelector *TipSelector) RemoveExpiredTips() (removedTips int) {
+	if tipSelector.options.tipExpiry <= 0 {
 		return
 	}
 
-	branchMessageId = tip.(message.Id)
+	now := time.Now()
+	var expiredTips []message.Id
+	unexpiredTips := 0
+	tipSelector.tips.ForEach(func(_ interface{}, value interface{}) bool {
+		if tip := value.(*Tip); tipSelector.expired(tip, now) {
+			expiredTips = append(expiredTips, tip.Id)
+		} else {
+			unexpiredTips++
+		}
+		return true
+	})
+	if unexpiredTips == 0 {
+		return
+	}
+
+	for _, messageId := range expiredTips {
+		if tipSelector.removeTip(messageId) {
+			removedTips++
+		}
+	}
 
-	if tipSelector.tips.Size() == 1 {
-		trunkMessageId = branchMessageId
+	return
+}
+
+// uniformTips selects two tips uniformly at random.
+func (tipSelector *TipSelector) uniformTips() (trunk, branch *Tip) {
+	if branch = tipSelector.randomTip(); branch == nil || tipSelector.tips.Size() == 1 {
 		return
 	}
 
-	trunkMessageId = tipSelector.tips.RandomEntry().(message.Id)
-	for trunkMessageId == branchMessageId && tipSelector.tips.Size() > 1 {
-		trunkMessageId = tipSelector.tips.RandomEntry().(message.Id)
+	trunk = tipSelector.randomTip()
+	for trunk != nil && trunk.Id == branch.Id && tipSelector.tips.Size() > 1 {
+		trunk = tipSelector.randomTip()
 	}
 
 	return
 }
 
-// TipCount the amount of current tips.
-func (tipSelector *TipSelector) TipCount() int {
-	return tipSelector.tips.Size()
+// randomTip returns a random tip.
+func (tipSelector *TipSelector) randomTip() *Tip {
+	entry := tipSelector.tips.RandomEntry()
+	if entry == nil {
+		return nil
+	}
+
+	return entry.(*Tip)
+}
+
+// weightedTips selects two distinct tips with a probability proportional to the weight assigned by the strategy.
+func (tipSelector *TipSelector) weightedTips() (trunk, branch *Tip) {
+	now := time.Now()
+	var (
+		candidates  []*Tip
+		weights     []float64
+		totalWeight float64
+	)
+	tipSelector.tips.ForEach(func(_ interface{}, value interface{}) bool {
+		tip := value.(*Tip)
+		weight := tipSelector.options.strategy(tip, now)
+		if weight < 0 {
+			weight = 0
+		}
+		candidates = append(candidates, tip)
+		weights = append(weights, weight)
+		totalWeight += weight
+		return true
+	})
+	if len(candidates) == 0 {
+		return
+	}
+
+	// fall back to a uniform selection if no tip has a weight
+	if totalWeight == 0 {
+		for i := range weights {
+			weights[i] = 1
+		}
+		totalWeight = float64(len(weights))
+	}
+
+	index := weightedIndex(weights, totalWeight)
+	branch = candidates[index]
+	if len(candidates) == 1 {
+		return
+	}
+
+	// select the trunk among the remaining tips
+	totalWeight -= weights[index]
+	candidates = append(candidates[:index], candidates[index+1:]...)
+	weights = append(weights[:index], weights[index+1:]...)
+	if totalWeight <= 0 {
+		trunk = candidates[rand.Intn(len(candidates))]
+		return
+	}
+	trunk = candidates[weightedIndex(weights, totalWeight)]
+
+	return
+}
+
+// weightedIndex returns a random index with a probability proportional to its weight.
+func weightedIndex(weights []float64, totalWeight float64) int {
+	r := rand.Float64() * totalWeight
+	for i, weight := range weights {
+		if r -= weight; r < 0 {
+			return i
+		}
+	}
+
+	// rounding errors might leave a small remainder
+	for i := len(weights) - 1; i > 0; i-- {
+		if weights[i] > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+func (tipSelector *TipSelector) expired(tip *Tip, now time.Time) bool {
+	return tipSelector.options.tipExpiry > 0 && now.Sub(tip.IssuingTime) > tipSelector.options.tipExpiry
+}
+
+func (tipSelector *TipSelector) removeTip(messageId message.Id) bool {
+	if _, deleted := tipSelector.tips.Delete(messageId); deleted {
+		tipSelector.Events.TipRemoved.Trigger(messageId)
+		return true
+	}
+
+	return false
 }
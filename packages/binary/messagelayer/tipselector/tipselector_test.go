@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/stretchr/testify/assert"
 
@@ -22,7 +24,7 @@ func Test(t *testing.T) {
 
 	// create a message and attach it
 	localIdentity1 := identity.GenerateLocalIdentity()
-	message1 := message.New(trunk1, branch1, localIdentity1, time.Now(), 0, payload.NewData([]byte("testmessage")))
+	message1 := message.New(trunk1, branch1, localIdentity1, time.Now(), 0, payload.NewData([]byte("testmessage")), 0)
 	tipSelector.AddTip(message1)
 
 	// check if the tip shows up in the tip count
@@ -35,7 +37,7 @@ func Test(t *testing.T) {
 
 	// create a 2nd message and attach it
 	localIdentity2 := identity.GenerateLocalIdentity()
-	message2 := message.New(message.EmptyId, message.EmptyId, localIdentity2, time.Now(), 0, payload.NewData([]byte("testmessage")))
+	message2 := message.New(message.EmptyId, message.EmptyId, localIdentity2, time.Now(), 0, payload.NewData([]byte("testmessage")), 0)
 	tipSelector.AddTip(message2)
 
 	// check if the tip shows up in the tip count
@@ -44,7 +46,7 @@ func Test(t *testing.T) {
 	// attach a message to our two tips
 	localIdentity3 := identity.GenerateLocalIdentity()
 	trunk3, branch3 := tipSelector.Tips()
-	message3 := message.New(trunk3, branch3, localIdentity3, time.Now(), 0, payload.NewData([]byte("testmessage")))
+	message3 := message.New(trunk3, branch3, localIdentity3, time.Now(), 0, payload.NewData([]byte("testmessage")), 0)
 	tipSelector.AddTip(message3)
 
 	// check if the tip shows replaces the current tips
@@ -53,3 +55,96 @@ func Test(t *testing.T) {
 	assert.Equal(t, message3.Id(), trunk4)
 	assert.Equal(t, message3.Id(), branch4)
 }
+
+func TestTipExpiry(t *testing.T) {
+	tipSelector := New(TipExpiry(time.Minute))
+
+	removedTips := 0
+	tipSelector.Events.TipRemoved.Attach(events.NewClosure(func(message.Id) { removedTips++ }))
+
+	// the last remaining tips are kept and selected, even if they are expired
+	localIdentity := identity.GenerateLocalIdentity()
+	expiredMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(-2*time.Minute), 0, payload.NewData([]byte("expired")), 0)
+	tipSelector.AddTip(expiredMessage)
+	assert.Equal(t, 0, tipSelector.RemoveExpiredTips())
+	trunk, branch := tipSelector.Tips()
+	assert.Equal(t, expiredMessage.Id(), trunk)
+	assert.Equal(t, expiredMessage.Id(), branch)
+	assert.Equal(t, 1, tipSelector.TipCount())
+
+	// expired tips are removed as soon as there are tips that are not expired
+	agingMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(-time.Minute+50*time.Millisecond), 1, payload.NewData([]byte("aging")), 0)
+	tipSelector.AddTip(agingMessage)
+	assert.Equal(t, 1, tipSelector.RemoveExpiredTips())
+	assert.Equal(t, 1, tipSelector.TipCount())
+	assert.Equal(t, 1, removedTips)
+
+	// expired tips are never selected if there are other tips
+	time.Sleep(100 * time.Millisecond)
+	freshMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 2, payload.NewData([]byte("fresh")), 0)
+	tipSelector.AddTip(freshMessage)
+	trunk, branch = tipSelector.Tips()
+	assert.Equal(t, freshMessage.Id(), trunk)
+	assert.Equal(t, freshMessage.Id(), branch)
+	assert.Equal(t, 2, removedTips)
+}
+
+func TestSelectionStrategy(t *testing.T) {
+	heavyIdentity := identity.GenerateLocalIdentity()
+	approvalWeight := func(issuer ed25519.PublicKey) float64 {
+		if issuer == heavyIdentity.PublicKey() {
+			return 1
+		}
+		return 0
+	}
+
+	tipSelector := New(SelectionStrategy(ApprovalWeighted(approvalWeight, time.Minute)))
+	heavyTip := message.New(message.EmptyId, message.EmptyId, heavyIdentity, time.Now(), 0, payload.NewData([]byte("heavy")), 0)
+	tipSelector.AddTip(heavyTip)
+	for i := 0; i < 10; i++ {
+		tipSelector.AddTip(message.New(message.EmptyId, message.EmptyId, identity.GenerateLocalIdentity(), time.Now(), 0, payload.NewData([]byte("light")), 0))
+	}
+
+	// the tip with the only weight is always selected as the branch, while the trunk falls back to a uniform selection
+	for i := 0; i < 10; i++ {
+		trunk, branch := tipSelector.Tips()
+		assert.Equal(t, heavyTip.Id(), branch)
+		assert.NotEqual(t, heavyTip.Id(), trunk)
+	}
+
+	// the approval weight of an issuer is cached
+	computedWeights := 0
+	cachedApprovalWeight := ApprovalWeighted(func(ed25519.PublicKey) float64 {
+		computedWeights++
+		return 1
+	}, time.Minute)
+	issuerTip := &Tip{IssuerPublicKey: heavyIdentity.PublicKey()}
+	now := time.Now()
+	assert.Equal(t, 1.0, cachedApprovalWeight(issuerTip, now))
+	assert.Equal(t, 1.0, cachedApprovalWeight(issuerTip, now.Add(time.Second)))
+	assert.Equal(t, 1, computedWeights)
+	assert.Equal(t, 1.0, cachedApprovalWeight(issuerTip, now.Add(time.Minute)))
+	assert.Equal(t, 2, computedWeights)
+
+	// young tips are preferred by the age weighted strategy
+	ageWeighted := AgeWeighted(time.Minute)
+	assert.Equal(t, 1.0, ageWeighted(&Tip{IssuingTime: now}, now))
+	assert.InDelta(t, 0.25, ageWeighted(&Tip{IssuingTime: now.Add(-2 * time.Minute)}, now), 1e-9)
+}
+
+func TestRecentTips(t *testing.T) {
+	tipSelector := New()
+	assert.Empty(t, tipSelector.RecentTips(2))
+
+	localIdentity := identity.GenerateLocalIdentity()
+	now := time.Now()
+	oldMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, now.Add(-2*time.Second), 0, payload.NewData([]byte("old")), 0)
+	newMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, now, 1, payload.NewData([]byte("new")), 0)
+	middleMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, now.Add(-time.Second), 2, payload.NewData([]byte("middle")), 0)
+	tipSelector.AddTip(oldMessage)
+	tipSelector.AddTip(newMessage)
+	tipSelector.AddTip(middleMessage)
+
+	assert.Equal(t, []message.Id{newMessage.Id(), middleMessage.Id()}, tipSelector.RecentTips(2))
+	assert.Len(t, tipSelector.RecentTips(5), 3)
+}
@@ -7,4 +7,5 @@ const (
 	// package specific prefixes used for the objectstorage in the corresponding packages
 	MessageLayer
 	ValueTransfers
+	Consensus
 )
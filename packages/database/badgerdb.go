@@ -74,6 +74,12 @@ func (db *badgerDB) GC() error {
 	return nil
 }
 
+// Size returns the size of the LSM tree and the value log of the database in bytes.
+func (db *badgerDB) Size() int64 {
+	lsmSize, vlogSize := db.DB.Size()
+	return lsmSize + vlogSize
+}
+
 // Returns whether the given file or directory exists.
 func exists(path string) (bool, error) {
 	_, err := os.Stat(path)
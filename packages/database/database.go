@@ -15,4 +15,6 @@ type DB interface {
 	RequiresGC() bool
 	// GC runs the garbage collection to clean deleted database items.
 	GC() error
+	// Size returns the size of the database in bytes.
+	Size() int64
 }
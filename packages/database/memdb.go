@@ -30,3 +30,8 @@ func (db *memDB) RequiresGC() bool {
 func (db *memDB) GC() error {
 	return nil
 }
+
+// Size returns 0 as the in-memory database does not keep track of its size.
+func (db *memDB) Size() int64 {
+	return 0
+}
@@ -8,4 +8,5 @@ var (
 	ErrLoopback          = errors.New("loopback connection not allowed")
 	ErrDuplicateNeighbor = errors.New("peer already connected")
 	ErrInvalidPacket     = errors.New("invalid packet")
+	ErrPacketTooLarge    = errors.New("packet exceeds the maximum size")
 )
@@ -1,6 +1,7 @@
 package gossip
 
 import (
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/events"
 )
@@ -8,6 +9,7 @@ import (
 // Events defines all the events related to the gossip protocol.
 type Events struct {
 	// Fired when an attempt to build a connection to a neighbor has failed.
+	// Incompatible peers are reported with server.ErrIncompatibleVersion or server.ErrIncompatibleNetwork.
 	ConnectionFailed *events.Event
 	// Fired when a neighbor connection has been established.
 	NeighborAdded *events.Event
@@ -15,6 +17,8 @@ type Events struct {
 	NeighborRemoved *events.Event
 	// Fired when a new message was received via the gossip protocol.
 	MessageReceived *events.Event
+	// Fired when a neighbor sent its current tips.
+	TipsReceived *events.Event
 }
 
 // MessageReceivedEvent holds data about a message received event.
@@ -25,6 +29,14 @@ type MessageReceivedEvent struct {
 	Peer *peer.Peer
 }
 
+// TipsReceivedEvent holds data about a tips received event.
+type TipsReceivedEvent struct {
+	// The ids of the tips.
+	Ids []message.Id
+	// The sender of the tips.
+	Peer *peer.Peer
+}
+
 func peerAndErrorCaller(handler interface{}, params ...interface{}) {
 	handler.(func(*peer.Peer, error))(params[0].(*peer.Peer), params[1].(error))
 }
@@ -40,3 +52,7 @@ func neighborCaller(handler interface{}, params ...interface{}) {
 func messageReceived(handler interface{}, params ...interface{}) {
 	handler.(func(*MessageReceivedEvent))(params[0].(*MessageReceivedEvent))
 }
+
+func tipsReceived(handler interface{}, params ...interface{}) {
+	handler.(func(*TipsReceivedEvent))(params[0].(*TipsReceivedEvent))
+}
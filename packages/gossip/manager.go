@@ -2,7 +2,6 @@ package gossip
 
 import (
 	"fmt"
-	"net"
 	"sync"
 
 	"github.com/golang/protobuf/proto"
@@ -13,19 +12,28 @@ import (
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/netutil/buffconn"
 )
 
 const (
-	maxPacketSize = 2048
+	// maxPacketSize is the maximum size of a packet, which is limited by the buffered connection.
+	// It is large enough for messages of the maximum message size.
+	maxPacketSize = buffconn.MaxMessageSize
+	// maxTipsPerPacket is the maximum number of tips sent in a single tips packet, so that it stays below the max packet size.
+	maxTipsPerPacket = 16
 )
 
 // LoadMessageFunc defines a function that returns the message for the given id.
 type LoadMessageFunc func(messageId message.Id) ([]byte, error)
 
+// LoadTipsFunc defines a function that returns the ids of the current tips.
+type LoadTipsFunc func() []message.Id
+
 // The Manager handles the connected neighbors.
 type Manager struct {
 	local           *peer.Local
 	loadMessageFunc LoadMessageFunc
+	loadTipsFunc    LoadTipsFunc
 	log             *logger.Logger
 	events          Events
 
@@ -37,16 +45,18 @@ type Manager struct {
 }
 
 // NewManager creates a new Manager.
-func NewManager(local *peer.Local, f LoadMessageFunc, log *logger.Logger) *Manager {
+func NewManager(local *peer.Local, loadMessage LoadMessageFunc, loadTips LoadTipsFunc, log *logger.Logger) *Manager {
 	return &Manager{
 		local:           local,
-		loadMessageFunc: f,
+		loadMessageFunc: loadMessage,
+		loadTipsFunc:    loadTips,
 		log:             log,
 		events: Events{
 			ConnectionFailed: events.NewEvent(peerAndErrorCaller),
 			NeighborAdded:    events.NewEvent(neighborCaller),
 			NeighborRemoved:  events.NewEvent(peerCaller),
 			MessageReceived:  events.NewEvent(messageReceived),
+			TipsReceived:     events.NewEvent(tipsReceived),
 		},
 		srv:       nil,
 		neighbors: make(map[identity.ID]*Neighbor),
@@ -86,30 +96,26 @@ func (m *Manager) stop() {
 
 // AddOutbound tries to add a neighbor by connecting to that peer.
 func (m *Manager) AddOutbound(p *peer.Peer) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	if p.ID() == m.local.ID() {
 		return ErrLoopback
 	}
-	if m.srv == nil {
+	srv := m.server()
+	if srv == nil {
 		return ErrNotRunning
 	}
-	return m.addNeighbor(p, m.srv.DialPeer)
+	return m.addNeighbor(p, srv.DialPeer)
 }
 
 // AddInbound tries to add a neighbor by accepting an incoming connection from that peer.
 func (m *Manager) AddInbound(p *peer.Peer) error {
-	m.mu.Lock()
-	defer m.mu.Unlock()
-
 	if p.ID() == m.local.ID() {
 		return ErrLoopback
 	}
-	if m.srv == nil {
+	srv := m.server()
+	if srv == nil {
 		return ErrNotRunning
 	}
-	return m.addNeighbor(p, m.srv.AcceptPeer)
+	return m.addNeighbor(p, srv.AcceptPeer)
 }
 
 // DropNeighbor disconnects the neighbor with the given ID.
@@ -131,15 +137,43 @@ func (m *Manager) DropNeighbor(id identity.ID) error {
 func (m *Manager) RequestMessage(messageId []byte, to ...identity.ID) {
 	msgReq := &pb.MessageRequest{Id: messageId}
 	m.log.Debugw("send packet", "type", msgReq.Type(), "to", to)
-	m.send(marshal(msgReq), to...)
+	m.send(marshal(msgReq), msgReq.Type(), to...)
 }
 
 // SendMessage adds the given message the send queue of the neighbors.
 // The actual send then happens asynchronously. If no peer is provided, it is send to all neighbors.
 func (m *Manager) SendMessage(msgData []byte, to ...identity.ID) {
+	if len(msgData) > message.MaxMessageSize {
+		m.log.Warnw("message not sent", "err", message.ErrMessageTooLarge, "len", len(msgData))
+		return
+	}
+
 	msg := &pb.Message{Data: msgData}
 	m.log.Debugw("send packet", "type", msg.Type(), "to", to)
-	m.send(marshal(msg), to...)
+	m.send(marshal(msg), msg.Type(), to...)
+}
+
+// RequestTips requests the current tips from the neighbors.
+// If no peer is provided, all neighbors are queried.
+func (m *Manager) RequestTips(to ...identity.ID) {
+	tipsReq := &pb.TipsRequest{}
+	m.log.Debugw("send packet", "type", tipsReq.Type(), "to", to)
+	m.send(marshal(tipsReq), tipsReq.Type(), to...)
+}
+
+// SendTips sends the given tip ids to the neighbors. At most maxTipsPerPacket tips are sent.
+// If no peer is provided, they are sent to all neighbors.
+func (m *Manager) SendTips(tipIds []message.Id, to ...identity.ID) {
+	if len(tipIds) > maxTipsPerPacket {
+		tipIds = tipIds[:maxTipsPerPacket]
+	}
+
+	tips := &pb.Tips{Ids: make([][]byte, len(tipIds))}
+	for i, tipId := range tipIds {
+		tips.Ids[i] = tipId.Bytes()
+	}
+	m.log.Debugw("send packet", "type", tips.Type(), "to", to)
+	m.send(marshal(tips), tips.Type(), to...)
 }
 
 // AllNeighbors returns all the neighbors that are currently connected.
@@ -175,23 +209,44 @@ func (m *Manager) getNeighborsById(ids []identity.ID) []*Neighbor {
 	return result
 }
 
-func (m *Manager) send(b []byte, to ...identity.ID) {
+// send sends the packet to the given neighbors, skipping the ones that do not support its type.
+func (m *Manager) send(b []byte, packetType pb.PacketType, to ...identity.ID) {
 	neighbors := m.getNeighbors(to...)
 
 	for _, nbr := range neighbors {
+		if !nbr.Supports(packetType) {
+			m.log.Debugw("packet type not supported by neighbor", "type", packetType, "neighbor", nbr.ID())
+			continue
+		}
 		if _, err := nbr.Write(b); err != nil {
 			m.log.Warnw("send error", "err", err, "neighbor", nbr.Peer.Address())
 		}
 	}
 }
 
-func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (net.Conn, error)) error {
+func (m *Manager) server() *server.TCP {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.srv
+}
+
+// addNeighbor establishes the connection without holding the lock, as accepting a connection can take several seconds.
+func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (*server.Connection, error)) error {
 	conn, err := connectorFunc(peer)
 	if err != nil {
 		m.events.ConnectionFailed.Trigger(peer, err)
 		return err
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.srv == nil {
+		_ = conn.Close()
+		m.events.ConnectionFailed.Trigger(peer, ErrNotRunning)
+		return ErrNotRunning
+	}
 	if _, ok := m.neighbors[peer.ID()]; ok {
 		_ = conn.Close()
 		m.events.ConnectionFailed.Trigger(peer, ErrDuplicateNeighbor)
@@ -200,6 +255,7 @@ func (m *Manager) addNeighbor(peer *peer.Peer, connectorFunc func(*peer.Peer) (n
 
 	// create and add the neighbor
 	n := NewNeighbor(peer, conn, m.log)
+	n.setPacketTypes(conn.PacketTypes())
 	n.Events.Close.Attach(events.NewClosure(func() {
 		// assure that the neighbor is removed and notify
 		_ = m.DropNeighbor(peer.ID())
@@ -223,6 +279,9 @@ func (m *Manager) handlePacket(data []byte, p *peer.Peer) error {
 	if len(data) == 0 {
 		return nil
 	}
+	if len(data) > maxPacketSize {
+		return fmt.Errorf("%w: %d bytes", ErrPacketTooLarge, len(data))
+	}
 
 	switch pb.PacketType(data[0]) {
 
@@ -232,6 +291,9 @@ func (m *Manager) handlePacket(data []byte, p *peer.Peer) error {
 			return fmt.Errorf("invalid packet: %w", err)
 		}
 		m.log.Debugw("received packet", "type", protoMsg.Type(), "peer-id", p.ID())
+		if len(protoMsg.GetData()) > message.MaxMessageSize {
+			return fmt.Errorf("%w: %d bytes", message.ErrMessageTooLarge, len(protoMsg.GetData()))
+		}
 		m.events.MessageReceived.Trigger(&MessageReceivedEvent{Data: protoMsg.GetData(), Peer: p})
 
 	case pb.PacketMessageRequest:
@@ -254,6 +316,34 @@ func (m *Manager) handlePacket(data []byte, p *peer.Peer) error {
 		}
 
 		m.SendMessage(msg, p.ID())
+
+	case pb.PacketTipsRequest:
+		protoTipsReq := new(pb.TipsRequest)
+		if err := proto.Unmarshal(data[1:], protoTipsReq); err != nil {
+			return fmt.Errorf("invalid packet: %w", err)
+		}
+
+		m.log.Debugw("received packet", "type", protoTipsReq.Type(), "peer-id", p.ID())
+		m.SendTips(m.loadTipsFunc(), p.ID())
+
+	case pb.PacketTips:
+		protoTips := new(pb.Tips)
+		if err := proto.Unmarshal(data[1:], protoTips); err != nil {
+			return fmt.Errorf("invalid packet: %w", err)
+		}
+
+		m.log.Debugw("received packet", "type", protoTips.Type(), "peer-id", p.ID())
+		tipIds := make([]message.Id, 0, len(protoTips.GetIds()))
+		for _, idBytes := range protoTips.GetIds() {
+			tipId, _, err := message.IdFromBytes(idBytes)
+			if err != nil {
+				m.log.Debugw("couldn't compute message id from bytes", "peer-id", p.ID(), "err", err)
+				return nil
+			}
+			tipIds = append(tipIds, tipId)
+		}
+		m.events.TipsReceived.Trigger(&TipsReceivedEvent{Ids: tipIds, Peer: p})
+
 	default:
 		return ErrInvalidPacket
 	}
@@ -25,10 +25,13 @@ const graceTime = 10 * time.Millisecond
 var (
 	log             = logger.NewExampleLogger("gossip")
 	testMessageData = []byte("testMsg")
+	testTips        = []message.Id{{1}, {2}}
 )
 
 func loadTestMessage(message.Id) ([]byte, error) { return testMessageData, nil }
 
+func loadTestTips() []message.Id { return testTips }
+
 func TestClose(t *testing.T) {
 	_, teardown, _ := newMockedManager(t, "A")
 	teardown()
@@ -76,6 +79,11 @@ func TestClosedConnection(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestMaxMessageFitsIntoPacket(t *testing.T) {
+	packet := marshal(&pb.Message{Data: make([]byte, message.MaxMessageSize)})
+	assert.LessOrEqual(t, len(packet), maxPacketSize)
+}
+
 func TestP2PSend(t *testing.T) {
 	mgrA, closeA, peerA := newMockedManager(t, "A")
 	mgrB, closeB, peerB := newMockedManager(t, "B")
@@ -357,6 +365,50 @@ func TestMessageRequest(t *testing.T) {
 	mgrB.AssertExpectations(t)
 }
 
+func TestTipsRequest(t *testing.T) {
+	mgrA, closeA, peerA := newMockedManager(t, "A")
+	mgrB, closeB, peerB := newMockedManager(t, "B")
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// connect in the following way
+	// B -> A
+	mgrA.On("neighborAdded", mock.Anything).Once()
+	mgrB.On("neighborAdded", mock.Anything).Once()
+
+	go func() {
+		defer wg.Done()
+		err := mgrA.AddInbound(peerB)
+		assert.NoError(t, err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		err := mgrB.AddOutbound(peerA)
+		assert.NoError(t, err)
+	}()
+
+	// wait for the connections to establish
+	wg.Wait()
+
+	// mgrA should eventually receive the tips of B
+	mgrA.On("tipsReceived", &TipsReceivedEvent{Ids: testTips, Peer: peerB}).Once()
+
+	mgrA.RequestTips()
+	time.Sleep(graceTime)
+
+	mgrA.On("neighborRemoved", peerB).Once()
+	mgrB.On("neighborRemoved", peerA).Once()
+
+	closeA()
+	closeB()
+	time.Sleep(graceTime)
+
+	mgrA.AssertExpectations(t)
+	mgrB.AssertExpectations(t)
+}
+
 func TestDropNeighbor(t *testing.T) {
 	mgrA, closeA, peerA := newTestManager(t, "A")
 	defer closeA()
@@ -441,7 +493,7 @@ func newTestManager(t require.TestingT, name string) (*Manager, func(), *peer.Pe
 	srv := server.ServeTCP(local, lis, l)
 
 	// start the actual gossipping
-	mgr := NewManager(local, loadTestMessage, l)
+	mgr := NewManager(local, loadTestMessage, loadTestTips, l)
 	mgr.Start(srv)
 
 	detach := func() {
@@ -465,6 +517,7 @@ func mockManager(t mock.TestingT, mgr *Manager) *mockedManager {
 	e.Events().NeighborAdded.Attach(events.NewClosure(e.neighborAdded))
 	e.Events().NeighborRemoved.Attach(events.NewClosure(e.neighborRemoved))
 	e.Events().MessageReceived.Attach(events.NewClosure(e.messageReceived))
+	e.Events().TipsReceived.Attach(events.NewClosure(e.tipsReceived))
 
 	return e
 }
@@ -478,3 +531,4 @@ func (e *mockedManager) connectionFailed(p *peer.Peer, err error) { e.Called(p,
 func (e *mockedManager) neighborAdded(n *Neighbor)                { e.Called(n) }
 func (e *mockedManager) neighborRemoved(p *peer.Peer)             { e.Called(p) }
 func (e *mockedManager) messageReceived(ev *MessageReceivedEvent) { e.Called(ev) }
+func (e *mockedManager) tipsReceived(ev *TipsReceivedEvent)       { e.Called(ev) }
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/netutil"
@@ -32,6 +33,7 @@ type Neighbor struct {
 	log             *logger.Logger
 	queue           chan []byte
 	messagesDropped atomic.Int32
+	packetTypes     map[pb.PacketType]bool
 
 	wg             sync.WaitGroup
 	closing        chan struct{}
@@ -60,6 +62,29 @@ func NewNeighbor(peer *peer.Peer, conn net.Conn, log *logger.Logger) *Neighbor {
 	}
 }
 
+// Supports returns true if the neighbor advertised the given packet type during the handshake.
+// Neighbors without any advertised packet types are assumed to support all of them.
+func (n *Neighbor) Supports(packetType pb.PacketType) bool {
+	if n.packetTypes == nil {
+		return true
+	}
+	return n.packetTypes[packetType]
+}
+
+// setPacketTypes sets the packet types the neighbor advertised during the handshake.
+// An empty list leaves the packet types unset, so that the neighbor is assumed to support all of them.
+func (n *Neighbor) setPacketTypes(packetTypes []pb.PacketType) {
+	if len(packetTypes) == 0 {
+		n.packetTypes = nil
+		return
+	}
+
+	n.packetTypes = make(map[pb.PacketType]bool, len(packetTypes))
+	for _, packetType := range packetTypes {
+		n.packetTypes[packetType] = true
+	}
+}
+
 // Listen starts the communication to the neighbor.
 func (n *Neighbor) Listen() {
 	n.wg.Add(2)
@@ -143,7 +168,7 @@ func (n *Neighbor) readLoop() {
 func (n *Neighbor) Write(b []byte) (int, error) {
 	l := len(b)
 	if l > maxPacketSize {
-		n.log.Panicw("message too large", "len", l, "max", maxPacketSize)
+		return 0, ErrPacketTooLarge
 	}
 
 	// add to queue
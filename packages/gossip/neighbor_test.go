@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	pb "github.com/iotaledger/goshimmer/packages/gossip/proto"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/crypto/ed25519"
@@ -37,6 +38,25 @@ func TestNeighborCloseTwice(t *testing.T) {
 	require.NoError(t, n.Close())
 }
 
+func TestNeighborSupports(t *testing.T) {
+	a, _, teardown := newPipe()
+	defer teardown()
+
+	n := newTestNeighbor("A", a)
+	assert.True(t, n.Supports(pb.PacketMessageRequest))
+
+	n.setPacketTypes([]pb.PacketType{pb.PacketMessage})
+	assert.True(t, n.Supports(pb.PacketMessage))
+	assert.False(t, n.Supports(pb.PacketMessageRequest))
+
+	// neighbors that advertise no packet types (i.e. from handshakes without the field) support all of them
+	n.setPacketTypes(nil)
+	assert.True(t, n.Supports(pb.PacketMessage))
+	assert.True(t, n.Supports(pb.PacketMessageRequest))
+	n.setPacketTypes([]pb.PacketType{})
+	assert.True(t, n.Supports(pb.PacketMessageRequest))
+}
+
 func TestNeighborWrite(t *testing.T) {
 	a, b, teardown := newPipe()
 	defer teardown()
@@ -61,6 +81,18 @@ func TestNeighborWrite(t *testing.T) {
 	assert.Eventually(t, func() bool { return atomic.LoadUint32(&count) == 1 }, time.Second, 10*time.Millisecond)
 }
 
+func TestNeighborWriteTooLarge(t *testing.T) {
+	a, _, teardown := newPipe()
+	defer teardown()
+
+	neighborA := newTestNeighbor("A", a)
+	defer neighborA.Close()
+	neighborA.Listen()
+
+	_, err := neighborA.Write(make([]byte, maxPacketSize+1))
+	assert.Equal(t, ErrPacketTooLarge, err)
+}
+
 func TestNeighborParallelWrite(t *testing.T) {
 	a, b, teardown := newPipe()
 	defer teardown()
@@ -5,9 +5,8 @@ package proto
 
 import (
 	fmt "fmt"
-	math "math"
-
 	proto "github.com/golang/protobuf/proto"
+	math "math"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -99,9 +98,81 @@ func (m *MessageRequest) GetId() []byte {
 	return nil
 }
 
+type TipsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TipsRequest) Reset()         { *m = TipsRequest{} }
+func (m *TipsRequest) String() string { return proto.CompactTextString(m) }
+func (*TipsRequest) ProtoMessage()    {}
+func (*TipsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{2}
+}
+
+func (m *TipsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TipsRequest.Unmarshal(m, b)
+}
+func (m *TipsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TipsRequest.Marshal(b, m, deterministic)
+}
+func (m *TipsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TipsRequest.Merge(m, src)
+}
+func (m *TipsRequest) XXX_Size() int {
+	return xxx_messageInfo_TipsRequest.Size(m)
+}
+func (m *TipsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TipsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TipsRequest proto.InternalMessageInfo
+
+type Tips struct {
+	Ids                  [][]byte `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Tips) Reset()         { *m = Tips{} }
+func (m *Tips) String() string { return proto.CompactTextString(m) }
+func (*Tips) ProtoMessage()    {}
+func (*Tips) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{3}
+}
+
+func (m *Tips) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Tips.Unmarshal(m, b)
+}
+func (m *Tips) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Tips.Marshal(b, m, deterministic)
+}
+func (m *Tips) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Tips.Merge(m, src)
+}
+func (m *Tips) XXX_Size() int {
+	return xxx_messageInfo_Tips.Size(m)
+}
+func (m *Tips) XXX_DiscardUnknown() {
+	xxx_messageInfo_Tips.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Tips proto.InternalMessageInfo
+
+func (m *Tips) GetIds() [][]byte {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Message)(nil), "proto.Message")
 	proto.RegisterType((*MessageRequest)(nil), "proto.MessageRequest")
+	proto.RegisterType((*TipsRequest)(nil), "proto.TipsRequest")
+	proto.RegisterType((*Tips)(nil), "proto.Tips")
 }
 
 func init() {
@@ -109,15 +180,16 @@ func init() {
 }
 
 var fileDescriptor_33c57e4bae7b9afd = []byte{
-	// 147 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcd, 0x4d, 0x2d, 0x2e,
-	0x4e, 0x4c, 0x4f, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x05, 0x53, 0x4a, 0xb2, 0x5c,
-	0xec, 0xbe, 0x10, 0x71, 0x21, 0x21, 0x2e, 0x96, 0x94, 0xc4, 0x92, 0x44, 0x09, 0x46, 0x05, 0x46,
-	0x0d, 0x9e, 0x20, 0x30, 0x5b, 0x49, 0x81, 0x8b, 0x0f, 0x2a, 0x1d, 0x94, 0x5a, 0x58, 0x9a, 0x5a,
-	0x5c, 0x22, 0xc4, 0xc7, 0xc5, 0x94, 0x99, 0x02, 0x55, 0xc3, 0x94, 0x99, 0xe2, 0x64, 0x1e, 0x65,
-	0x9a, 0x9e, 0x59, 0x92, 0x51, 0x9a, 0xa4, 0x97, 0x9c, 0x9f, 0xab, 0x9f, 0x99, 0x5f, 0x92, 0x98,
-	0x93, 0x9a, 0x92, 0x9e, 0x5a, 0xa4, 0x9f, 0x9e, 0x5f, 0x9c, 0x91, 0x99, 0x9b, 0x9b, 0x5a, 0xa4,
-	0x5f, 0x90, 0x98, 0x9c, 0x9d, 0x98, 0x9e, 0x5a, 0x0c, 0x12, 0x2a, 0xce, 0x2c, 0xd0, 0x07, 0xdb,
-	0x9c, 0xc4, 0x06, 0xa6, 0x8c, 0x01, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc4, 0xb0, 0x39, 0x6d, 0x98,
-	0x00, 0x00, 0x00,
+	// 173 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x2c, 0x8e, 0xbf, 0x6f, 0x83, 0x30,
+	0x10, 0x85, 0xc5, 0x8f, 0xb6, 0xd2, 0x15, 0x50, 0xe5, 0x89, 0xa5, 0x12, 0xf2, 0xc4, 0x84, 0x87,
+	0xaa, 0xca, 0x9e, 0x3d, 0x0b, 0xca, 0x94, 0xcd, 0xe0, 0x93, 0x39, 0x25, 0x96, 0x1d, 0xce, 0xfc,
+	0xff, 0x11, 0x0e, 0xd3, 0x7b, 0xf7, 0x7d, 0x37, 0x3c, 0xa8, 0x1d, 0x32, 0x6b, 0x8b, 0x43, 0x58,
+	0x7d, 0xf4, 0xe2, 0x23, 0x85, 0xfc, 0x85, 0xaf, 0xcb, 0x9b, 0x0b, 0x01, 0xa5, 0xd1, 0x51, 0xb7,
+	0x59, 0x97, 0xf5, 0xd5, 0x98, 0xba, 0xec, 0xa0, 0x39, 0xf4, 0x88, 0xcf, 0x0d, 0x39, 0x8a, 0x06,
+	0x72, 0x32, 0xc7, 0x4f, 0x4e, 0x46, 0xd6, 0xf0, 0x7d, 0xa5, 0xc0, 0x87, 0x96, 0x2d, 0x94, 0xfb,
+	0x29, 0x7e, 0xa0, 0x20, 0xc3, 0x6d, 0xd6, 0x15, 0x7d, 0x35, 0xee, 0xf5, 0x7c, 0xba, 0xfd, 0x5b,
+	0x8a, 0xcb, 0x36, 0x0d, 0xb3, 0x77, 0x8a, 0x7c, 0xd4, 0x0f, 0x34, 0x16, 0x57, 0x65, 0x3d, 0x2f,
+	0xe4, 0x1c, 0xae, 0x2a, 0xe8, 0xf9, 0xae, 0x2d, 0xf2, 0x8e, 0x98, 0x82, 0x4a, 0x13, 0xa7, 0xcf,
+	0x14, 0x7f, 0xaf, 0x01, 0x00, 0xb9, 0x59, 0x7e, 0x4c, 0xc1, 0x00, 0x00, 0x00,
 }
@@ -11,8 +11,14 @@ type PacketType uint
 const (
 	PacketMessage PacketType = 20 + iota
 	PacketMessageRequest
+	PacketTipsRequest
+	PacketTips
 )
 
+// SupportedPacketTypes contains all the packet types this node is able to handle.
+// They are advertised to the neighbors during the handshake.
+var SupportedPacketTypes = []PacketType{PacketMessage, PacketMessageRequest, PacketTipsRequest, PacketTips}
+
 // Packet extends the proto.Message interface with additional util functions.
 type Packet interface {
 	proto.Message
@@ -34,3 +40,15 @@ func (m *MessageRequest) Name() string { return "message_request" }
 
 // Type returns the packet type id of the message request packet.
 func (m *MessageRequest) Type() PacketType { return PacketMessageRequest }
+
+// Name returns the name of the tips request packet.
+func (m *TipsRequest) Name() string { return "tips_request" }
+
+// Type returns the packet type id of the tips request packet.
+func (m *TipsRequest) Type() PacketType { return PacketTipsRequest }
+
+// Name returns the name of the tips packet.
+func (m *Tips) Name() string { return "tips" }
+
+// Type returns the packet type id of the tips packet.
+func (m *Tips) Type() PacketType { return PacketTips }
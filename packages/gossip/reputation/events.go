@@ -0,0 +1,22 @@
+package reputation
+
+import (
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// Events represents events happening on a reputation manager.
+type Events struct {
+	// Fired when the score of a neighbor fell below the threshold and the neighbor got blacklisted.
+	NeighborBlacklisted *events.Event
+}
+
+func newEvents() Events {
+	return Events{
+		NeighborBlacklisted: events.NewEvent(identityCaller),
+	}
+}
+
+func identityCaller(handler interface{}, params ...interface{}) {
+	handler.(func(identity.ID))(params[0].(identity.ID))
+}
@@ -0,0 +1,86 @@
+package reputation
+
+import (
+	"time"
+)
+
+const (
+	// DefaultUsefulWeight defines the default score change for a message that passed all filters.
+	DefaultUsefulWeight = 1.0
+	// DefaultDuplicateWeight defines the default score change for a message that was already seen.
+	DefaultDuplicateWeight = -0.05
+	// DefaultInvalidWeight defines the default score change for invalid bytes or an invalid message.
+	DefaultInvalidWeight = -10.0
+	// DefaultUnansweredWeight defines the default score change for a message request that was not answered.
+	DefaultUnansweredWeight = -2.0
+	// DefaultThreshold defines the default score below which a neighbor gets blacklisted.
+	DefaultThreshold = -50.0
+	// DefaultHalfLife defines the default time after which a score decayed to half of its value.
+	DefaultHalfLife = 10 * time.Minute
+	// DefaultBlacklistDuration defines the default time a misbehaving neighbor stays blacklisted.
+	DefaultBlacklistDuration = 30 * time.Minute
+)
+
+// Options holds options for a reputation manager.
+type Options struct {
+	usefulWeight      float64
+	duplicateWeight   float64
+	invalidWeight     float64
+	unansweredWeight  float64
+	threshold         float64
+	halfLife          time.Duration
+	blacklistDuration time.Duration
+}
+
+func newOptions(optionalOptions []Option) *Options {
+	result := &Options{
+		usefulWeight:      DefaultUsefulWeight,
+		duplicateWeight:   DefaultDuplicateWeight,
+		invalidWeight:     DefaultInvalidWeight,
+		unansweredWeight:  DefaultUnansweredWeight,
+		threshold:         DefaultThreshold,
+		halfLife:          DefaultHalfLife,
+		blacklistDuration: DefaultBlacklistDuration,
+	}
+
+	for _, optionalOption := range optionalOptions {
+		optionalOption(result)
+	}
+
+	return result
+}
+
+// Option is a function which inits an option.
+type Option func(*Options)
+
+// Weights creates an option which sets the score changes for useful, duplicate, invalid and unanswered messages.
+func Weights(useful float64, duplicate float64, invalid float64, unanswered float64) Option {
+	return func(args *Options) {
+		args.usefulWeight = useful
+		args.duplicateWeight = duplicate
+		args.invalidWeight = invalid
+		args.unansweredWeight = unanswered
+	}
+}
+
+// Threshold creates an option which sets the score below which a neighbor gets blacklisted.
+func Threshold(threshold float64) Option {
+	return func(args *Options) {
+		args.threshold = threshold
+	}
+}
+
+// HalfLife creates an option which sets the time after which a score decayed to half of its value.
+// A non-positive value disables the decay.
+func HalfLife(halfLife time.Duration) Option {
+	return func(args *Options) {
+		args.halfLife = halfLife
+	}
+}
+
+// BlacklistDuration creates an option which sets the time a misbehaving neighbor stays blacklisted.
+func BlacklistDuration(duration time.Duration) Option {
+	return func(args *Options) {
+		args.blacklistDuration = duration
+	}
+}
@@ -0,0 +1,190 @@
+package reputation
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+)
+
+// Manager keeps track of the reputation of the gossip neighbors and blacklists the ones that misbehave.
+type Manager struct {
+	Events Events
+
+	options         *Options
+	scores          map[identity.ID]*Score
+	pendingRequests map[string]identity.ID
+	blacklist       map[identity.ID]time.Time
+	mutex           sync.Mutex
+}
+
+// New creates a new reputation manager.
+func New(optionalOptions ...Option) *Manager {
+	return &Manager{
+		Events:          newEvents(),
+		options:         newOptions(optionalOptions),
+		scores:          make(map[identity.ID]*Score),
+		pendingRequests: make(map[string]identity.ID),
+		blacklist:       make(map[identity.ID]time.Time),
+	}
+}
+
+// AddNeighbor starts tracking the reputation of the given neighbor.
+func (manager *Manager) AddNeighbor(id identity.ID) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	if _, exists := manager.scores[id]; !exists {
+		manager.scores[id] = &Score{updated: time.Now()}
+	}
+}
+
+// RemoveNeighbor stops tracking the reputation of the given neighbor.
+func (manager *Manager) RemoveNeighbor(id identity.ID) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	delete(manager.scores, id)
+}
+
+// RecordUseful records a message of the given neighbor that passed all filters.
+func (manager *Manager) RecordUseful(id identity.ID) {
+	manager.record(id, manager.options.usefulWeight, func(score *Score) { score.Useful++ })
+}
+
+// RecordDuplicate records a message of the given neighbor that was already seen.
+func (manager *Manager) RecordDuplicate(id identity.ID) {
+	manager.record(id, manager.options.duplicateWeight, func(score *Score) { score.Duplicate++ })
+}
+
+// RecordInvalid records invalid bytes or an invalid message of the given neighbor.
+func (manager *Manager) RecordInvalid(id identity.ID) {
+	manager.record(id, manager.options.invalidWeight, func(score *Score) { score.Invalid++ })
+}
+
+// RequestSent records that the given message was requested from the given neighbor.
+// If the message was already requested from a neighbor before, that request counts as unanswered.
+func (manager *Manager) RequestSent(id identity.ID, messageID []byte) {
+	manager.mutex.Lock()
+	previous, pending := manager.pendingRequests[string(messageID)]
+	manager.pendingRequests[string(messageID)] = id
+	manager.mutex.Unlock()
+
+	if pending {
+		manager.recordUnanswered(previous)
+	}
+}
+
+// RequestCompleted removes the pending request of the given message without changing any score.
+func (manager *Manager) RequestCompleted(messageID []byte) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	delete(manager.pendingRequests, string(messageID))
+}
+
+// RequestFailed removes the pending request of the given message and counts it as unanswered.
+func (manager *Manager) RequestFailed(messageID []byte) {
+	manager.mutex.Lock()
+	id, pending := manager.pendingRequests[string(messageID)]
+	delete(manager.pendingRequests, string(messageID))
+	manager.mutex.Unlock()
+
+	if pending {
+		manager.recordUnanswered(id)
+	}
+}
+
+// Score returns the current score of the given neighbor.
+func (manager *Manager) Score(id identity.ID) (result Score, exists bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	score, exists := manager.scores[id]
+	if !exists {
+		return
+	}
+	score.decay(time.Now(), manager.options.halfLife)
+
+	return *score, true
+}
+
+// Scores returns the current scores of all tracked neighbors.
+func (manager *Manager) Scores() map[identity.ID]Score {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	now := time.Now()
+	result := make(map[identity.ID]Score, len(manager.scores))
+	for id, score := range manager.scores {
+		score.decay(now, manager.options.halfLife)
+		result[id] = *score
+	}
+
+	return result
+}
+
+// IsBlacklisted returns true if the given peer is currently blacklisted.
+func (manager *Manager) IsBlacklisted(id identity.ID) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	until, blacklisted := manager.blacklist[id]
+	if !blacklisted {
+		return false
+	}
+	if time.Now().After(until) {
+		delete(manager.blacklist, id)
+		return false
+	}
+
+	return true
+}
+
+// Blacklist returns all currently blacklisted peers together with the time their blacklisting ends.
+func (manager *Manager) Blacklist() map[identity.ID]time.Time {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+
+	now := time.Now()
+	result := make(map[identity.ID]time.Time, len(manager.blacklist))
+	for id, until := range manager.blacklist {
+		if now.After(until) {
+			delete(manager.blacklist, id)
+			continue
+		}
+		result[id] = until
+	}
+
+	return result
+}
+
+func (manager *Manager) recordUnanswered(id identity.ID) {
+	manager.record(id, manager.options.unansweredWeight, func(score *Score) { score.Unanswered++ })
+}
+
+// record applies the given weight to the score of the neighbor and blacklists it if it fell below the threshold.
+func (manager *Manager) record(id identity.ID, weight float64, count func(score *Score)) {
+	manager.mutex.Lock()
+	score, exists := manager.scores[id]
+	if !exists {
+		manager.mutex.Unlock()
+		return
+	}
+
+	score.decay(time.Now(), manager.options.halfLife)
+	score.Value += weight
+	count(score)
+
+	if score.Value >= manager.options.threshold {
+		manager.mutex.Unlock()
+		return
+	}
+
+	// stop tracking the neighbor so that it is only reported once
+	delete(manager.scores, id)
+	manager.blacklist[id] = time.Now().Add(manager.options.blacklistDuration)
+	manager.mutex.Unlock()
+
+	manager.Events.NeighborBlacklisted.Trigger(id)
+}
@@ -0,0 +1,98 @@
+package reputation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Record(t *testing.T) {
+	manager := New(Weights(1, -0.5, -10, -2), HalfLife(0))
+	id := identity.GenerateIdentity().ID()
+
+	// observations of unknown neighbors are ignored
+	manager.RecordUseful(id)
+	_, exists := manager.Score(id)
+	assert.False(t, exists)
+
+	manager.AddNeighbor(id)
+	manager.RecordUseful(id)
+	manager.RecordUseful(id)
+	manager.RecordDuplicate(id)
+	manager.RecordInvalid(id)
+
+	score, exists := manager.Score(id)
+	require.True(t, exists)
+	assert.Equal(t, 2-0.5-10, score.Value)
+	assert.EqualValues(t, 2, score.Useful)
+	assert.EqualValues(t, 1, score.Duplicate)
+	assert.EqualValues(t, 1, score.Invalid)
+	assert.EqualValues(t, 0, score.Unanswered)
+
+	manager.RemoveNeighbor(id)
+	assert.Empty(t, manager.Scores())
+}
+
+func TestManager_Requests(t *testing.T) {
+	manager := New(Weights(1, 0, -10, -2), HalfLife(0))
+	first := identity.GenerateIdentity().ID()
+	second := identity.GenerateIdentity().ID()
+	manager.AddNeighbor(first)
+	manager.AddNeighbor(second)
+
+	// a retry to another neighbor counts the previous request as unanswered
+	manager.RequestSent(first, []byte("message"))
+	manager.RequestSent(second, []byte("message"))
+	manager.RequestFailed([]byte("message"))
+
+	// completed requests do not change the score
+	manager.RequestSent(first, []byte("other"))
+	manager.RequestCompleted([]byte("other"))
+	manager.RequestFailed([]byte("other"))
+
+	scores := manager.Scores()
+	assert.Equal(t, -2.0, scores[first].Value)
+	assert.EqualValues(t, 1, scores[first].Unanswered)
+	assert.Equal(t, -2.0, scores[second].Value)
+	assert.EqualValues(t, 1, scores[second].Unanswered)
+}
+
+func TestManager_Blacklist(t *testing.T) {
+	manager := New(Weights(1, 0, -10, -2), Threshold(-15), HalfLife(0), BlacklistDuration(100*time.Millisecond))
+	id := identity.GenerateIdentity().ID()
+	manager.AddNeighbor(id)
+
+	blacklisted := 0
+	manager.Events.NeighborBlacklisted.Attach(events.NewClosure(func(blacklistedID identity.ID) {
+		assert.Equal(t, id, blacklistedID)
+		blacklisted++
+	}))
+
+	manager.RecordInvalid(id)
+	assert.False(t, manager.IsBlacklisted(id))
+	manager.RecordInvalid(id)
+	assert.True(t, manager.IsBlacklisted(id))
+	assert.Contains(t, manager.Blacklist(), id)
+
+	// the neighbor is only reported once
+	manager.RecordInvalid(id)
+	assert.Equal(t, 1, blacklisted)
+
+	assert.Eventually(t, func() bool { return !manager.IsBlacklisted(id) }, time.Second, 10*time.Millisecond)
+	assert.Empty(t, manager.Blacklist())
+}
+
+func TestScore_Decay(t *testing.T) {
+	now := time.Now()
+	score := &Score{Value: -8, updated: now}
+
+	score.decay(now.Add(2*time.Minute), time.Minute)
+	assert.InDelta(t, -2, score.Value, 1e-9)
+
+	score.decay(now.Add(3*time.Minute), 0)
+	assert.InDelta(t, -2, score.Value, 1e-9)
+}
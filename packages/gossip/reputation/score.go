@@ -0,0 +1,30 @@
+package reputation
+
+import (
+	"math"
+	"time"
+)
+
+// Score contains the reputation of a single neighbor.
+type Score struct {
+	// Value is the decayed sum of all weighted observations.
+	Value float64
+	// Useful is the number of messages that passed all filters.
+	Useful uint64
+	// Duplicate is the number of messages that were already seen.
+	Duplicate uint64
+	// Invalid is the number of rejected bytes or messages.
+	Invalid uint64
+	// Unanswered is the number of message requests that were not answered.
+	Unanswered uint64
+
+	updated time.Time
+}
+
+// decay reduces the value of the score according to the time passed since its last update.
+func (score *Score) decay(now time.Time, halfLife time.Duration) {
+	if halfLife > 0 && !score.updated.IsZero() {
+		score.Value *= math.Exp2(-float64(now.Sub(score.updated)) / float64(halfLife))
+	}
+	score.updated = now
+}
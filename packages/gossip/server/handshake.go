@@ -2,15 +2,17 @@ package server
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	gp "github.com/iotaledger/goshimmer/packages/gossip/proto"
 	pb "github.com/iotaledger/goshimmer/packages/gossip/server/proto"
 	"github.com/iotaledger/hive.go/autopeering/server"
 )
 
 const (
-	versionNum          = 0
+	versionNum          = 1
 	handshakeExpiration = 20 * time.Second
 )
 
@@ -19,36 +21,37 @@ func isExpired(ts int64) bool {
 	return time.Since(time.Unix(ts, 0)) >= handshakeExpiration
 }
 
-func newHandshakeRequest(toAddr string) ([]byte, error) {
+func (t *TCP) newHandshakeRequest(toAddr string) ([]byte, error) {
 	m := &pb.HandshakeRequest{
-		Version:   versionNum,
-		To:        toAddr,
-		Timestamp: time.Now().Unix(),
+		Version:     versionNum,
+		To:          toAddr,
+		Timestamp:   time.Now().Unix(),
+		NetworkId:   t.networkID,
+		PacketTypes: marshalPacketTypes(t.packetTypes),
 	}
 	return proto.Marshal(m)
 }
 
-func newHandshakeResponse(reqData []byte) ([]byte, error) {
+func (t *TCP) newHandshakeResponse(reqData []byte) ([]byte, error) {
 	m := &pb.HandshakeResponse{
-		ReqHash: server.PacketHash(reqData),
+		ReqHash:     server.PacketHash(reqData),
+		Version:     versionNum,
+		NetworkId:   t.networkID,
+		PacketTypes: marshalPacketTypes(t.packetTypes),
 	}
 	return proto.Marshal(m)
 }
 
-func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
+func (t *TCP) validateHandshakeRequest(reqData []byte) (*pb.HandshakeRequest, error) {
 	m := new(pb.HandshakeRequest)
 	if err := proto.Unmarshal(reqData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return nil, ErrInvalidHandshake
 	}
-	if m.GetVersion() != versionNum {
-		t.log.Debugw("invalid handshake",
-			"version", m.GetVersion(),
-			"want", versionNum,
-		)
-		return false
+	if err := t.checkCompatibility(m.GetVersion(), m.GetNetworkId()); err != nil {
+		return nil, err
 	}
 	if isExpired(m.GetTimestamp()) {
 		t.log.Debugw("invalid handshake",
@@ -56,23 +59,53 @@ func (t *TCP) validateHandshakeRequest(reqData []byte) bool {
 		)
 	}
 
-	return true
+	return m, nil
 }
 
-func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) bool {
+func (t *TCP) validateHandshakeResponse(resData []byte, reqData []byte) (*pb.HandshakeResponse, error) {
 	m := new(pb.HandshakeResponse)
 	if err := proto.Unmarshal(resData, m); err != nil {
 		t.log.Debugw("invalid handshake",
 			"err", err,
 		)
-		return false
+		return nil, ErrInvalidHandshake
 	}
 	if !bytes.Equal(m.GetReqHash(), server.PacketHash(reqData)) {
 		t.log.Debugw("invalid handshake",
 			"hash", m.GetReqHash(),
 		)
-		return false
+		return nil, ErrInvalidHandshake
+	}
+	if err := t.checkCompatibility(m.GetVersion(), m.GetNetworkId()); err != nil {
+		return nil, err
 	}
 
-	return true
+	return m, nil
+}
+
+// checkCompatibility returns an error if the remote peer speaks another protocol version or belongs to another network.
+func (t *TCP) checkCompatibility(version uint32, networkID uint32) error {
+	if version != versionNum {
+		return fmt.Errorf("%w: version %d, want %d", ErrIncompatibleVersion, version, versionNum)
+	}
+	if networkID != t.networkID {
+		return fmt.Errorf("%w: network %d, want %d", ErrIncompatibleNetwork, networkID, t.networkID)
+	}
+	return nil
+}
+
+func marshalPacketTypes(packetTypes []gp.PacketType) []uint32 {
+	result := make([]uint32, len(packetTypes))
+	for i, packetType := range packetTypes {
+		result[i] = uint32(packetType)
+	}
+	return result
+}
+
+func unmarshalPacketTypes(packetTypes []uint32) []gp.PacketType {
+	result := make([]gp.PacketType, len(packetTypes))
+	for i, packetType := range packetTypes {
+		result[i] = gp.PacketType(packetType)
+	}
+	return result
 }
@@ -27,7 +27,11 @@ type HandshakeRequest struct {
 	// string form of the recipient address
 	To string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
 	// unix time
-	Timestamp            int64    `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Timestamp int64 `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// identifier of the network the sender belongs to
+	NetworkId uint32 `protobuf:"varint,4,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// packet types supported by the sender
+	PacketTypes          []uint32 `protobuf:"varint,5,rep,packed,name=packet_types,json=packetTypes,proto3" json:"packet_types,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -79,9 +83,29 @@ func (m *HandshakeRequest) GetTimestamp() int64 {
 	return 0
 }
 
+func (m *HandshakeRequest) GetNetworkId() uint32 {
+	if m != nil {
+		return m.NetworkId
+	}
+	return 0
+}
+
+func (m *HandshakeRequest) GetPacketTypes() []uint32 {
+	if m != nil {
+		return m.PacketTypes
+	}
+	return nil
+}
+
 type HandshakeResponse struct {
 	// hash of the ping packet
-	ReqHash              []byte   `protobuf:"bytes,1,opt,name=req_hash,json=reqHash,proto3" json:"req_hash,omitempty"`
+	ReqHash []byte `protobuf:"bytes,1,opt,name=req_hash,json=reqHash,proto3" json:"req_hash,omitempty"`
+	// protocol version number
+	Version uint32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// identifier of the network the sender belongs to
+	NetworkId uint32 `protobuf:"varint,3,opt,name=network_id,json=networkId,proto3" json:"network_id,omitempty"`
+	// packet types supported by the sender
+	PacketTypes          []uint32 `protobuf:"varint,4,rep,packed,name=packet_types,json=packetTypes,proto3" json:"packet_types,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -119,6 +143,27 @@ func (m *HandshakeResponse) GetReqHash() []byte {
 	return nil
 }
 
+func (m *HandshakeResponse) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+func (m *HandshakeResponse) GetNetworkId() uint32 {
+	if m != nil {
+		return m.NetworkId
+	}
+	return 0
+}
+
+func (m *HandshakeResponse) GetPacketTypes() []uint32 {
+	if m != nil {
+		return m.PacketTypes
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*HandshakeRequest)(nil), "proto.HandshakeRequest")
 	proto.RegisterType((*HandshakeResponse)(nil), "proto.HandshakeResponse")
@@ -127,18 +172,23 @@ func init() {
 func init() { proto.RegisterFile("server/proto/handshake.proto", fileDescriptor_d7101ffe19b05443) }
 
 var fileDescriptor_d7101ffe19b05443 = []byte{
-	// 206 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x5c, 0x8f, 0x31, 0x4b, 0x83, 0x31,
-	0x10, 0x86, 0x69, 0x8b, 0xd6, 0x06, 0x15, 0xcd, 0xf4, 0x09, 0x82, 0xd2, 0xc9, 0xe9, 0xcb, 0xe0,
-	0x0f, 0x10, 0x9d, 0x3a, 0x67, 0xec, 0x22, 0xd7, 0xf6, 0x48, 0x42, 0x4d, 0x2e, 0xcd, 0x5d, 0xfd,
-	0xfd, 0x9e, 0x81, 0xa2, 0x74, 0x7a, 0xdf, 0xe7, 0x09, 0x79, 0x49, 0xcc, 0x93, 0x34, 0x28, 0x5c,
-	0xa9, 0x89, 0xab, 0x8d, 0x84, 0x5c, 0x84, 0xb2, 0xe3, 0x08, 0x7b, 0x1c, 0x3b, 0xdb, 0x8b, 0x1e,
-	0xcb, 0xb5, 0xb9, 0x5b, 0x9d, 0x4e, 0x3c, 0x1e, 0x8e, 0xc8, 0x62, 0x07, 0x33, 0xff, 0xc6, 0xc6,
-	0x89, 0xca, 0x30, 0x79, 0x9e, 0xbc, 0xdc, 0xf8, 0x13, 0xda, 0x5b, 0x33, 0x15, 0x1a, 0xa6, 0x2a,
-	0x17, 0x5e, 0x9b, 0x7d, 0x34, 0x0b, 0x49, 0x59, 0xef, 0x40, 0xae, 0xc3, 0x4c, 0xf5, 0xcc, 0xff,
-	0x89, 0xe5, 0x68, 0xee, 0xff, 0x6d, 0xeb, 0x63, 0x0a, 0xa3, 0x7d, 0x30, 0x57, 0x0d, 0x0f, 0x9f,
-	0x11, 0x38, 0xf6, 0xf5, 0x6b, 0x3f, 0x57, 0x5e, 0x29, 0x7e, 0xbc, 0xaf, 0xdf, 0x42, 0x92, 0x78,
-	0xdc, 0x8c, 0x5b, 0xca, 0x2e, 0x91, 0xc0, 0x17, 0xee, 0x02, 0x36, 0x17, 0x88, 0x63, 0xca, 0x59,
-	0x5b, 0x85, 0xed, 0x1e, 0x02, 0xf2, 0xaf, 0xe2, 0x54, 0xdd, 0xd9, 0x2f, 0x37, 0x97, 0x3d, 0x5e,
-	0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x51, 0x6a, 0x20, 0xf4, 0xff, 0x00, 0x00, 0x00,
+	// 273 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x91, 0xb1, 0x4e, 0xc3, 0x30,
+	0x10, 0x86, 0xe5, 0xa4, 0xa5, 0xd4, 0xb4, 0x08, 0x3c, 0x19, 0xa9, 0x48, 0xa1, 0x53, 0xa6, 0x66,
+	0xe0, 0x01, 0x10, 0x4c, 0x65, 0xb5, 0x98, 0x58, 0x22, 0x37, 0x39, 0xc5, 0x56, 0x48, 0xec, 0xf8,
+	0xae, 0x45, 0xbc, 0x02, 0xcf, 0xc0, 0xc3, 0xa2, 0xa4, 0x54, 0xb4, 0x62, 0x60, 0xfa, 0xf5, 0x7f,
+	0x27, 0x9d, 0x3e, 0xdd, 0xf1, 0x05, 0x42, 0xd8, 0x41, 0xc8, 0x7c, 0x70, 0xe4, 0x32, 0xa3, 0xdb,
+	0x12, 0x8d, 0xae, 0x61, 0x35, 0x74, 0x31, 0x1e, 0x62, 0xf9, 0xc5, 0xf8, 0xd5, 0xfa, 0x30, 0x52,
+	0xd0, 0x6d, 0x01, 0x49, 0x48, 0x3e, 0xd9, 0x41, 0x40, 0xeb, 0x5a, 0xc9, 0x12, 0x96, 0xce, 0xd5,
+	0xa1, 0x8a, 0x4b, 0x1e, 0x91, 0x93, 0x51, 0xc2, 0xd2, 0xa9, 0x8a, 0xc8, 0x89, 0x05, 0x9f, 0x92,
+	0x6d, 0x00, 0x49, 0x37, 0x5e, 0xc6, 0x09, 0x4b, 0x63, 0xf5, 0x0b, 0xc4, 0x2d, 0xe7, 0x2d, 0xd0,
+	0xbb, 0x0b, 0x75, 0x6e, 0x4b, 0x39, 0x1a, 0x56, 0x4d, 0x7f, 0xc8, 0x73, 0x29, 0xee, 0xf8, 0xcc,
+	0xeb, 0xa2, 0x06, 0xca, 0xe9, 0xc3, 0x03, 0xca, 0x71, 0x12, 0xa7, 0x73, 0x75, 0xb1, 0x67, 0x2f,
+	0x3d, 0x5a, 0x7e, 0x32, 0x7e, 0x7d, 0xa4, 0x87, 0xde, 0xb5, 0x08, 0xe2, 0x86, 0x9f, 0x07, 0xe8,
+	0x72, 0xa3, 0xd1, 0x0c, 0x82, 0x33, 0x35, 0x09, 0xd0, 0xad, 0x35, 0x9a, 0x63, 0xf5, 0xe8, 0x54,
+	0xfd, 0x54, 0x26, 0xfe, 0x4f, 0x66, 0xf4, 0x47, 0xe6, 0xe9, 0xf1, 0xf5, 0xa1, 0xb2, 0x64, 0xb6,
+	0x9b, 0x55, 0xe1, 0x9a, 0xcc, 0x3a, 0xd2, 0x6f, 0x50, 0x56, 0x10, 0xb2, 0xca, 0xa1, 0xb1, 0x4d,
+	0xd3, 0xdf, 0x5a, 0x17, 0xb5, 0xae, 0x00, 0x7b, 0x84, 0xd6, 0x67, 0x14, 0x74, 0x8b, 0xde, 0x05,
+	0xda, 0x7f, 0x61, 0x73, 0x36, 0xc4, 0xfd, 0xf7, 0x00, 0x0f, 0x54, 0x39, 0xeb, 0x9c, 0x01, 0x00,
+	0x00,
 }
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	gp "github.com/iotaledger/goshimmer/packages/gossip/proto"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	pb "github.com/iotaledger/hive.go/autopeering/server/proto"
@@ -32,6 +33,10 @@ var (
 	ErrInvalidHandshake = errors.New("invalid handshake")
 	// ErrNoGossip means that the given peer does not support the gossip service.
 	ErrNoGossip = errors.New("peer does not have a gossip service")
+	// ErrIncompatibleVersion is returned when the peer speaks a different gossip protocol version.
+	ErrIncompatibleVersion = errors.New("incompatible protocol version")
+	// ErrIncompatibleNetwork is returned when the peer belongs to a different network.
+	ErrIncompatibleNetwork = errors.New("incompatible network")
 )
 
 // connection timeouts
@@ -49,9 +54,11 @@ var dialRetryPolicy = backoff.ConstantBackOff(500 * time.Millisecond).With(backo
 
 // TCP establishes verified incoming and outgoing TCP connections to other peers.
 type TCP struct {
-	local    *peer.Local
-	listener *net.TCPListener
-	log      *zap.SugaredLogger
+	local       *peer.Local
+	listener    *net.TCPListener
+	log         *zap.SugaredLogger
+	networkID   uint32
+	packetTypes []gp.PacketType
 
 	addAcceptMatcher chan *acceptMatcher
 	acceptReceived   chan accept
@@ -61,9 +68,39 @@ type TCP struct {
 	closing   chan struct{} // if this channel gets closed all pending waits should terminate
 }
 
+// Connection is an established gossip connection together with the capabilities the peer advertised in the handshake.
+type Connection struct {
+	net.Conn
+
+	packetTypes []gp.PacketType
+}
+
+// PacketTypes returns the packet types supported by the peer.
+func (c *Connection) PacketTypes() []gp.PacketType {
+	return c.packetTypes
+}
+
+// Option configures the TCP server.
+type Option func(*TCP)
+
+// NetworkID creates an option which sets the identifier of the network; peers of other networks are rejected.
+func NetworkID(networkID uint32) Option {
+	return func(t *TCP) {
+		t.networkID = networkID
+	}
+}
+
+// PacketTypes creates an option which sets the packet types advertised to the peers.
+// It defaults to all the supported packet types.
+func PacketTypes(packetTypes ...gp.PacketType) Option {
+	return func(t *TCP) {
+		t.packetTypes = packetTypes
+	}
+}
+
 // connect contains the result of an incoming connection.
 type connect struct {
-	c   net.Conn
+	c   *Connection
 	err error
 }
 
@@ -74,21 +111,27 @@ type acceptMatcher struct {
 }
 
 type accept struct {
-	fromID identity.ID // ID of the connecting peer
-	req    []byte      // raw data of the handshake request
-	conn   net.Conn    // the actual network connection
+	fromID      identity.ID     // ID of the connecting peer
+	req         []byte          // raw data of the handshake request
+	packetTypes []gp.PacketType // packet types supported by the connecting peer
+	err         error           // reason why the handshake request was rejected
+	conn        net.Conn        // the actual network connection
 }
 
 // ServeTCP creates the object and starts listening for incoming connections.
-func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger) *TCP {
+func ServeTCP(local *peer.Local, listener *net.TCPListener, log *zap.SugaredLogger, opts ...Option) *TCP {
 	t := &TCP{
 		local:            local,
 		listener:         listener,
 		log:              log,
+		packetTypes:      gp.SupportedPacketTypes,
 		addAcceptMatcher: make(chan *acceptMatcher),
 		acceptReceived:   make(chan accept),
 		closing:          make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(t)
+	}
 
 	t.log.Debugw("server started",
 		"network", listener.Addr().Network(),
@@ -120,13 +163,14 @@ func (t *TCP) LocalAddr() net.Addr {
 
 // DialPeer establishes a gossip connection to the given peer.
 // If the peer does not accept the connection or the handshake fails, an error is returned.
-func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
+func (t *TCP) DialPeer(p *peer.Peer) (*Connection, error) {
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
 	}
 
 	var conn net.Conn
+	var packetTypes []gp.PacketType
 	if err := backoff.Retry(dialRetryPolicy, func() error {
 		var err error
 		address := net.JoinHostPort(p.IP().String(), strconv.Itoa(gossipEndpoint.Port()))
@@ -135,8 +179,14 @@ func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
 			return fmt.Errorf("dial %s / %s failed: %w", address, p.ID(), err)
 		}
 
-		if err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
-			return fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
+		if packetTypes, err = t.doHandshake(p.PublicKey(), address, conn); err != nil {
+			t.closeConnection(conn)
+			err = fmt.Errorf("handshake %s / %s failed: %w", address, p.ID(), err)
+			// there is no point in retrying with an incompatible peer
+			if errors.Is(err, ErrIncompatibleVersion) || errors.Is(err, ErrIncompatibleNetwork) {
+				return backoff.Permanent(err)
+			}
+			return err
 		}
 		return nil
 	}); err != nil {
@@ -147,12 +197,12 @@ func (t *TCP) DialPeer(p *peer.Peer) (net.Conn, error) {
 		"id", p.ID(),
 		"addr", conn.RemoteAddr(),
 	)
-	return conn, nil
+	return &Connection{Conn: conn, packetTypes: packetTypes}, nil
 }
 
 // AcceptPeer awaits an incoming connection from the given peer.
 // If the peer does not establish the connection or the handshake fails, an error is returned.
-func (t *TCP) AcceptPeer(p *peer.Peer) (net.Conn, error) {
+func (t *TCP) AcceptPeer(p *peer.Peer) (*Connection, error) {
 	gossipEndpoint := p.Services().Get(service.GossipKey)
 	if gossipEndpoint == nil {
 		return nil, ErrNoGossip
@@ -226,11 +276,11 @@ func (t *TCP) run() {
 					matched = true
 					matcherList.Remove(e)
 					// finish the handshake
-					go t.matchAccept(m, a.req, a.conn)
+					go t.matchAccept(m, a)
 				}
 			}
 			// close the connection if not matched
-			if !matched {
+			if !matched && a.err == nil {
 				t.log.Debugw("unexpected connection", "id", a.fromID, "addr", a.conn.RemoteAddr())
 				t.closeConnection(a.conn)
 			}
@@ -260,16 +310,21 @@ func (t *TCP) run() {
 	}
 }
 
-func (t *TCP) matchAccept(m *acceptMatcher, req []byte, conn net.Conn) {
+func (t *TCP) matchAccept(m *acceptMatcher, a accept) {
 	t.wg.Add(1)
 	defer t.wg.Done()
 
-	if err := t.writeHandshakeResponse(req, conn); err != nil {
+	// the connection of a rejected request has already been closed
+	if a.err != nil {
+		m.connected <- connect{nil, fmt.Errorf("incoming handshake failed: %w", a.err)}
+		return
+	}
+	if err := t.writeHandshakeResponse(a.req, a.conn); err != nil {
 		m.connected <- connect{nil, fmt.Errorf("incoming handshake failed: %w", err)}
-		t.closeConnection(conn)
+		t.closeConnection(a.conn)
 		return
 	}
-	m.connected <- connect{conn, nil}
+	m.connected <- connect{&Connection{Conn: a.conn, packetTypes: a.packetTypes}, nil}
 }
 
 func (t *TCP) listenLoop() {
@@ -290,8 +345,13 @@ func (t *TCP) listenLoop() {
 			return
 		}
 
-		key, req, err := t.readHandshakeRequest(conn)
-		if err != nil {
+		key, req, packetTypes, err := t.readHandshakeRequest(conn)
+		if errors.Is(err, ErrIncompatibleVersion) || errors.Is(err, ErrIncompatibleNetwork) {
+			// answer incompatible peers, so that they learn about the reason of the rejection
+			t.log.Infow("incompatible peer", "id", identity.NewID(key), "addr", conn.RemoteAddr(), "err", err)
+			_ = t.writeHandshakeResponse(req, conn)
+			t.closeConnection(conn)
+		} else if err != nil {
 			t.log.Warnw("failed handshake", "addr", conn.RemoteAddr(), "err", err)
 			t.closeConnection(conn)
 			continue
@@ -299,21 +359,25 @@ func (t *TCP) listenLoop() {
 
 		select {
 		case t.acceptReceived <- accept{
-			fromID: identity.NewID(key),
-			req:    req,
-			conn:   conn,
+			fromID:      identity.NewID(key),
+			req:         req,
+			packetTypes: packetTypes,
+			err:         err,
+			conn:        conn,
 		}:
 		case <-t.closing:
-			t.closeConnection(conn)
+			if err == nil {
+				t.closeConnection(conn)
+			}
 			return
 		}
 	}
 }
 
-func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) error {
-	reqData, err := newHandshakeRequest(remoteAddr)
+func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Conn) ([]gp.PacketType, error) {
+	reqData, err := t.newHandshakeRequest(remoteAddr)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt := &pb.Packet{
@@ -323,78 +387,81 @@ func (t *TCP) doHandshake(key ed25519.PublicKey, remoteAddr string, conn net.Con
 	}
 	b, err := proto.Marshal(pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if l := len(b); l > maxHandshakePacketSize {
-		return fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
+		return nil, fmt.Errorf("handshake size too large: %d, max %d", l, maxHandshakePacketSize)
 	}
 
 	err = conn.SetWriteDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	_, err = conn.Write(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = conn.SetReadDeadline(time.Now().Add(handshakeTimeout))
 	if err != nil {
-		return err
+		return nil, err
 	}
 	b = make([]byte, maxHandshakePacketSize)
 	n, err := conn.Read(b)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	pkt = &pb.Packet{}
 	err = proto.Unmarshal(b[:n], pkt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	signer, err := peer.RecoverKeyFromSignedData(pkt)
 	if err != nil || !bytes.Equal(key.Bytes(), signer.Bytes()) {
-		return ErrInvalidHandshake
+		return nil, ErrInvalidHandshake
 	}
-	if !t.validateHandshakeResponse(pkt.GetData(), reqData) {
-		return ErrInvalidHandshake
+	res, err := t.validateHandshakeResponse(pkt.GetData(), reqData)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return unmarshalPacketTypes(res.GetPacketTypes()), nil
 }
 
-func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, error) {
+func (t *TCP) readHandshakeRequest(conn net.Conn) (ed25519.PublicKey, []byte, []gp.PacketType, error) {
 	if err := conn.SetReadDeadline(time.Now().Add(handshakeTimeout)); err != nil {
-		return ed25519.PublicKey{}, nil, err
+		return ed25519.PublicKey{}, nil, nil, err
 	}
 	b := make([]byte, maxHandshakePacketSize)
 	n, err := conn.Read(b)
 	if err != nil {
-		return ed25519.PublicKey{}, nil, fmt.Errorf("%w: %s", ErrInvalidHandshake, err.Error())
+		return ed25519.PublicKey{}, nil, nil, fmt.Errorf("%w: %s", ErrInvalidHandshake, err.Error())
 	}
 
 	pkt := &pb.Packet{}
 	err = proto.Unmarshal(b[:n], pkt)
 	if err != nil {
-		return ed25519.PublicKey{}, nil, err
+		return ed25519.PublicKey{}, nil, nil, err
 	}
 
 	key, err := peer.RecoverKeyFromSignedData(pkt)
 	if err != nil {
-		return ed25519.PublicKey{}, nil, err
+		return ed25519.PublicKey{}, nil, nil, err
 	}
 
-	if !t.validateHandshakeRequest(pkt.GetData()) {
-		return ed25519.PublicKey{}, nil, ErrInvalidHandshake
+	// the key and the request are also returned for incompatible peers, so that they can be answered
+	req, err := t.validateHandshakeRequest(pkt.GetData())
+	if err != nil {
+		return key, pkt.GetData(), nil, err
 	}
 
-	return key, pkt.GetData(), nil
+	return key, pkt.GetData(), unmarshalPacketTypes(req.GetPacketTypes()), nil
 }
 
 func (t *TCP) writeHandshakeResponse(reqData []byte, conn net.Conn) error {
-	data, err := newHandshakeResponse(reqData)
+	data, err := t.newHandshakeResponse(reqData)
 	if err != nil {
 		return err
 	}
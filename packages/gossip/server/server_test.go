@@ -1,11 +1,13 @@
 package server
 
 import (
+	"errors"
 	"net"
 	"sync"
 	"testing"
 	"time"
 
+	gp "github.com/iotaledger/goshimmer/packages/gossip/proto"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/autopeering/peer/service"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
@@ -139,6 +141,61 @@ func TestConnect(t *testing.T) {
 	wg.Wait()
 }
 
+func TestConnectPacketTypes(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", PacketTypes(gp.PacketMessage))
+	defer closeA()
+	transB, closeB := newTestServer(t, "B")
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		c, err := transA.AcceptPeer(getPeer(transB))
+		if assert.NoError(t, err) {
+			assert.Equal(t, gp.SupportedPacketTypes, c.PacketTypes())
+			_ = c.Close()
+		}
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		c, err := transB.DialPeer(getPeer(transA))
+		if assert.NoError(t, err) {
+			assert.Equal(t, []gp.PacketType{gp.PacketMessage}, c.PacketTypes())
+			_ = c.Close()
+		}
+	}()
+
+	wg.Wait()
+}
+
+func TestIncompatibleNetwork(t *testing.T) {
+	transA, closeA := newTestServer(t, "A", NetworkID(1))
+	defer closeA()
+	transB, closeB := newTestServer(t, "B", NetworkID(2))
+	defer closeB()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	// both sides report the reason of the rejection
+	go func() {
+		defer wg.Done()
+		_, err := transA.AcceptPeer(getPeer(transB))
+		assert.True(t, errors.Is(err, ErrIncompatibleNetwork), "unexpected error: %v", err)
+	}()
+	time.Sleep(graceTime)
+	go func() {
+		defer wg.Done()
+		_, err := transB.DialPeer(getPeer(transA))
+		assert.True(t, errors.Is(err, ErrIncompatibleNetwork), "unexpected error: %v", err)
+	}()
+
+	wg.Wait()
+}
+
 func TestWrongConnect(t *testing.T) {
 	transA, closeA := newTestServer(t, "A")
 	defer closeA()
@@ -171,7 +228,7 @@ func newTestDB(t require.TestingT) *peer.DB {
 	return db
 }
 
-func newTestServer(t require.TestingT, name string) (*TCP, func()) {
+func newTestServer(t require.TestingT, name string, opts ...Option) (*TCP, func()) {
 	l := log.Named(name)
 
 	laddr, err := net.ResolveTCPAddr("tcp", "127.0.0.1:0")
@@ -186,7 +243,7 @@ func newTestServer(t require.TestingT, name string) (*TCP, func()) {
 	local, err := peer.NewLocal(lis.Addr().(*net.TCPAddr).IP, services, newTestDB(t))
 	require.NoError(t, err)
 
-	srv := ServeTCP(local, lis, l)
+	srv := ServeTCP(local, lis, l, opts...)
 
 	teardown := func() {
 		srv.Close()
@@ -0,0 +1,95 @@
+package pow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+// AdaptiveDifficulty determines the proof-of-work difficulty of an issuer based on the number of messages it issued
+// recently. Every rateStep messages issued within the window before a message add one bit to the base difficulty,
+// so that honest low-rate issuers barely pay anything while spammers get throttled.
+// Messages are identified by their issuer and issuing time, so that every message is only counted once, no matter how
+// often it is received.
+type AdaptiveDifficulty struct {
+	baseDifficulty int
+	window         time.Duration
+	rateStep       int
+	issuances      map[ed25519.PublicKey]map[int64]struct{}
+	lastCleanup    time.Time
+	mutex          sync.Mutex
+}
+
+// NewAdaptiveDifficulty creates a new adaptive difficulty.
+func NewAdaptiveDifficulty(baseDifficulty int, window time.Duration, rateStep int) *AdaptiveDifficulty {
+	if rateStep < 1 {
+		rateStep = 1
+	}
+
+	return &AdaptiveDifficulty{
+		baseDifficulty: baseDifficulty,
+		window:         window,
+		rateStep:       rateStep,
+		issuances:      make(map[ed25519.PublicKey]map[int64]struct{}),
+		lastCleanup:    time.Now(),
+	}
+}
+
+// Difficulty returns the difficulty a message of the given issuer issued at the given time needs to satisfy.
+func (d *AdaptiveDifficulty) Difficulty(issuer ed25519.PublicKey, issuingTime time.Time) int {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	recent := 0
+	windowStart, windowEnd := issuingTime.Add(-d.window).UnixNano(), issuingTime.UnixNano()
+	for t := range d.issuances[issuer] {
+		if t < windowEnd && t >= windowStart {
+			recent++
+		}
+	}
+
+	return d.baseDifficulty + recent/d.rateStep
+}
+
+// Record records a message of the given issuer issued at the given time. Messages that were recorded already and
+// messages that were issued before the window are ignored, as they do not count for the difficulty of new messages.
+// It returns true if the message was recorded.
+func (d *AdaptiveDifficulty) Record(issuer ed25519.PublicKey, issuingTime time.Time) (recorded bool) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	now := time.Now()
+	windowStart := now.Add(-d.window).UnixNano()
+	if issuingTime.UnixNano() < windowStart {
+		return false
+	}
+
+	issuances, exists := d.issuances[issuer]
+	if !exists {
+		issuances = make(map[int64]struct{})
+		d.issuances[issuer] = issuances
+	}
+	if _, exists := issuances[issuingTime.UnixNano()]; !exists {
+		issuances[issuingTime.UnixNano()] = struct{}{}
+		recorded = true
+	}
+
+	// remove the issuances and issuers that are outside of the window
+	if now.Sub(d.lastCleanup) < d.window {
+		return
+	}
+	for key, issuances := range d.issuances {
+		for t := range issuances {
+			if t < windowStart {
+				delete(issuances, t)
+			}
+		}
+		if len(issuances) == 0 {
+			delete(d.issuances, key)
+		}
+	}
+	d.lastCleanup = now
+
+	return
+}
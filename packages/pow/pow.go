@@ -0,0 +1,97 @@
+package pow
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// NonceSize is the size of the nonce in bytes.
+const NonceSize = 8
+
+// checkCancelInterval is the number of hashes after which a miner checks whether it got canceled.
+const checkCancelInterval = 1024
+
+var (
+	// ErrCanceled is returned when the proof-of-work got canceled before a nonce was found.
+	ErrCanceled = errors.New("proof-of-work canceled")
+	// ErrInvalidData is returned when the data is too short to contain a nonce.
+	ErrInvalidData = errors.New("data does not contain a nonce")
+)
+
+// Worker performs the proof-of-work by searching for a nonce, so that the hash of the data followed by the nonce
+// starts with a given number of zero bits.
+type Worker struct {
+	numWorkers int
+}
+
+// New creates a new proof-of-work worker using the given number of goroutines to search for a nonce.
+func New(numWorkers int) *Worker {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+
+	return &Worker{numWorkers: numWorkers}
+}
+
+// Mine searches for a nonce so that the hash of the data followed by the nonce has at least the given number of
+// leading zero bits.
+func (w *Worker) Mine(ctx context.Context, data []byte, difficulty int) (uint64, error) {
+	var (
+		done   uint32
+		result uint64
+		wg     sync.WaitGroup
+	)
+
+	for i := 0; i < w.numWorkers; i++ {
+		wg.Add(1)
+		go func(startNonce uint64) {
+			defer wg.Done()
+
+			buf := make([]byte, len(data)+NonceSize)
+			copy(buf, data)
+			for nonce := startNonce; atomic.LoadUint32(&done) == 0; nonce += uint64(w.numWorkers) {
+				if nonce%checkCancelInterval == startNonce%checkCancelInterval && ctx.Err() != nil {
+					return
+				}
+
+				binary.LittleEndian.PutUint64(buf[len(data):], nonce)
+				if leadingZeros(blake2b.Sum256(buf)) >= difficulty && atomic.CompareAndSwapUint32(&done, 0, 1) {
+					result = nonce
+					return
+				}
+			}
+		}(uint64(i))
+	}
+	wg.Wait()
+
+	if atomic.LoadUint32(&done) == 0 {
+		return 0, ErrCanceled
+	}
+	return result, nil
+}
+
+// LeadingZeros returns the number of leading zero bits of the hash of the given data, whose last bytes are the nonce.
+func (w *Worker) LeadingZeros(data []byte) (int, error) {
+	if len(data) < NonceSize {
+		return 0, ErrInvalidData
+	}
+
+	return leadingZeros(blake2b.Sum256(data)), nil
+}
+
+func leadingZeros(hash [blake2b.Size256]byte) (result int) {
+	for _, b := range hash {
+		if b != 0 {
+			return result + bits.LeadingZeros8(b)
+		}
+		result += 8
+	}
+
+	return
+}
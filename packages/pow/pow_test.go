@@ -0,0 +1,67 @@
+package pow
+
+import (
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDifficulty = 12
+
+func TestWorker_Mine(t *testing.T) {
+	worker := New(4)
+	data := []byte("some data")
+
+	nonce, err := worker.Mine(context.Background(), data, testDifficulty)
+	require.NoError(t, err)
+
+	buf := make([]byte, len(data)+NonceSize)
+	copy(buf, data)
+	binary.LittleEndian.PutUint64(buf[len(data):], nonce)
+	zeros, err := worker.LeadingZeros(buf)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, zeros, testDifficulty)
+
+	_, err = worker.LeadingZeros([]byte("short"))
+	assert.Equal(t, ErrInvalidData, err)
+}
+
+func TestWorker_Cancel(t *testing.T) {
+	worker := New(2)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := worker.Mine(ctx, []byte("some data"), 256)
+	assert.Equal(t, ErrCanceled, err)
+}
+
+func TestAdaptiveDifficulty(t *testing.T) {
+	difficulty := NewAdaptiveDifficulty(testDifficulty, time.Minute, 2)
+	issuer := ed25519.GenerateKeyPair().PublicKey
+	other := ed25519.GenerateKeyPair().PublicKey
+	now := time.Now()
+
+	assert.Equal(t, testDifficulty, difficulty.Difficulty(issuer, now))
+
+	for i := 0; i < 4; i++ {
+		difficulty.Record(issuer, now.Add(time.Duration(i)*time.Second))
+	}
+	// only the messages issued within the window before a message count
+	assert.Equal(t, testDifficulty, difficulty.Difficulty(issuer, now))
+	assert.Equal(t, testDifficulty+1, difficulty.Difficulty(issuer, now.Add(2*time.Second)))
+	assert.Equal(t, testDifficulty+2, difficulty.Difficulty(issuer, now.Add(time.Minute)))
+	assert.Equal(t, testDifficulty, difficulty.Difficulty(issuer, now.Add(2*time.Minute)))
+
+	// the difficulty is tracked per issuer
+	assert.Equal(t, testDifficulty, difficulty.Difficulty(other, now.Add(time.Minute)))
+
+	// messages that are received again or that were issued before the window are not counted
+	assert.False(t, difficulty.Record(issuer, now.Add(3*time.Second)))
+	assert.False(t, difficulty.Record(issuer, now.Add(-2*time.Minute)))
+	assert.Equal(t, testDifficulty+2, difficulty.Difficulty(issuer, now.Add(time.Minute)))
+}
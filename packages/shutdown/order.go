@@ -5,9 +5,11 @@ const (
 	PriorityFPC
 	PriorityTangle
 	PriorityMissingMessagesMonitoring
+	PriorityPruning
 	PriorityRemoteLog
 	PriorityAnalysis
 	PriorityMetrics
+	PriorityPrometheus
 	PriorityAutopeering
 	PriorityGossip
 	PriorityWebAPI
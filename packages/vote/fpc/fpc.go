@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -181,6 +182,45 @@ func (f *FPC) finalizeOpinions() {
 	}
 }
 
+// samples QuerySampleSize opinion givers (with replacement) and returns how many times each of them was selected.
+// if a weight provider is set, the opinion givers are selected with a probability proportional to their weight.
+func (f *FPC) sampleOpinionGivers(opinionGivers []vote.OpinionGiver) map[vote.OpinionGiver]int {
+	selectedOpinionGivers := map[vote.OpinionGiver]int{}
+
+	// compute the cumulative weights to select opinion givers via binary search
+	var cumulativeWeights []float64
+	if f.paras.OpinionGiverWeightProvider != nil {
+		cumulativeWeights = make([]float64, len(opinionGivers))
+		var totalWeight float64
+		for i, opinionGiver := range opinionGivers {
+			if weight := f.paras.OpinionGiverWeightProvider.Weight(opinionGiver); weight > 0 {
+				totalWeight += weight
+			}
+			cumulativeWeights[i] = totalWeight
+		}
+
+		// fall back to uniform sampling if nobody has any weight
+		if totalWeight == 0 {
+			cumulativeWeights = nil
+		}
+	}
+
+	for i := 0; i < f.paras.QuerySampleSize; i++ {
+		if cumulativeWeights == nil {
+			selectedOpinionGivers[opinionGivers[f.opinionGiverRng.Intn(len(opinionGivers))]]++
+			continue
+		}
+
+		target := f.opinionGiverRng.Float64() * cumulativeWeights[len(cumulativeWeights)-1]
+		selected := sort.Search(len(cumulativeWeights), func(i int) bool {
+			return cumulativeWeights[i] > target
+		})
+		selectedOpinionGivers[opinionGivers[selected]]++
+	}
+
+	return selectedOpinionGivers
+}
+
 // queries the opinions of QuerySampleSize amount of OpinionGivers.
 func (f *FPC) queryOpinions() ([]vote.QueriedOpinions, error) {
 	ids := f.voteContextIDs()
@@ -203,11 +243,7 @@ func (f *FPC) queryOpinions() ([]vote.QueriedOpinions, error) {
 	// select a random subset of opinion givers to query.
 	// if the same opinion giver is selected multiple times, we query it only once
 	// but use its opinion N selected times.
-	opinionGiversToQuery := map[vote.OpinionGiver]int{}
-	for i := 0; i < f.paras.QuerySampleSize; i++ {
-		selected := opinionGivers[f.opinionGiverRng.Intn(len(opinionGivers))]
-		opinionGiversToQuery[selected]++
-	}
+	opinionGiversToQuery := f.sampleOpinionGivers(opinionGivers)
 
 	// votes per id
 	var voteMapMu sync.Mutex
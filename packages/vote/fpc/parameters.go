@@ -1,6 +1,10 @@
 package fpc
 
-import "time"
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
 
 // Parameters define the parameters of an FPC instance.
 type Parameters struct {
@@ -22,6 +26,9 @@ type Parameters struct {
 	MaxRoundsPerVoteContext int
 	// The max amount of time a query is allowed to take.
 	QueryTimeout time.Duration
+	// The provider of the weights which are used to sample the opinion givers to query.
+	// If nil, all opinion givers are sampled with the same probability.
+	OpinionGiverWeightProvider vote.WeightProvider
 }
 
 // DefaultParameters returns the default parameters used in FPC.
@@ -0,0 +1,148 @@
+package fpc_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/packages/vote/fpc"
+)
+
+// weightedopiniongiver is an opinion giver with a fixed weight that always replies with the same opinion.
+type weightedopiniongiver struct {
+	id      string
+	weight  float64
+	opinion vote.Opinion
+	queried int
+}
+
+func (wog *weightedopiniongiver) ID() string {
+	return wog.id
+}
+
+func (wog *weightedopiniongiver) Query(_ context.Context, ids []string) (vote.Opinions, error) {
+	wog.queried++
+	opinions := make(vote.Opinions, len(ids))
+	for i := range opinions {
+		opinions[i] = wog.opinion
+	}
+	return opinions, nil
+}
+
+var opinionGiverWeight = vote.WeightProviderFunc(func(opinionGiver vote.OpinionGiver) float64 {
+	return opinionGiver.(*weightedopiniongiver).weight
+})
+
+func TestFPCWeightedSamplingIgnoresZeroWeight(t *testing.T) {
+	withWeight := &weightedopiniongiver{id: "withWeight", weight: 1, opinion: vote.Like}
+	withoutWeight := &weightedopiniongiver{id: "withoutWeight", weight: 0, opinion: vote.Dislike}
+	opinionGiverFunc := func() (givers []vote.OpinionGiver, err error) {
+		return []vote.OpinionGiver{withoutWeight, withWeight, withoutWeight}, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.OpinionGiverWeightProvider = opinionGiverWeight
+	voter := fpc.New(opinionGiverFunc, paras)
+	assert.NoError(t, voter.Vote("a", vote.Like))
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, voter.Round(0.5))
+	}
+
+	assert.Equal(t, 5, withWeight.queried)
+	assert.Equal(t, 0, withoutWeight.queried)
+}
+
+func TestFPCWeightedSamplingFallsBackToUniform(t *testing.T) {
+	opinionGiver := &weightedopiniongiver{id: "withoutWeight", weight: 0, opinion: vote.Like}
+	opinionGiverFunc := func() (givers []vote.OpinionGiver, err error) {
+		return []vote.OpinionGiver{opinionGiver}, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.OpinionGiverWeightProvider = opinionGiverWeight
+	voter := fpc.New(opinionGiverFunc, paras)
+	assert.NoError(t, voter.Vote("a", vote.Like))
+
+	assert.NoError(t, voter.Round(0.5))
+	assert.NoError(t, voter.Round(0.5))
+	assert.Equal(t, 2, opinionGiver.queried)
+}
+
+// simulateSybilAttack lets an honest voter, that initially likes a set of transactions, vote against a network in
+// which the honest opinion givers hold most of the weight while the adversary controls many cheap identities that
+// always dislike the transactions. It returns the share of the vote contexts that finalized with the honest opinion.
+func simulateSybilAttack(t *testing.T, weightProvider vote.WeightProvider, honestCount int, adversaryCount int) float64 {
+	opinionGivers := make([]vote.OpinionGiver, 0, honestCount+adversaryCount)
+	for i := 0; i < honestCount; i++ {
+		opinionGivers = append(opinionGivers, &weightedopiniongiver{id: fmt.Sprintf("honest%d", i), weight: 20, opinion: vote.Like})
+	}
+	for i := 0; i < adversaryCount; i++ {
+		opinionGivers = append(opinionGivers, &weightedopiniongiver{id: fmt.Sprintf("adversary%d", i), weight: 1, opinion: vote.Dislike})
+	}
+	opinionGiverFunc := func() (givers []vote.OpinionGiver, err error) {
+		return opinionGivers, nil
+	}
+
+	paras := fpc.DefaultParameters()
+	paras.OpinionGiverWeightProvider = weightProvider
+	voter := fpc.New(opinionGiverFunc, paras)
+
+	const voteContextCount = 20
+	finalOpinions := make(map[string]vote.Opinion)
+	voter.Events().Finalized.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		finalOpinions[id] = opinion
+	}))
+	voter.Events().Failed.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		finalOpinions[id] = vote.Unknown
+	}))
+	for i := 0; i < voteContextCount; i++ {
+		require.NoError(t, voter.Vote(fmt.Sprintf("transaction%d", i), vote.Like))
+	}
+
+	rng := rand.New(rand.NewSource(0))
+	for round := 0; len(finalOpinions) < voteContextCount && round <= paras.MaxRoundsPerVoteContext; round++ {
+		require.NoError(t, voter.Round(rng.Float64()))
+	}
+
+	var honestlyFinalized int
+	for _, opinion := range finalOpinions {
+		if opinion == vote.Like {
+			honestlyFinalized++
+		}
+	}
+
+	return float64(honestlyFinalized) / voteContextCount
+}
+
+func TestFPCWeightedSamplingSybilResistance(t *testing.T) {
+	type testInput struct {
+		honestCount    int
+		adversaryCount int
+	}
+	var tests = []testInput{
+		{20, 5},
+		{20, 20},
+		{20, 40},
+		{20, 80},
+	}
+
+	for _, test := range tests {
+		uniform := simulateSybilAttack(t, nil, test.honestCount, test.adversaryCount)
+		weighted := simulateSybilAttack(t, opinionGiverWeight, test.honestCount, test.adversaryCount)
+		t.Logf("%d honest vs %d adversarial opinion givers: uniform %.2f, weighted %.2f honestly finalized", test.honestCount, test.adversaryCount, uniform, weighted)
+
+		// the adversary can not outweigh the honest opinion givers, no matter how many identities it creates
+		assert.Equal(t, 1.0, weighted)
+		assert.GreaterOrEqual(t, weighted, uniform)
+	}
+
+	// once the adversary controls the majority of the identities, uniform sampling follows the adversary
+	assert.Equal(t, 0.0, simulateSybilAttack(t, nil, 20, 80))
+}
@@ -28,6 +28,21 @@ type QueriedOpinions struct {
 // OpinionGiverFunc is a function which gives a slice of OpinionGivers or an error.
 type OpinionGiverFunc func() ([]OpinionGiver, error)
 
+// WeightProvider provides the weights of OpinionGivers, which determine the probability of an OpinionGiver to be
+// selected for a query.
+type WeightProvider interface {
+	// Weight returns the weight of the given OpinionGiver. OpinionGivers with a weight of 0 are never selected.
+	Weight(opinionGiver OpinionGiver) float64
+}
+
+// WeightProviderFunc is a function which implements the WeightProvider interface.
+type WeightProviderFunc func(opinionGiver OpinionGiver) float64
+
+// Weight returns the weight of the given OpinionGiver by calling the function itself.
+func (f WeightProviderFunc) Weight(opinionGiver OpinionGiver) float64 {
+	return f(opinionGiver)
+}
+
 // Opinions is a slice of Opinion.
 type Opinions []Opinion
 
@@ -40,6 +55,17 @@ const (
 	Unknown Opinion = 1 << 2
 )
 
+// String returns a human readable version of the Opinion.
+func (o Opinion) String() string {
+	switch o {
+	case Like:
+		return "Like"
+	case Dislike:
+		return "Dislike"
+	}
+	return "Unknown"
+}
+
 // ConvertInt32Opinion converts the given int32 to an Opinion.
 func ConvertInt32Opinion(x int32) Opinion {
 	switch {
@@ -0,0 +1,10 @@
+package dapps
+
+import (
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/hive.go/node"
+)
+
+var PLUGINS = node.Plugins(
+	valuetransfers.App,
+)
@@ -4,6 +4,7 @@ import (
 	analysisclient "github.com/iotaledger/goshimmer/plugins/analysis/client"
 	analysisserver "github.com/iotaledger/goshimmer/plugins/analysis/server"
 	analysiswebinterface "github.com/iotaledger/goshimmer/plugins/analysis/webinterface"
+	"github.com/iotaledger/goshimmer/plugins/prometheus"
 	"github.com/iotaledger/goshimmer/plugins/remotelog"
 	"github.com/iotaledger/hive.go/node"
 )
@@ -13,4 +14,5 @@ var PLUGINS = node.Plugins(
 	analysisserver.Plugin,
 	analysisclient.Plugin,
 	analysiswebinterface.Plugin,
+	prometheus.Plugin,
 )
@@ -5,8 +5,12 @@ import (
 	"net/http"
 	"sync"
 
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 )
 
@@ -46,8 +50,26 @@ func createExplorerMessage(msg *message.Message) (*ExplorerMessage, error) {
 
 // ExplorerAddress defines the struct of the ExplorerAddress.
 type ExplorerAddress struct {
-	// Messagess hold the list of *ExplorerMessage.
-	Messages []*ExplorerMessage `json:"message"`
+	// Address is the base58 encoded address.
+	Address string `json:"address"`
+	// History holds the latest transactions that spent from or created outputs on the address.
+	History []*ExplorerAddressTransaction `json:"history"`
+}
+
+// ExplorerAddressTransaction defines the struct of a transaction in the history of an address.
+type ExplorerAddressTransaction struct {
+	// ID is the transaction ID.
+	ID string `json:"id"`
+	// Timestamp is the time when the transaction was booked.
+	Timestamp int64 `json:"timestamp"`
+	// Spent defines whether the transaction spent outputs of the address.
+	Spent bool `json:"spent"`
+	// Created defines whether the transaction created outputs on the address.
+	Created bool `json:"created"`
+	// Liked defines whether the transaction is booked into a liked branch.
+	Liked bool `json:"liked"`
+	// Finalized defines whether the opinion about the transaction is final.
+	Finalized bool `json:"finalized"`
 }
 
 // SearchResult defines the struct of the SearchResult.
@@ -58,6 +80,9 @@ type SearchResult struct {
 	Address *ExplorerAddress `json:"address"`
 }
 
+// the maximum number of transactions shown in the history of an address.
+const maxAddressHistorySize = 100
+
 func setupExplorerRoutes(routeGroup *echo.Group) {
 	routeGroup.GET("/message/:id", func(c echo.Context) (err error) {
 		messageID, err := message.NewId(c.Param("id"))
@@ -85,10 +110,6 @@ func setupExplorerRoutes(routeGroup *echo.Group) {
 		search := c.Param("search")
 		result := &SearchResult{}
 
-		if len(search) < 81 {
-			return fmt.Errorf("%w: search ID %s", ErrInvalidParameter, search)
-		}
-
 		wg := sync.WaitGroup{}
 		wg.Add(2)
 		go func() {
@@ -128,8 +149,47 @@ func findMessage(messageID message.Id) (explorerMsg *ExplorerMessage, err error)
 	return
 }
 
-func findAddress(address string) (*ExplorerAddress, error) {
-	return nil, fmt.Errorf("%w: address %s", ErrNotFound, address)
+func findAddress(base58Address string) (*ExplorerAddress, error) {
+	addr, err := address.FromBase58(base58Address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: address %s", ErrInvalidParameter, base58Address)
+	}
+
+	// the address history is only known to nodes that run the value transfers dApp
+	if node.IsSkipped(valuetransfers.App) {
+		return nil, fmt.Errorf("%w: address %s", ErrNotFound, base58Address)
+	}
+
+	// only the latest entries of the history are loaded
+	offset := valuetransfers.LedgerState.AddressHistorySize(addr) - maxAddressHistorySize
+	if offset < 0 {
+		offset = 0
+	}
+	history, total := valuetransfers.LedgerState.AddressHistory(addr, offset, maxAddressHistorySize)
+	if total == 0 {
+		return nil, fmt.Errorf("%w: address %s", ErrNotFound, base58Address)
+	}
+
+	explorerAddress := &ExplorerAddress{
+		Address: addr.String(),
+		History: make([]*ExplorerAddressTransaction, len(history)),
+	}
+	for i, addressTransaction := range history {
+		explorerAddress.History[i] = &ExplorerAddressTransaction{
+			ID:        addressTransaction.TransactionID().String(),
+			Timestamp: addressTransaction.Timestamp().Unix(),
+			Spent:     addressTransaction.Spent(),
+			Created:   addressTransaction.Created(),
+			Liked:     true,
+			Finalized: true,
+		}
+
+		// the transactions of the snapshot have no metadata and are always liked and finalized
+		valuetransfers.Tangle.TransactionMetadata(addressTransaction.TransactionID()).Consume(func(transactionMetadata *tangle.TransactionMetadata) {
+			explorerAddress.History[i].Liked = valuetransfers.Tangle.BranchManager().IsBranchLiked(transactionMetadata.BranchID())
+			explorerAddress.History[i].Finalized = transactionMetadata.Finalized()
+		})
+	}
 
-	// TODO: ADD ADDRESS LOOKUPS ONCE THE VALUE TRANSFER ONTOLOGY IS MERGED
+	return explorerAddress, nil
 }
@@ -7,7 +7,7 @@ import {inject, observer} from "mobx-react";
 import ExplorerStore from "app/stores/ExplorerStore";
 import Spinner from "react-bootstrap/Spinner";
 import ListGroup from "react-bootstrap/ListGroup";
-import {Link} from 'react-router-dom';
+import Badge from "react-bootstrap/Badge";
 import * as dateformat from 'dateformat';
 import Alert from "react-bootstrap/Alert";
 
@@ -41,15 +41,19 @@ export class ExplorerAddressQueryResult extends React.Component<Props, any> {
     render() {
         let {id} = this.props.match.params;
         let {addr, query_loading} = this.props.explorerStore;
-        let msgsEle = [];
+        let txsEle = [];
         if (addr) {
-            for (let i = 0; i < addr.messages.length; i++) {
-                let msg = addr.messages[i];
-                msgsEle.push(
-                    <ListGroup.Item key={msg.id}>
+            for (let i = 0; i < addr.history.length; i++) {
+                let tx = addr.history[i];
+                txsEle.push(
+                    <ListGroup.Item key={tx.id}>
                         <small>
-                            {dateformat(new Date(msg.timestamp * 1000), "dd.mm.yyyy HH:MM:ss")} {' '}
-                            <Link to={`/explorer/message/${msg.id}`}>{msg.id}</Link>
+                            {dateformat(new Date(tx.timestamp * 1000), "dd.mm.yyyy HH:MM:ss")} {' '}
+                            {tx.id} {' '}
+                            {tx.spent && <Badge variant={"danger"}>Spent</Badge>} {' '}
+                            {tx.created && <Badge variant={"success"}>Created</Badge>} {' '}
+                            {!tx.liked && <Badge variant={"secondary"}>Disliked</Badge>} {' '}
+                            {!tx.finalized && <Badge variant={"warning"}>Pending</Badge>}
                         </small>
                     </ListGroup.Item>
                 );
@@ -57,7 +61,7 @@ export class ExplorerAddressQueryResult extends React.Component<Props, any> {
         }
         return (
             <Container>
-                <h3>Address {addr !== null && <span>({addr.messages.length} Messages)</span>}</h3>
+                <h3>Address {addr !== null && <span>({addr.history.length} Transactions)</span>}</h3>
                 <p>
                     {id} {' '}
                 </p>
@@ -65,15 +69,15 @@ export class ExplorerAddressQueryResult extends React.Component<Props, any> {
                     addr !== null ?
                         <React.Fragment>
                             {
-                                addr.messages !== null && addr.messages.length === 100 &&
+                                addr.history !== null && addr.history.length === 100 &&
                                 <Alert variant={"warning"}>
-                                    Max. 100 messages are shown.
+                                    Max. 100 transactions are shown.
                                 </Alert>
                             }
                             <Row className={"mb-3"}>
                                 <Col>
                                     <ListGroup variant={"flush"}>
-                                        {msgsEle}
+                                        {txsEle}
                                     </ListGroup>
                                 </Col>
                             </Row>
@@ -16,9 +16,18 @@ export class Message {
     payload: any;
 }
 
+export class AddressTransaction {
+    id: string;
+    timestamp: number;
+    spent: boolean;
+    created: boolean;
+    liked: boolean;
+    finalized: boolean;
+}
+
 class AddressResult {
-    balance: number;
-    messages: Array<Message>;
+    address: string;
+    history: Array<AddressTransaction>;
 }
 
 class SearchResult {
@@ -142,7 +151,7 @@ export class ExplorerStore {
 
     @action
     updateAddress = (addr: AddressResult) => {
-        addr.messages = addr.messages.sort((a, b) => {
+        addr.history = addr.history.sort((a, b) => {
             return a.timestamp < b.timestamp ? 1 : -1;
         });
         this.addr = addr;
@@ -9,9 +9,15 @@ const (
 	CfgDatabaseDir = "database.directory"
 	// CfgDatabaseInMemory defines whether to use an in-memory database.
 	CfgDatabaseInMemory = "database.inMemory"
+	// CfgDatabasePruningWindowSec defines the age in seconds after which finalized objects are pruned (0 disables pruning).
+	CfgDatabasePruningWindowSec = "database.pruning.windowSec"
+	// CfgDatabasePruningIntervalSec defines the interval in seconds in which the pruning is executed.
+	CfgDatabasePruningIntervalSec = "database.pruning.intervalSec"
 )
 
 func init() {
 	flag.String(CfgDatabaseDir, "mainnetdb", "path to the database folder")
 	flag.Bool(CfgDatabaseInMemory, false, "whether the database is only kept in memory and not persisted")
+	flag.Int(CfgDatabasePruningWindowSec, 0, "the age in seconds after which finalized messages and value objects are pruned (0 disables pruning)")
+	flag.Int(CfgDatabasePruningIntervalSec, 600, "the interval in seconds in which old messages and value objects are pruned")
 }
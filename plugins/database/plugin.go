@@ -36,6 +36,12 @@ func Store() kvstore.KVStore {
 	return store
 }
 
+// DB returns the DB instance that backs the KVStore.
+func DB() database.DB {
+	storeOnce.Do(createStore)
+	return db
+}
+
 // StoreRealm is a factory method for a different realm backed by the KVStore instance.
 func StoreRealm(realm kvstore.Realm) kvstore.KVStore {
 	return Store().WithRealm(realm)
@@ -18,6 +18,9 @@ import (
 	"github.com/iotaledger/hive.go/netutil"
 )
 
+// maxTips is the maximum number of tips sent to a neighbor requesting our tips.
+const maxTips = 16
+
 var (
 	mgr     *gossip.Manager
 	mgrOnce sync.Once
@@ -43,7 +46,7 @@ func createManager() {
 	if err := lPeer.UpdateService(service.GossipKey, "tcp", gossipPort); err != nil {
 		log.Fatalf("could not update services: %s", err)
 	}
-	mgr = gossip.NewManager(lPeer, loadMessage, log)
+	mgr = gossip.NewManager(lPeer, loadMessage, loadTips, log)
 }
 
 func start(shutdownSignal <-chan struct{}) {
@@ -67,7 +70,7 @@ func start(shutdownSignal <-chan struct{}) {
 	}
 	defer listener.Close()
 
-	srv := server.ServeTCP(lPeer, listener, log)
+	srv := server.ServeTCP(lPeer, listener, log, server.NetworkID(autopeering.NetworkID))
 	defer srv.Close()
 
 	mgr.Start(srv)
@@ -95,3 +98,8 @@ func loadMessage(messageID message.Id) (bytes []byte, err error) {
 	}
 	return
 }
+
+// loads the ids of the most recent tips of the message layer.
+func loadTips() []message.Id {
+	return messagelayer.TipSelector.RecentTips(maxTips)
+}
@@ -0,0 +1,267 @@
+package gossip
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
+	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/timeutil"
+)
+
+const (
+	manualNeighborCheckInterval = time.Second
+	manualNeighborMinBackoff    = time.Second
+	manualNeighborMaxBackoff    = 2 * time.Minute
+
+	// the pause between two accept attempts is kept short, as the neighbor can only connect while we are accepting
+	manualNeighborMaxAcceptBackoff = 10 * time.Second
+)
+
+var (
+	// ErrParsingManualNeighbor is returned for an invalid manual neighbor definition.
+	ErrParsingManualNeighbor = errors.New("cannot parse manual neighbor")
+	// ErrManualNeighborExists is returned when a manual neighbor is added twice.
+	ErrManualNeighborExists = errors.New("manual neighbor already exists")
+	// ErrUnknownManualNeighbor is returned when an unknown manual neighbor is removed.
+	ErrUnknownManualNeighbor = errors.New("unknown manual neighbor")
+)
+
+var manualNeighbors = &manualNeighborSet{
+	neighbors: make(map[identity.ID]*manualNeighbor),
+}
+
+// manualNeighbor contains the connection state of a statically configured neighbor.
+type manualNeighbor struct {
+	peer        *peer.Peer
+	connected   bool
+	connecting  bool
+	backoff     time.Duration
+	nextAttempt time.Time
+}
+
+type manualNeighborSet struct {
+	neighbors map[identity.ID]*manualNeighbor
+	mutex     sync.Mutex
+}
+
+// ParseManualNeighbor parses a manual neighbor given as base64 public key and gossip address in the form publicKey@host:port.
+func ParseManualNeighbor(definition string) (*peer.Peer, error) {
+	parts := strings.Split(definition, "@")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: parts must be 2, is %d", ErrParsingManualNeighbor, len(parts))
+	}
+	publicKey, err := ParsePublicKey(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	addr, err := net.ResolveTCPAddr("tcp", parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("%w: host cannot be resolved: %s", ErrParsingManualNeighbor, err)
+	}
+
+	// manual neighbors are not discovered through the autopeering, but a peer always needs a peering service
+	services := service.New()
+	services.Update(service.PeeringKey, "udp", 0)
+	services.Update(service.GossipKey, addr.Network(), addr.Port)
+
+	return peer.NewPeer(identity.New(publicKey), addr.IP, services), nil
+}
+
+// ParsePublicKey parses a base64 encoded public key.
+func ParsePublicKey(encodedPublicKey string) (publicKey ed25519.PublicKey, err error) {
+	pubKeyBytes, err := base64.StdEncoding.DecodeString(encodedPublicKey)
+	if err != nil {
+		return publicKey, fmt.Errorf("%w: invalid public key: %s", ErrParsingManualNeighbor, err)
+	}
+	if publicKey, _, err = ed25519.PublicKeyFromBytes(pubKeyBytes); err != nil {
+		return publicKey, fmt.Errorf("%w: invalid public key: %s", ErrParsingManualNeighbor, err)
+	}
+	return publicKey, nil
+}
+
+// AddManualNeighbor adds a neighbor that is always kept connected and never evicted by the autopeering.
+// The peer must list this node as a manual neighbor as well.
+func AddManualNeighbor(p *peer.Peer) error {
+	manualNeighbors.mutex.Lock()
+	defer manualNeighbors.mutex.Unlock()
+
+	if _, exists := manualNeighbors.neighbors[p.ID()]; exists {
+		return ErrManualNeighborExists
+	}
+	manualNeighbors.neighbors[p.ID()] = &manualNeighbor{
+		peer:    p,
+		backoff: manualNeighborMinBackoff,
+	}
+
+	return nil
+}
+
+// RemoveManualNeighbor removes the manual neighbor with the given ID and drops its connection.
+func RemoveManualNeighbor(id identity.ID) error {
+	manualNeighbors.mutex.Lock()
+	if _, exists := manualNeighbors.neighbors[id]; !exists {
+		manualNeighbors.mutex.Unlock()
+		return ErrUnknownManualNeighbor
+	}
+	delete(manualNeighbors.neighbors, id)
+	manualNeighbors.mutex.Unlock()
+
+	if err := Manager().DropNeighbor(id); err != nil && !errors.Is(err, gossip.ErrNotANeighbor) {
+		return err
+	}
+
+	return nil
+}
+
+// ManualNeighbors returns all manual neighbors together with their connection state.
+func ManualNeighbors() map[*peer.Peer]bool {
+	manualNeighbors.mutex.Lock()
+	defer manualNeighbors.mutex.Unlock()
+
+	result := make(map[*peer.Peer]bool, len(manualNeighbors.neighbors))
+	for _, neighbor := range manualNeighbors.neighbors {
+		result[neighbor.peer] = neighbor.connected
+	}
+
+	return result
+}
+
+// IsManualNeighbor returns true if the peer with the given ID is a manual neighbor.
+func IsManualNeighbor(id identity.ID) bool {
+	manualNeighbors.mutex.Lock()
+	defer manualNeighbors.mutex.Unlock()
+
+	_, exists := manualNeighbors.neighbors[id]
+	return exists
+}
+
+func configureManualNeighbors() {
+	for _, definition := range config.Node.GetStringSlice(CfgGossipManualNeighbors) {
+		if definition == "" {
+			continue
+		}
+
+		p, err := ParseManualNeighbor(definition)
+		if err != nil {
+			log.Fatalf("Invalid manual neighbor (%s): %s", CfgGossipManualNeighbors, err)
+		}
+		if err := AddManualNeighbor(p); err != nil {
+			log.Warnf("Ignoring manual neighbor %s: %s", definition, err)
+		}
+	}
+
+	// keep track of the connection state of the manual neighbors, even if they got connected by the autopeering
+	mgr := Manager()
+	mgr.Events().NeighborAdded.Attach(events.NewClosure(func(n *gossip.Neighbor) {
+		manualNeighbors.mutex.Lock()
+		defer manualNeighbors.mutex.Unlock()
+
+		if neighbor, exists := manualNeighbors.neighbors[n.ID()]; exists {
+			neighbor.connected = true
+			neighbor.backoff = manualNeighborMinBackoff
+		}
+	}))
+	mgr.Events().NeighborRemoved.Attach(events.NewClosure(func(p *peer.Peer) {
+		manualNeighbors.mutex.Lock()
+		defer manualNeighbors.mutex.Unlock()
+
+		if neighbor, exists := manualNeighbors.neighbors[p.ID()]; exists {
+			log.Infof("Manual neighbor %s / %s disconnected, reconnecting in %s", gossip.GetAddress(p), p.ID(), neighbor.backoff)
+			neighbor.connected = false
+			neighbor.nextAttempt = time.Now().Add(neighbor.backoff)
+		}
+	}))
+}
+
+func maintainManualNeighbors(shutdownSignal <-chan struct{}) {
+	timeutil.Ticker(connectManualNeighbors, manualNeighborCheckInterval, shutdownSignal)
+}
+
+// connectManualNeighbors starts a connection attempt for every disconnected manual neighbor whose backoff expired.
+func connectManualNeighbors() {
+	manualNeighbors.mutex.Lock()
+	defer manualNeighbors.mutex.Unlock()
+
+	now := time.Now()
+	for _, neighbor := range manualNeighbors.neighbors {
+		if neighbor.connected || neighbor.connecting || now.Before(neighbor.nextAttempt) {
+			continue
+		}
+
+		neighbor.connecting = true
+		go connectManualNeighbor(neighbor)
+	}
+}
+
+// connectManualNeighbor connects to the given manual neighbor. To avoid both sides dialing each other, the peer with
+// the smaller ID dials while the other one keeps waiting for the incoming connection.
+func connectManualNeighbor(neighbor *manualNeighbor) {
+	mgr := Manager()
+	dial := bytes.Compare(local.GetInstance().ID().Bytes(), neighbor.peer.ID().Bytes()) < 0
+
+	var err error
+	if dial {
+		err = mgr.AddOutbound(neighbor.peer)
+	} else {
+		// an accepting node keeps waiting for the incoming connection until the neighbor is removed
+		err = acceptWithRetry(func() error {
+			return mgr.AddInbound(neighbor.peer)
+		}, func() bool {
+			return IsManualNeighbor(neighbor.peer.ID())
+		})
+	}
+
+	manualNeighbors.mutex.Lock()
+	defer manualNeighbors.mutex.Unlock()
+
+	neighbor.connecting = false
+	if errors.Is(err, gossip.ErrDuplicateNeighbor) {
+		// the peer is already connected, e.g. through the autopeering
+		neighbor.connected = true
+		return
+	}
+	if err == nil {
+		if _, exists := manualNeighbors.neighbors[neighbor.peer.ID()]; !exists {
+			// the neighbor was removed while connecting
+			go func() { _ = mgr.DropNeighbor(neighbor.peer.ID()) }()
+		}
+		return
+	}
+
+	log.Debugw("error connecting manual neighbor", "id", neighbor.peer.ID(), "err", err, "retry", neighbor.backoff)
+	neighbor.nextAttempt = time.Now().Add(neighbor.backoff)
+	if neighbor.backoff *= 2; neighbor.backoff > manualNeighborMaxBackoff {
+		neighbor.backoff = manualNeighborMaxBackoff
+	}
+}
+
+// acceptWithRetry calls accept until the incoming connection is established. Only accept timeouts are retried, with an
+// exponentially increasing pause in between, as long as keepWaiting returns true. All other errors are returned, so
+// that e.g. a loopback or a closed server do not cause an endless loop.
+func acceptWithRetry(accept func() error, keepWaiting func() bool) (err error) {
+	backoff := manualNeighborMinBackoff
+	for {
+		if err = accept(); !errors.Is(err, server.ErrTimeout) || !keepWaiting() {
+			return
+		}
+
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > manualNeighborMaxAcceptBackoff {
+			backoff = manualNeighborMaxAcceptBackoff
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gossip
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
+)
+
+func TestParseManualNeighbor(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey()
+	require.NoError(t, err)
+	encodedPublicKey := base64.StdEncoding.EncodeToString(publicKey.Bytes())
+
+	p, err := ParseManualNeighbor(encodedPublicKey + "@127.0.0.1:14666")
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, p.PublicKey())
+	assert.Equal(t, "127.0.0.1", p.IP().String())
+	require.NotNil(t, p.Services().Get(service.GossipKey))
+	assert.Equal(t, 14666, p.Services().Get(service.GossipKey).Port())
+	assert.NotNil(t, p.Services().Get(service.PeeringKey))
+
+	for _, definition := range []string{
+		"",
+		"127.0.0.1:14666",
+		encodedPublicKey + "@127.0.0.1:14666@other",
+		"invalid@127.0.0.1:14666",
+		encodedPublicKey + "@127.0.0.1:invalid",
+	} {
+		_, err := ParseManualNeighbor(definition)
+		assert.Truef(t, errors.Is(err, ErrParsingManualNeighbor), "definition %q: %v", definition, err)
+	}
+}
+
+func TestAcceptWithRetry(t *testing.T) {
+	keepWaiting := func() bool { return true }
+	acceptTimeout := fmt.Errorf("accept failed: %w", server.ErrTimeout)
+
+	// errors other than accept timeouts are returned immediately
+	for _, acceptErr := range []error{
+		gossip.ErrLoopback,
+		gossip.ErrNotRunning,
+		gossip.ErrDuplicateNeighbor,
+		fmt.Errorf("accept failed: %w", server.ErrClosed),
+	} {
+		calls := 0
+		err := acceptWithRetry(func() error {
+			calls++
+			return acceptErr
+		}, keepWaiting)
+		assert.Equal(t, acceptErr, err)
+		assert.Equal(t, 1, calls)
+	}
+
+	// accept timeouts are retried until the connection is established
+	calls := 0
+	err := acceptWithRetry(func() error {
+		if calls++; calls == 1 {
+			return acceptTimeout
+		}
+		return nil
+	}, keepWaiting)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	// the retries stop once the neighbor is no longer expected
+	calls = 0
+	err = acceptWithRetry(func() error {
+		calls++
+		return acceptTimeout
+	}, func() bool { return false })
+	assert.True(t, errors.Is(err, server.ErrTimeout))
+	assert.Equal(t, 1, calls)
+}
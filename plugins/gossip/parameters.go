@@ -7,8 +7,20 @@ import (
 const (
 	// CfgGossipPort defines the config flag of the gossip port.
 	CfgGossipPort = "gossip.port"
+	// CfgGossipManualNeighbors defines the config flag of the manual neighbors.
+	CfgGossipManualNeighbors = "gossip.manualNeighbors"
+	// CfgGossipReputationThreshold defines the config flag of the score below which a neighbor gets dropped.
+	CfgGossipReputationThreshold = "gossip.reputation.threshold"
+	// CfgGossipReputationHalfLife defines the config flag of the half-life of the neighbor scores in seconds.
+	CfgGossipReputationHalfLife = "gossip.reputation.halfLife"
+	// CfgGossipReputationBlacklistDuration defines the config flag of the blacklist duration of dropped neighbors in seconds.
+	CfgGossipReputationBlacklistDuration = "gossip.reputation.blacklistDuration"
 )
 
 func init() {
 	flag.Int(CfgGossipPort, 14666, "tcp port for gossip connection")
+	flag.StringSlice(CfgGossipManualNeighbors, nil, "list of neighbors (publicKey@host:port) that are always kept connected; the node with the smaller ID dials, so the neighbors must list each other")
+	flag.Float64(CfgGossipReputationThreshold, -50, "score below which a neighbor gets dropped and blacklisted")
+	flag.Int(CfgGossipReputationHalfLife, 600, "time in seconds after which a neighbor score decayed to half of its value")
+	flag.Int(CfgGossipReputationBlacklistDuration, 1800, "time in seconds a dropped neighbor stays blacklisted")
 }
@@ -1,9 +1,14 @@
 package gossip
 
 import (
+	"bytes"
+	"errors"
+	"sort"
+
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
 	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/gossip/server"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering"
 	"github.com/iotaledger/goshimmer/plugins/messagelayer"
@@ -11,6 +16,7 @@ import (
 	"github.com/iotaledger/hive.go/autopeering/selection"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
 )
@@ -30,6 +36,8 @@ func configure(*node.Plugin) {
 
 	configureLogging()
 	configureMessageLayer()
+	configureReputation()
+	configureManualNeighbors()
 	configureAutopeering()
 }
 
@@ -37,6 +45,9 @@ func run(*node.Plugin) {
 	if err := daemon.BackgroundWorker(PluginName, start, shutdown.PriorityGossip); err != nil {
 		log.Errorf("Failed to start as daemon: %s", err)
 	}
+	if err := daemon.BackgroundWorker(PluginName+"[ManualNeighbors]", maintainManualNeighbors, shutdown.PriorityGossip); err != nil {
+		log.Errorf("Failed to start as daemon: %s", err)
+	}
 }
 
 func configureAutopeering() {
@@ -46,6 +57,10 @@ func configureAutopeering() {
 	// link to the autopeering events
 	peerSel := autopeering.Selection()
 	peerSel.Events().Dropped.Attach(events.NewClosure(func(ev *selection.DroppedEvent) {
+		// manual neighbors are never evicted by the autopeering
+		if IsManualNeighbor(ev.DroppedID) {
+			return
+		}
 		go func() {
 			if err := mgr.DropNeighbor(ev.DroppedID); err != nil {
 				log.Debugw("error dropping neighbor", "id", ev.DroppedID, "err", err)
@@ -57,6 +72,11 @@ func configureAutopeering() {
 			return // ignore rejected peering
 		}
 		go func() {
+			// refuse peering with blacklisted neighbors and free the slot for another peer
+			if Reputation().IsBlacklisted(ev.Peer.ID()) {
+				peerSel.RemoveNeighbor(ev.Peer.ID())
+				return
+			}
 			if err := mgr.AddInbound(ev.Peer); err != nil {
 				log.Debugw("error adding inbound", "id", ev.Peer.ID(), "err", err)
 			}
@@ -67,6 +87,11 @@ func configureAutopeering() {
 			return // ignore rejected peering
 		}
 		go func() {
+			// refuse peering with blacklisted neighbors and free the slot for another peer
+			if Reputation().IsBlacklisted(ev.Peer.ID()) {
+				peerSel.RemoveNeighbor(ev.Peer.ID())
+				return
+			}
 			if err := mgr.AddOutbound(ev.Peer); err != nil {
 				log.Debugw("error adding outbound", "id", ev.Peer.ID(), "err", err)
 			}
@@ -88,6 +113,15 @@ func configureLogging() {
 
 	// log the gossip events
 	mgr.Events().ConnectionFailed.Attach(events.NewClosure(func(p *peer.Peer, err error) {
+		if errors.Is(err, server.ErrIncompatibleVersion) || errors.Is(err, server.ErrIncompatibleNetwork) {
+			log.Warnf("Rejected incompatible neighbor %s / %s: %s", gossip.GetAddress(p), p.ID(), err)
+			return
+		}
+		// manual neighbors are retried continuously
+		if IsManualNeighbor(p.ID()) {
+			log.Debugf("Connection to manual neighbor %s / %s failed: %s", gossip.GetAddress(p), p.ID(), err)
+			return
+		}
 		log.Infof("Connection to neighbor %s / %s failed: %s", gossip.GetAddress(p), p.ID(), err)
 	}))
 	mgr.Events().NeighborAdded.Attach(events.NewClosure(func(n *gossip.Neighbor) {
@@ -115,8 +149,31 @@ func configureMessageLayer() {
 		})
 	}))
 
-	// request missing messages
-	messagelayer.MessageRequester.Events.SendRequest.Attach(events.NewClosure(func(messageId message.Id) {
-		mgr.RequestMessage(messageId[:])
+	// request missing messages from a single neighbor, rotating through the neighbors on every retry
+	messagelayer.MessageRequester.Events.SendRequest.Attach(events.NewClosure(func(messageId message.Id, retryCount int) {
+		neighbors := mgr.AllNeighbors()
+		if len(neighbors) == 0 {
+			return
+		}
+		neighborIDs := make([]identity.ID, len(neighbors))
+		for i, neighbor := range neighbors {
+			neighborIDs[i] = neighbor.ID()
+		}
+
+		neighborID := requestTarget(neighborIDs, messageId, retryCount)
+		Reputation().RequestSent(neighborID, messageId[:])
+		mgr.RequestMessage(messageId[:], neighborID)
 	}))
 }
+
+// requestTarget selects the neighbor that the given retry of a message request is sent to. The neighbors are rotated
+// on every retry, starting at a message dependent offset to spread the requests of different messages.
+func requestTarget(neighborIDs []identity.ID, messageId message.Id, retryCount int) identity.ID {
+	sortedIDs := make([]identity.ID, len(neighborIDs))
+	copy(sortedIDs, neighborIDs)
+	sort.Slice(sortedIDs, func(i, j int) bool {
+		return bytes.Compare(sortedIDs[i].Bytes(), sortedIDs[j].Bytes()) < 0
+	})
+
+	return sortedIDs[(int(messageId[0])+retryCount)%len(sortedIDs)]
+}
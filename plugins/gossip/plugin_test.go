@@ -0,0 +1,26 @@
+package gossip
+
+import (
+	"testing"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+)
+
+func TestRequestTarget(t *testing.T) {
+	neighborIDs := []identity.ID{{3}, {1}, {2}}
+	messageID := message.Id{1}
+
+	// the retries rotate through all neighbors, independent of their order
+	var targets []identity.ID
+	for retryCount := 0; retryCount < 4; retryCount++ {
+		targets = append(targets, requestTarget(neighborIDs, messageID, retryCount))
+	}
+	assert.Equal(t, []identity.ID{{2}, {3}, {1}, {2}}, targets)
+	assert.Equal(t, []identity.ID{{3}, {1}, {2}}, neighborIDs)
+
+	// requests of different messages start at different neighbors
+	assert.Equal(t, identity.ID{3}, requestTarget(neighborIDs, message.Id{2}, 0))
+}
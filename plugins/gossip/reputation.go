@@ -0,0 +1,109 @@
+package gossip
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messageparser/builtinfilters"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	"github.com/iotaledger/goshimmer/packages/gossip/reputation"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+)
+
+var (
+	reputationManager     *reputation.Manager
+	reputationManagerOnce sync.Once
+)
+
+// Reputation returns the reputation manager keeping track of the scores of the neighbors.
+func Reputation() *reputation.Manager {
+	reputationManagerOnce.Do(createReputationManager)
+	return reputationManager
+}
+
+func createReputationManager() {
+	reputationManager = reputation.New(
+		reputation.Threshold(config.Node.GetFloat64(CfgGossipReputationThreshold)),
+		reputation.HalfLife(time.Duration(config.Node.GetInt(CfgGossipReputationHalfLife))*time.Second),
+		reputation.BlacklistDuration(time.Duration(config.Node.GetInt(CfgGossipReputationBlacklistDuration))*time.Second),
+	)
+}
+
+func configureReputation() {
+	// assure that the Manager is instantiated
+	mgr := Manager()
+	rep := Reputation()
+
+	// only the current neighbors are scored
+	mgr.Events().NeighborAdded.Attach(events.NewClosure(func(n *gossip.Neighbor) {
+		rep.AddNeighbor(n.ID())
+	}))
+	mgr.Events().NeighborRemoved.Attach(events.NewClosure(func(p *peer.Peer) {
+		rep.RemoveNeighbor(p.ID())
+	}))
+
+	// score the messages received from the neighbors
+	messagelayer.MessageParser.Events.MessageParsed.Attach(events.NewClosure(func(_ *message.Message, p *peer.Peer) {
+		if p != nil {
+			rep.RecordUseful(p.ID())
+		}
+	}))
+	messagelayer.MessageParser.Events.BytesRejected.Attach(events.NewClosure(func(_ []byte, err error, p *peer.Peer) {
+		if p == nil {
+			return
+		}
+		if errors.Is(err, builtinfilters.ErrReceivedDuplicateBytes) {
+			rep.RecordDuplicate(p.ID())
+			return
+		}
+		rep.RecordInvalid(p.ID())
+	}))
+	messagelayer.MessageParser.Events.MessageRejected.Attach(events.NewClosure(func(_ *message.Message, err error, p *peer.Peer) {
+		if p != nil && provablyInvalid(err) {
+			rep.RecordInvalid(p.ID())
+		}
+	}))
+
+	// score the answers to message requests
+	messagelayer.Tangle.Events.MissingMessageReceived.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessageMetadata.Release()
+		cachedMessage.Consume(func(msg *message.Message) {
+			messageID := msg.Id()
+			rep.RequestCompleted(messageID[:])
+		})
+	}))
+	messagelayer.Tangle.Events.MessageUnsolidifiable.Attach(events.NewClosure(func(messageID message.Id) {
+		rep.RequestCompleted(messageID[:])
+	}))
+	messagelayer.MessageRequester.Events.RequestFailed.Attach(events.NewClosure(func(messageID message.Id) {
+		rep.RequestFailed(messageID[:])
+	}))
+
+	// drop misbehaving neighbors
+	rep.Events.NeighborBlacklisted.Attach(events.NewClosure(func(id identity.ID) {
+		if IsManualNeighbor(id) {
+			log.Warnf("Manual neighbor %s has a low reputation", id)
+			return
+		}
+		log.Infof("Neighbor %s blacklisted due to low reputation", id)
+		go func() {
+			if err := mgr.DropNeighbor(id); err != nil {
+				log.Debugw("error dropping neighbor", "id", id, "err", err)
+			}
+		}()
+	}))
+}
+
+// provablyInvalid returns true if the given rejection of a parsed message proves that the neighbor sent invalid data.
+// Rejections that depend on the local view of the node (e.g. its clock or the adaptive PoW difficulty) can also be
+// caused by honest neighbors and are therefore not penalized.
+func provablyInvalid(err error) bool {
+	return errors.Is(err, builtinfilters.ErrInvalidSignature)
+}
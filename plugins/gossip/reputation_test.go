@@ -0,0 +1,21 @@
+package gossip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messageparser/builtinfilters"
+)
+
+func TestProvablyInvalid(t *testing.T) {
+	assert.True(t, provablyInvalid(builtinfilters.ErrInvalidSignature))
+	assert.True(t, provablyInvalid(fmt.Errorf("%w: wrapped", builtinfilters.ErrInvalidSignature)))
+
+	// rejections that depend on the local view of the node must not be penalized
+	assert.False(t, provablyInvalid(fmt.Errorf("%w: issued at now", builtinfilters.ErrTimestampTooNew)))
+	assert.False(t, provablyInvalid(fmt.Errorf("%w: issued at now", builtinfilters.ErrTimestampTooOld)))
+	assert.False(t, provablyInvalid(fmt.Errorf("%w: issued at now", builtinfilters.ErrTimestampBelowParent)))
+	assert.False(t, provablyInvalid(fmt.Errorf("%w: 3 leading zeros, difficulty 4", builtinfilters.ErrInvalidPOWDifficulty)))
+}
@@ -1,6 +1,7 @@
 package issuer
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
@@ -16,15 +17,25 @@ const PluginName = "Issuer"
 var (
 	// Plugin is the plugin instance of the issuer plugin.
 	Plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+
+	// ErrMessageNotIssued is returned when the message factory failed to create the message.
+	ErrMessageNotIssued = errors.New("message could not be issued")
 )
 
 func configure(_ *node.Plugin) {}
 
 // IssuePayload issues a payload to the message layer.
-// If the node is not synchronized an error is returned.
-func IssuePayload(payload payload.Payload) (*message.Message, error) {
+// If the node is not synchronized or the payload exceeds the maximum size of its type an error is returned.
+func IssuePayload(p payload.Payload) (*message.Message, error) {
 	if !sync.Synced() {
 		return nil, fmt.Errorf("can't issue payload: %w", sync.ErrNodeNotSynchronized)
 	}
-	return messagelayer.MessageFactory.IssuePayload(payload), nil
+	if err := payload.CheckSize(p.Type(), len(p.Bytes())); err != nil {
+		return nil, fmt.Errorf("can't issue payload: %w", err)
+	}
+	msg := messagelayer.MessageFactory.IssuePayload(p)
+	if msg == nil {
+		return nil, ErrMessageNotIssued
+	}
+	return msg, nil
 }
@@ -0,0 +1,41 @@
+package messagelayer
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgTimestampPastWindowSec defines the maximum age in seconds of received messages.
+	CfgTimestampPastWindowSec = "messageLayer.timestamp.pastWindowSec"
+	// CfgTimestampFutureWindowSec defines the maximum time in seconds received messages may be issued ahead of the local time.
+	CfgTimestampFutureWindowSec = "messageLayer.timestamp.futureWindowSec"
+	// CfgTimestampParkingDurationSec defines the time in seconds messages with unknown parents are parked.
+	CfgTimestampParkingDurationSec = "messageLayer.timestamp.parkingDurationSec"
+	// CfgTimestampMaxParkedMessages defines the maximum number of parked messages.
+	CfgTimestampMaxParkedMessages = "messageLayer.timestamp.maxParkedMessages"
+	// CfgPowDifficulty defines the base PoW difficulty of messages in leading zero bits.
+	CfgPowDifficulty = "messageLayer.pow.difficulty"
+	// CfgPowWindowSec defines the time window in seconds in which the messages of an issuer raise its PoW difficulty.
+	CfgPowWindowSec = "messageLayer.pow.windowSec"
+	// CfgPowRateStep defines the number of messages within the window after which the PoW difficulty rises by one bit.
+	CfgPowRateStep = "messageLayer.pow.rateStep"
+	// CfgTipSelectionExpirySec defines the age in seconds after which tips are removed (0 keeps them forever).
+	CfgTipSelectionExpirySec = "messageLayer.tipSelection.expirySec"
+	// CfgTipSelectionStrategy defines the strategy used to select tips.
+	CfgTipSelectionStrategy = "messageLayer.tipSelection.strategy"
+	// CfgTipSelectionAgeHalfLifeSec defines the time in seconds after which the weight of a tip halves in the age weighted tip selection.
+	CfgTipSelectionAgeHalfLifeSec = "messageLayer.tipSelection.ageHalfLifeSec"
+)
+
+func init() {
+	flag.Int(CfgTimestampPastWindowSec, 1800, "the maximum age in seconds of received messages (requested messages are exempt)")
+	flag.Int(CfgTimestampFutureWindowSec, 60, "the maximum time in seconds received messages may be issued ahead of the local time")
+	flag.Int(CfgTimestampParkingDurationSec, 60, "the time in seconds messages with unknown parents are parked before they get rejected (0 disables the parking)")
+	flag.Int(CfgTimestampMaxParkedMessages, 10000, "the maximum number of parked messages")
+	flag.Int(CfgPowDifficulty, 10, "the base PoW difficulty of messages in leading zero bits")
+	flag.Int(CfgPowWindowSec, 10, "the time window in seconds in which the messages of an issuer raise its PoW difficulty")
+	flag.Int(CfgPowRateStep, 10, "the number of messages of an issuer within the window after which its PoW difficulty rises by one bit")
+	flag.Int(CfgTipSelectionExpirySec, 600, "the age in seconds after which tips are removed (0 keeps them forever)")
+	flag.String(CfgTipSelectionStrategy, TipSelectionUniform, "the strategy used to select tips (uniform, age or approvalWeight)")
+	flag.Int(CfgTipSelectionAgeHalfLifeSec, 60, "the time in seconds after which the weight of a tip halves in the age weighted tip selection")
+}
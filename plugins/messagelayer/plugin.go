@@ -1,20 +1,28 @@
 package messagelayer
 
 import (
+	"context"
+	"runtime"
+	"time"
+
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messagefactory"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messageparser"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messageparser/builtinfilters"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messagerequester"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tipselector"
+	"github.com/iotaledger/goshimmer/packages/pow"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
+	"github.com/iotaledger/goshimmer/plugins/config"
 	"github.com/iotaledger/goshimmer/plugins/database"
 	"github.com/iotaledger/hive.go/autopeering/peer"
 	"github.com/iotaledger/hive.go/daemon"
 	"github.com/iotaledger/hive.go/events"
 	"github.com/iotaledger/hive.go/logger"
 	"github.com/iotaledger/hive.go/node"
+	"github.com/iotaledger/hive.go/timeutil"
 )
 
 const (
@@ -31,6 +39,9 @@ var (
 	Tangle           *tangle.Tangle
 	MessageFactory   *messagefactory.MessageFactory
 	log              *logger.Logger
+
+	// the PoW of issued messages is canceled on shutdown
+	powCtx, powCancel = context.WithCancel(context.Background())
 )
 
 func configure(*node.Plugin) {
@@ -40,11 +51,46 @@ func configure(*node.Plugin) {
 	// create instances
 	MessageParser = messageparser.New()
 	MessageRequester = messagerequester.New()
-	TipSelector = tipselector.New()
+	TipSelector = createTipSelector()
 	Tangle = tangle.New(store)
 
+	timestampFilter := builtinfilters.NewTimestampFilter(parentTimestamp,
+		builtinfilters.TimestampWindow(
+			time.Duration(config.Node.GetInt(CfgTimestampPastWindowSec))*time.Second,
+			time.Duration(config.Node.GetInt(CfgTimestampFutureWindowSec))*time.Second,
+		),
+		builtinfilters.TimestampParkingDuration(time.Duration(config.Node.GetInt(CfgTimestampParkingDurationSec))*time.Second),
+		builtinfilters.TimestampMaxParkedMessages(config.Node.GetInt(CfgTimestampMaxParkedMessages)),
+		builtinfilters.TimestampRequestedMessages(MessageRequester.IsRequested),
+		builtinfilters.TimestampRequestParents(Tangle.MarkMessageMissing),
+	)
+	MessageParser.AddMessageFilter(timestampFilter)
+	Tangle.Events.MessageAttached.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessageMetadata.Release()
+		cachedMessage.Consume(func(msg *message.Message) {
+			timestampFilter.MessageStored(msg.Id())
+		})
+	}))
+
+	// the same difficulty is used for received and issued messages, so that the own messages raise the difficulty too
+	powWorker := pow.New(runtime.NumCPU())
+	powDifficulty := pow.NewAdaptiveDifficulty(
+		config.Node.GetInt(CfgPowDifficulty),
+		time.Duration(config.Node.GetInt(CfgPowWindowSec))*time.Second,
+		config.Node.GetInt(CfgPowRateStep),
+	)
+	MessageParser.AddMessageFilter(builtinfilters.NewPowFilter(powWorker, powDifficulty))
+
 	// Setup MessageFactory (behavior + logging))
 	MessageFactory = messagefactory.New(database.Store(), local.GetInstance().LocalIdentity(), TipSelector, []byte(DBSequenceNumber))
+	issuerPublicKey := local.GetInstance().PublicKey()
+	MessageFactory.SetWorker(messagefactory.WorkerFunc(func(issuingTime time.Time, msgBytes []byte) (uint64, error) {
+		nonce, err := powWorker.Mine(powCtx, msgBytes, powDifficulty.Difficulty(issuerPublicKey, issuingTime))
+		if err == nil {
+			powDifficulty.Record(issuerPublicKey, issuingTime)
+		}
+		return nonce, err
+	}))
 	MessageFactory.Events.MessageConstructed.Attach(events.NewClosure(Tangle.AttachMessage))
 	MessageFactory.Events.Error.Attach(events.NewClosure(func(err error) {
 		log.Errorf("internal error in message factory: %v", err)
@@ -52,7 +98,6 @@ func configure(*node.Plugin) {
 
 	// setup MessageParser
 	MessageParser.Events.MessageParsed.Attach(events.NewClosure(func(msg *message.Message, peer *peer.Peer) {
-		// TODO: ADD PEER
 		Tangle.AttachMessage(msg)
 	}))
 
@@ -64,6 +109,10 @@ func configure(*node.Plugin) {
 			MessageRequester.StopRequest(msg.Id())
 		})
 	}))
+	Tangle.Events.MessageUnsolidifiable.Attach(events.NewClosure(MessageRequester.StopRequest))
+	MessageRequester.Events.RequestFailed.Attach(events.NewClosure(func(messageId message.Id) {
+		log.Debugf("gave up requesting missing message %s", messageId)
+	}))
 
 	// setup TipSelector
 	Tangle.Events.MessageSolid.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
@@ -72,6 +121,15 @@ func configure(*node.Plugin) {
 	}))
 }
 
+// parentTimestamp returns the issuing time of the given message if it is stored in the tangle.
+func parentTimestamp(messageId message.Id) (issuingTime time.Time, exists bool) {
+	Tangle.Message(messageId).Consume(func(msg *message.Message) {
+		issuingTime = msg.IssuingTime()
+		exists = true
+	})
+	return
+}
+
 func run(*node.Plugin) {
 	_ = daemon.BackgroundWorker("Tangle[MissingMessagesMonitor]", func(shutdownSignal <-chan struct{}) {
 		Tangle.MonitorMissingMessages(shutdownSignal)
@@ -79,9 +137,27 @@ func run(*node.Plugin) {
 
 	_ = daemon.BackgroundWorker("Tangle", func(shutdownSignal <-chan struct{}) {
 		<-shutdownSignal
+		powCancel()
 		MessageFactory.Shutdown()
 		MessageParser.Shutdown()
 		Tangle.Shutdown()
 	}, shutdown.PriorityTangle)
 
+	_ = daemon.BackgroundWorker("TipSelector[Expiry]", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(func() {
+			if removedTips := TipSelector.RemoveExpiredTips(); removedTips > 0 {
+				log.Debugf("removed %d expired tips", removedTips)
+			}
+		}, tipExpiryCheckInterval, shutdownSignal)
+	}, shutdown.PriorityTangle)
+
+	if pruningWindow := time.Duration(config.Node.GetInt(database.CfgDatabasePruningWindowSec)) * time.Second; pruningWindow > 0 {
+		_ = daemon.BackgroundWorker("Tangle[Pruning]", func(shutdownSignal <-chan struct{}) {
+			timeutil.Ticker(func() {
+				if prunedMessages := Tangle.PruneOlderThan(time.Now().Add(-pruningWindow), messageFinalized); prunedMessages > 0 {
+					log.Infof("pruned %d messages", prunedMessages)
+				}
+			}, time.Duration(config.Node.GetInt(database.CfgDatabasePruningIntervalSec))*time.Second, shutdownSignal)
+		}, shutdown.PriorityPruning)
+	}
 }
@@ -0,0 +1,31 @@
+package messagelayer
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+)
+
+var (
+	finalityCheck      func(msg *message.Message) bool
+	finalityCheckMutex sync.RWMutex
+)
+
+// SetFinalityCheck sets the function that decides if a message is finalized and can be pruned (i.e. the transaction of
+// its value payload is final). As long as it is not set, all solid messages count as finalized.
+func SetFinalityCheck(finalized func(msg *message.Message) bool) {
+	finalityCheckMutex.Lock()
+	defer finalityCheckMutex.Unlock()
+
+	finalityCheck = finalized
+}
+
+func messageFinalized(msg *message.Message) bool {
+	finalityCheckMutex.RLock()
+	defer finalityCheckMutex.RUnlock()
+
+	if finalityCheck == nil {
+		return true
+	}
+	return finalityCheck(msg)
+}
@@ -0,0 +1,67 @@
+package messagelayer
+
+import (
+	"sync"
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tipselector"
+	"github.com/iotaledger/goshimmer/plugins/config"
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+)
+
+const (
+	// TipSelectionUniform selects the tips uniformly at random.
+	TipSelectionUniform = "uniform"
+	// TipSelectionAge prefers young tips.
+	TipSelectionAge = "age"
+	// TipSelectionApprovalWeight weights the tips by the approval weight of their issuer.
+	TipSelectionApprovalWeight = "approvalWeight"
+
+	tipExpiryCheckInterval = 10 * time.Second
+
+	// the approval weight of an issuer is only recomputed after this time, as it requires scanning the ledger state
+	approvalWeightCacheTime = 10 * time.Second
+)
+
+var (
+	approvalWeight      func(issuer ed25519.PublicKey) float64
+	approvalWeightMutex sync.RWMutex
+)
+
+// SetApprovalWeight sets the function providing the approval weight of the issuers for the approval weighted tip
+// selection. As long as it is not set, all issuers have the same weight.
+func SetApprovalWeight(weight func(issuer ed25519.PublicKey) float64) {
+	approvalWeightMutex.Lock()
+	defer approvalWeightMutex.Unlock()
+
+	approvalWeight = weight
+}
+
+func issuerApprovalWeight(issuer ed25519.PublicKey) float64 {
+	approvalWeightMutex.RLock()
+	defer approvalWeightMutex.RUnlock()
+
+	if approvalWeight == nil {
+		return 1
+	}
+	return approvalWeight(issuer)
+}
+
+func createTipSelector() *tipselector.TipSelector {
+	options := []tipselector.Option{
+		tipselector.TipExpiry(time.Duration(config.Node.GetInt(CfgTipSelectionExpirySec)) * time.Second),
+	}
+
+	switch strategy := config.Node.GetString(CfgTipSelectionStrategy); strategy {
+	case TipSelectionUniform:
+	case TipSelectionAge:
+		halfLife := time.Duration(config.Node.GetInt(CfgTipSelectionAgeHalfLifeSec)) * time.Second
+		options = append(options, tipselector.SelectionStrategy(tipselector.AgeWeighted(halfLife)))
+	case TipSelectionApprovalWeight:
+		options = append(options, tipselector.SelectionStrategy(tipselector.ApprovalWeighted(issuerApprovalWeight, approvalWeightCacheTime)))
+	default:
+		log.Fatalf("Invalid tip selection strategy (%s): %s", CfgTipSelectionStrategy, strategy)
+	}
+
+	return tipselector.New(options...)
+}
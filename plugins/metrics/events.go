@@ -8,11 +8,15 @@ import (
 var Events = pluginEvents{
 	// ReceivedMPSUpdated triggers upon reception of a MPS update.
 	ReceivedMPSUpdated: events.NewEvent(uint64EventCaller),
+	// MessageRequestQueueSizeUpdated triggers upon a new measurement of the message request queue size.
+	MessageRequestQueueSizeUpdated: events.NewEvent(uint64EventCaller),
 }
 
 type pluginEvents struct {
 	// Fired when the messages per second metric is updated.
 	ReceivedMPSUpdated *events.Event
+	// Fired when the message request queue size metric is updated.
+	MessageRequestQueueSizeUpdated *events.Event
 }
 
 func uint64EventCaller(handler interface{}, params ...interface{}) {
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"sync/atomic"
+
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// MessageRequestQueueSize retrieves the number of messages that are currently being requested from neighbors.
+func MessageRequestQueueSize() uint64 {
+	return atomic.LoadUint64(&measuredMessageRequestQueueSize)
+}
+
+// measured value of the message request queue size
+var measuredMessageRequestQueueSize uint64
+
+// measures the size of the message request queue
+func measureMessageRequestQueueSize() {
+	sampledSize := uint64(messagelayer.MessageRequester.RequestQueueSize())
+
+	// store the measured value
+	atomic.StoreUint64(&measuredMessageRequestQueueSize, sampledSize)
+
+	// trigger events for outside listeners
+	Events.MessageRequestQueueSizeUpdated.Trigger(sampledSize)
+}
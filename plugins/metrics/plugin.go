@@ -34,4 +34,9 @@ func run(_ *node.Plugin) {
 	daemon.BackgroundWorker("Metrics MPS Updater", func(shutdownSignal <-chan struct{}) {
 		timeutil.Ticker(measureReceivedMPS, 1*time.Second, shutdownSignal)
 	}, shutdown.PriorityMetrics)
+
+	// create a background worker that samples the size of the message request queue every second
+	daemon.BackgroundWorker("Metrics Message Requester Updater", func(shutdownSignal <-chan struct{}) {
+		timeutil.Ticker(measureMessageRequestQueueSize, 1*time.Second, shutdownSignal)
+	}, shutdown.PriorityMetrics)
 }
@@ -0,0 +1,20 @@
+package prometheus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/plugins/database"
+)
+
+var databaseSize = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+	Namespace: namespace,
+	Subsystem: "database",
+	Name:      "size_bytes",
+	Help:      "Size of the database in bytes.",
+}, func() float64 {
+	return float64(database.DB().Size())
+})
+
+func registerDatabaseMetrics() {
+	registry.MustRegister(databaseSize)
+}
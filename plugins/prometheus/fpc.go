@@ -0,0 +1,71 @@
+package prometheus
+
+import (
+	"github.com/iotaledger/hive.go/events"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+var (
+	fpcRounds = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fpc",
+		Name:      "rounds_total",
+		Help:      "Number of executed FPC rounds.",
+	})
+	fpcRoundDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "fpc",
+		Name:      "round_duration_seconds",
+		Help:      "Time it took to execute an FPC round.",
+		Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 1.5, 2, 5},
+	})
+	fpcActiveVoteContexts = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "fpc",
+		Name:      "active_vote_contexts",
+		Help:      "Number of vote contexts in the last FPC round.",
+	})
+	fpcQueriedOpinionGivers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "fpc",
+		Name:      "queried_opinion_givers",
+		Help:      "Number of opinion givers that replied in the last FPC round.",
+	})
+	fpcVotes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "fpc",
+		Name:      "votes_total",
+		Help:      "Number of concluded votes per outcome (finalized, failed) and opinion.",
+	}, []string{"outcome", "opinion"})
+)
+
+func registerFPCMetrics() {
+	registry.MustRegister(
+		fpcRounds,
+		fpcRoundDuration,
+		fpcActiveVoteContexts,
+		fpcQueriedOpinionGivers,
+		fpcVotes,
+	)
+
+	attachFPCMetrics(valuetransfers.Voter().Events())
+}
+
+// attachFPCMetrics updates the metrics of the FPC rounds and votes on the given voter events.
+func attachFPCMetrics(voterEvents vote.Events) {
+	voterEvents.RoundExecuted.Attach(events.NewClosure(func(roundStats *vote.RoundStats) {
+		fpcRounds.Inc()
+		fpcRoundDuration.Observe(roundStats.Duration.Seconds())
+		fpcActiveVoteContexts.Set(float64(len(roundStats.ActiveVoteContexts)))
+		fpcQueriedOpinionGivers.Set(float64(len(roundStats.QueriedOpinions)))
+	}))
+	voterEvents.Finalized.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		fpcVotes.WithLabelValues("finalized", opinion.String()).Inc()
+	}))
+	voterEvents.Failed.Attach(events.NewClosure(func(id string, opinion vote.Opinion) {
+		fpcVotes.WithLabelValues("failed", opinion.String()).Inc()
+	}))
+}
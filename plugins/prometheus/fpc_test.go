@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/vote"
+)
+
+func TestAttachFPCMetrics(t *testing.T) {
+	voterEvents := vote.Events{
+		Finalized:     events.NewEvent(vote.OpinionCaller),
+		Failed:        events.NewEvent(vote.OpinionCaller),
+		RoundExecuted: events.NewEvent(vote.RoundStatsCaller),
+		Error:         events.NewEvent(events.ErrorCaller),
+	}
+	attachFPCMetrics(voterEvents)
+
+	roundsBefore := testutil.ToFloat64(fpcRounds)
+	finalizedBefore := testutil.ToFloat64(fpcVotes.WithLabelValues("finalized", vote.Like.String()))
+	failedBefore := testutil.ToFloat64(fpcVotes.WithLabelValues("failed", vote.Dislike.String()))
+
+	voterEvents.RoundExecuted.Trigger(&vote.RoundStats{
+		Duration: 100 * time.Millisecond,
+		ActiveVoteContexts: map[string]*vote.Context{
+			"a": vote.NewContext("a", vote.Like),
+			"b": vote.NewContext("b", vote.Dislike),
+		},
+	})
+	voterEvents.Finalized.Trigger("a", vote.Like)
+	voterEvents.Failed.Trigger("b", vote.Dislike)
+
+	assert.Equal(t, roundsBefore+1, testutil.ToFloat64(fpcRounds))
+	assert.Equal(t, 2.0, testutil.ToFloat64(fpcActiveVoteContexts))
+	assert.Equal(t, finalizedBefore+1, testutil.ToFloat64(fpcVotes.WithLabelValues("finalized", vote.Like.String())))
+	assert.Equal(t, failedBefore+1, testutil.ToFloat64(fpcVotes.WithLabelValues("failed", vote.Dislike.String())))
+}
@@ -0,0 +1,103 @@
+package prometheus
+
+import (
+	"github.com/iotaledger/hive.go/autopeering/peer"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/packages/gossip"
+	gossipplugin "github.com/iotaledger/goshimmer/plugins/gossip"
+)
+
+var (
+	neighborsConnected = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "gossip",
+		Name:      "neighbors",
+		Help:      "Number of connected neighbors.",
+	}, func() float64 {
+		return float64(len(gossipplugin.Manager().AllNeighbors()))
+	})
+	neighborConnectionsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gossip",
+		Name:      "connections_failed_total",
+		Help:      "Number of failed connection attempts to neighbors.",
+	})
+	neighborsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gossip",
+		Name:      "neighbors_dropped_total",
+		Help:      "Number of neighbors that were removed.",
+	})
+	gossipMessagesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gossip",
+		Name:      "messages_received_total",
+		Help:      "Number of messages received from neighbors.",
+	})
+	gossipMessageBytesReceived = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gossip",
+		Name:      "message_bytes_received_total",
+		Help:      "Number of bytes of the messages received from neighbors.",
+	})
+)
+
+// neighborTrafficCollector collects the traffic of the currently connected neighbors at every scrape.
+type neighborTrafficCollector struct {
+	bytesRead    *prometheus.Desc
+	bytesWritten *prometheus.Desc
+}
+
+func newNeighborTrafficCollector() *neighborTrafficCollector {
+	return &neighborTrafficCollector{
+		bytesRead: prometheus.NewDesc(prometheus.BuildFQName(namespace, "gossip", "neighbor_bytes_read"),
+			"Number of bytes read from the connection to a neighbor.", []string{"neighbor", "direction"}, nil),
+		bytesWritten: prometheus.NewDesc(prometheus.BuildFQName(namespace, "gossip", "neighbor_bytes_written"),
+			"Number of bytes written to the connection to a neighbor.", []string{"neighbor", "direction"}, nil),
+	}
+}
+
+// Describe implements the prometheus.Collector interface.
+func (c *neighborTrafficCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.bytesRead
+	ch <- c.bytesWritten
+}
+
+// Collect implements the prometheus.Collector interface.
+func (c *neighborTrafficCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, neighbor := range gossipplugin.Manager().AllNeighbors() {
+		direction := "inbound"
+		if neighbor.IsOutbound() {
+			direction = "outbound"
+		}
+		id := neighbor.ID().String()
+
+		ch <- prometheus.MustNewConstMetric(c.bytesRead, prometheus.GaugeValue, float64(neighbor.BytesRead()), id, direction)
+		ch <- prometheus.MustNewConstMetric(c.bytesWritten, prometheus.GaugeValue, float64(neighbor.BytesWritten()), id, direction)
+	}
+}
+
+func registerGossipMetrics() {
+	registry.MustRegister(
+		neighborsConnected,
+		neighborConnectionsFailed,
+		neighborsDropped,
+		gossipMessagesReceived,
+		gossipMessageBytesReceived,
+		newNeighborTrafficCollector(),
+	)
+
+	mgrEvents := gossipplugin.Manager().Events()
+	mgrEvents.ConnectionFailed.Attach(events.NewClosure(func(*peer.Peer, error) {
+		neighborConnectionsFailed.Inc()
+	}))
+	mgrEvents.NeighborRemoved.Attach(events.NewClosure(func(*peer.Peer) {
+		neighborsDropped.Inc()
+	}))
+	mgrEvents.MessageReceived.Attach(events.NewClosure(func(event *gossip.MessageReceivedEvent) {
+		gossipMessagesReceived.Inc()
+		gossipMessageBytesReceived.Add(float64(len(event.Data)))
+	}))
+}
@@ -0,0 +1,20 @@
+package prometheus
+
+import (
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// CfgPrometheusBindAddress defines the bind address on which the Prometheus exporter listens.
+	CfgPrometheusBindAddress = "prometheus.bindAddress"
+	// CfgPrometheusGoMetrics defines whether to include the go runtime metrics.
+	CfgPrometheusGoMetrics = "prometheus.goMetrics"
+	// CfgPrometheusProcessMetrics defines whether to include the process metrics.
+	CfgPrometheusProcessMetrics = "prometheus.processMetrics"
+)
+
+func init() {
+	flag.String(CfgPrometheusBindAddress, "127.0.0.1:9311", "the bind address on which the Prometheus exporter listens on")
+	flag.Bool(CfgPrometheusGoMetrics, false, "include go runtime metrics")
+	flag.Bool(CfgPrometheusProcessMetrics, false, "include process metrics")
+}
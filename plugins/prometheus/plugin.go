@@ -0,0 +1,95 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/config"
+)
+
+// PluginName is the name of the prometheus plugin.
+const PluginName = "Prometheus"
+
+// namespace is the prefix of all the exported metrics.
+const namespace = "goshimmer"
+
+var (
+	// Plugin is the plugin instance of the prometheus plugin.
+	Plugin = node.NewPlugin(PluginName, node.Disabled, configure, run)
+	log    *logger.Logger
+
+	// registry holds the collectors of all the exported metrics.
+	registry = prometheus.NewRegistry()
+)
+
+func configure(_ *node.Plugin) {
+	log = logger.NewLogger(PluginName)
+
+	if config.Node.GetBool(CfgPrometheusGoMetrics) {
+		registry.MustRegister(prometheus.NewGoCollector())
+	}
+	if config.Node.GetBool(CfgPrometheusProcessMetrics) {
+		registry.MustRegister(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
+	}
+
+	registerTangleMetrics()
+	registerGossipMetrics()
+	registerDatabaseMetrics()
+}
+
+func run(_ *node.Plugin) {
+	// the dApps are configured after this plugin, so their metrics can only be registered once the node is running
+	if !node.IsSkipped(valuetransfers.App) {
+		registerValueTransfersMetrics()
+		registerFPCMetrics()
+	}
+
+	log.Infof("Starting %s ...", PluginName)
+	if err := daemon.BackgroundWorker("Prometheus Exporter", worker, shutdown.PriorityPrometheus); err != nil {
+		log.Errorf("Error starting as daemon: %s", err)
+	}
+}
+
+func worker(shutdownSignal <-chan struct{}) {
+	defer log.Infof("Stopping %s ... done", PluginName)
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	bindAddr := config.Node.GetString(CfgPrometheusBindAddress)
+	server := &http.Server{Addr: bindAddr, Handler: mux}
+
+	stopped := make(chan struct{})
+	go func() {
+		log.Infof("%s started, bind-address=%s", PluginName, bindAddr)
+		if err := server.ListenAndServe(); err != nil {
+			if !errors.Is(err, http.ErrServerClosed) {
+				log.Errorf("Error serving: %s", err)
+			}
+			close(stopped)
+		}
+	}()
+
+	// stop if we are shutting down or the server could not be started
+	select {
+	case <-shutdownSignal:
+	case <-stopped:
+	}
+
+	log.Infof("Stopping %s ...", PluginName)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Errorf("Error stopping: %s", err)
+	}
+}
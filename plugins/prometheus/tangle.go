@@ -0,0 +1,123 @@
+package prometheus
+
+import (
+	"github.com/iotaledger/hive.go/events"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/metrics"
+)
+
+var (
+	messagesAttached = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "messages_attached_total",
+		Help:      "Number of messages attached to the tangle.",
+	})
+	messagesSolid = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "messages_solid_total",
+		Help:      "Number of messages that became solid.",
+	})
+	messagesRemoved = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "messages_removed_total",
+		Help:      "Number of messages that were removed from the tangle.",
+	})
+	messagesUnsolidifiable = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "messages_unsolidifiable_total",
+		Help:      "Number of missing messages that could not be retrieved and whose future cone was removed.",
+	})
+	missingMessages = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "missing_messages",
+		Help:      "Number of messages that are currently missing.",
+	}, func() float64 {
+		return float64(messagelayer.Tangle.MissingMessageCount())
+	})
+	solidificationTime = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "solidification_seconds",
+		Help:      "Time it took messages to become solid after they were received.",
+		Buckets:   []float64{0.001, 0.01, 0.1, 0.5, 1, 5, 10, 30, 60},
+	})
+	tips = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "tips",
+		Help:      "Number of tips in the tip selector.",
+	})
+	messageRequestQueueSize = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "message_request_queue_size",
+		Help:      "Number of messages that are currently being requested from neighbors.",
+	}, func() float64 {
+		return float64(messagelayer.MessageRequester.RequestQueueSize())
+	})
+	receivedMPS = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "tangle",
+		Name:      "received_messages_per_second",
+		Help:      "Number of messages received in the last second.",
+	}, func() float64 {
+		return float64(metrics.ReceivedMessagesPerSecond())
+	})
+)
+
+func registerTangleMetrics() {
+	registry.MustRegister(
+		messagesAttached,
+		messagesSolid,
+		messagesRemoved,
+		messagesUnsolidifiable,
+		missingMessages,
+		solidificationTime,
+		tips,
+		messageRequestQueueSize,
+		receivedMPS,
+	)
+
+	attachTangleMetrics(messagelayer.Tangle)
+
+	tips.Set(float64(messagelayer.TipSelector.TipCount()))
+	messagelayer.TipSelector.Events.TipAdded.Attach(events.NewClosure(func(message.Id) {
+		tips.Inc()
+	}))
+	messagelayer.TipSelector.Events.TipRemoved.Attach(events.NewClosure(func(message.Id) {
+		tips.Dec()
+	}))
+}
+
+// attachTangleMetrics updates the event based metrics of the given message tangle.
+func attachTangleMetrics(messageTangle *tangle.Tangle) {
+	messageTangle.Events.MessageAttached.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessage.Release()
+		cachedMessageMetadata.Release()
+		messagesAttached.Inc()
+	}))
+	messageTangle.Events.MessageSolid.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessage.Release()
+		defer cachedMessageMetadata.Release()
+
+		if messageMetadata := cachedMessageMetadata.Unwrap(); messageMetadata != nil {
+			solidificationTime.Observe(messageMetadata.SoldificationTime().Sub(messageMetadata.ReceivedTime()).Seconds())
+		}
+		messagesSolid.Inc()
+	}))
+	messageTangle.Events.MessageRemoved.Attach(events.NewClosure(func(message.Id) {
+		messagesRemoved.Inc()
+	}))
+	messageTangle.Events.MessageUnsolidifiable.Attach(events.NewClosure(func(message.Id) {
+		messagesUnsolidifiable.Inc()
+	}))
+}
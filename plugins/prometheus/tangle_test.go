@@ -0,0 +1,41 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+)
+
+func TestAttachTangleMetrics(t *testing.T) {
+	messageTangle := tangle.New(mapdb.NewMapDB())
+	defer messageTangle.Shutdown()
+	attachTangleMetrics(messageTangle)
+
+	attachedBefore := testutil.ToFloat64(messagesAttached)
+	solidBefore := testutil.ToFloat64(messagesSolid)
+
+	localIdentity := identity.GenerateLocalIdentity()
+	parent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("parent")), 0)
+	child := message.New(parent.Id(), parent.Id(), localIdentity, time.Now(), 1, payload.NewData([]byte("child")), 0)
+
+	// the child is attached but can not become solid before its parent arrives
+	messageTangle.AttachMessage(child)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(messagesAttached) == attachedBefore+1 && messageTangle.MissingMessageCount() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, solidBefore, testutil.ToFloat64(messagesSolid))
+
+	messageTangle.AttachMessage(parent)
+	assert.Eventually(t, func() bool {
+		return testutil.ToFloat64(messagesAttached) == attachedBefore+2 && testutil.ToFloat64(messagesSolid) == solidBefore+2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, 0, messageTangle.MissingMessageCount())
+}
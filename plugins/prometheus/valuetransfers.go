@@ -0,0 +1,108 @@
+package prometheus
+
+import (
+	"github.com/iotaledger/hive.go/events"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+var (
+	valuePayloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "payloads_total",
+		Help:      "Number of value payloads per state (attached, solid, liked, disliked).",
+	}, []string{"state"})
+	valuePayloadsMissing = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "payloads_missing_total",
+		Help:      "Number of value payloads that were reported as missing.",
+	})
+	valueTransactionsBooked = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "transactions_booked_total",
+		Help:      "Number of transactions that were booked into the ledger.",
+	})
+	valueForks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "branches_created_total",
+		Help:      "Number of branches that were created because of conflicting transactions.",
+	})
+	valueBranches = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "branch_decisions_total",
+		Help:      "Number of branches per decision (preferred, unpreferred, liked, disliked).",
+	}, []string{"decision"})
+	valueTips = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "valuetransfers",
+		Name:      "tips",
+		Help:      "Number of tips in the value tip manager.",
+	}, func() float64 {
+		return float64(valuetransfers.TipManager().Size())
+	})
+)
+
+func registerValueTransfersMetrics() {
+	registry.MustRegister(
+		valuePayloads,
+		valuePayloadsMissing,
+		valueTransactionsBooked,
+		valueForks,
+		valueBranches,
+		valueTips,
+	)
+
+	attachValueTransfersMetrics(valuetransfers.Tangle)
+}
+
+// attachValueTransfersMetrics updates the event based metrics of the given value tangle.
+func attachValueTransfersMetrics(valueTangle *tangle.Tangle) {
+	countPayload := func(state string) *events.Closure {
+		return events.NewClosure(func(cachedPayload *payload.CachedPayload, cachedPayloadMetadata *tangle.CachedPayloadMetadata) {
+			cachedPayload.Release()
+			cachedPayloadMetadata.Release()
+			valuePayloads.WithLabelValues(state).Inc()
+		})
+	}
+	valueTangle.Events.PayloadAttached.Attach(countPayload("attached"))
+	valueTangle.Events.PayloadSolid.Attach(countPayload("solid"))
+	valueTangle.Events.PayloadLiked.Attach(countPayload("liked"))
+	valueTangle.Events.PayloadDisliked.Attach(countPayload("disliked"))
+	valueTangle.Events.PayloadMissing.Attach(events.NewClosure(func(payload.ID) {
+		valuePayloadsMissing.Inc()
+	}))
+
+	valueTangle.Events.TransactionBooked.Attach(events.NewClosure(func(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *tangle.CachedTransactionMetadata, decisionPending bool) {
+		cachedTransaction.Release()
+		cachedTransactionMetadata.Release()
+		valueTransactionsBooked.Inc()
+	}))
+	valueTangle.Events.Fork.Attach(events.NewClosure(func(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *tangle.CachedTransactionMetadata, cachedBranch *branchmanager.CachedBranch, forkedOutputs []transaction.OutputID) {
+		cachedTransaction.Release()
+		cachedTransactionMetadata.Release()
+		cachedBranch.Release()
+		valueForks.Inc()
+	}))
+
+	countBranch := func(decision string) *events.Closure {
+		return events.NewClosure(func(cachedBranch *branchmanager.CachedBranch) {
+			cachedBranch.Release()
+			valueBranches.WithLabelValues(decision).Inc()
+		})
+	}
+	branchManagerEvents := valueTangle.BranchManager().Events
+	branchManagerEvents.BranchPreferred.Attach(countBranch("preferred"))
+	branchManagerEvents.BranchUnpreferred.Attach(countBranch("unpreferred"))
+	branchManagerEvents.BranchLiked.Attach(countBranch("liked"))
+	branchManagerEvents.BranchDisliked.Attach(countBranch("disliked"))
+}
@@ -0,0 +1,54 @@
+package prometheus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/crypto/ed25519"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address/signaturescheme"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+func TestAttachValueTransfersMetrics(t *testing.T) {
+	valueTangle := tangle.New(mapdb.NewMapDB())
+	defer valueTangle.Shutdown()
+	attachValueTransfersMetrics(valueTangle)
+
+	genesisKeyPair := signaturescheme.ED25519(ed25519.GenerateKeyPair())
+	valueTangle.LoadSnapshot(tangle.Snapshot{
+		transaction.GenesisID: {
+			genesisKeyPair.Address(): []*balance.Balance{balance.New(balance.ColorIOTA, 1000)},
+		},
+	})
+
+	attachedBefore := testutil.ToFloat64(valuePayloads.WithLabelValues("attached"))
+	solidBefore := testutil.ToFloat64(valuePayloads.WithLabelValues("solid"))
+	bookedBefore := testutil.ToFloat64(valueTransactionsBooked)
+	forksBefore := testutil.ToFloat64(valueForks)
+
+	// two transactions spending the same output create a fork
+	spentOutputID := transaction.NewOutputID(genesisKeyPair.Address(), transaction.GenesisID)
+	for i := 0; i < 2; i++ {
+		tx := transaction.New(
+			transaction.NewInputs(spentOutputID),
+			transaction.NewOutputs(map[address.Address][]*balance.Balance{
+				address.Random(): {balance.New(balance.ColorIOTA, 1000)},
+			}),
+		)
+		tx.Sign(genesisKeyPair)
+		valueTangle.AttachPayloadSync(payload.New(payload.GenesisID, payload.GenesisID, tx), time.Now())
+	}
+
+	assert.Equal(t, attachedBefore+2, testutil.ToFloat64(valuePayloads.WithLabelValues("attached")))
+	assert.Equal(t, solidBefore+2, testutil.ToFloat64(valuePayloads.WithLabelValues("solid")))
+	assert.Equal(t, bookedBefore+2, testutil.ToFloat64(valueTransactionsBooked))
+	assert.Equal(t, forksBefore+1, testutil.ToFloat64(valueForks))
+}
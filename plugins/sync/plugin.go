@@ -6,6 +6,7 @@ import (
 
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
 	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	gossippkg "github.com/iotaledger/goshimmer/packages/gossip"
 	"github.com/iotaledger/goshimmer/packages/shutdown"
 	"github.com/iotaledger/goshimmer/plugins/autopeering/local"
 	"github.com/iotaledger/goshimmer/plugins/config"
@@ -39,6 +40,12 @@ const (
 	// CfgSyncDesyncedIfNoMessageAfterSec defines the time period in which new messages must be received and if not
 	// the node is marked as desynced.
 	CfgSyncDesyncedIfNoMessageAfterSec = "sync.desyncedIfNoMessagesAfterSec"
+	// CfgSyncTipsRequestIntervalSec defines the interval at which the tips of the neighbors are requested while the
+	// node is desynced. The received tips are used as anchor points and missing ones are requested.
+	CfgSyncTipsRequestIntervalSec = "sync.tipsRequestIntervalSec"
+	// CfgSyncAnchorPointsMaxAgeSec defines the maximum age of anchor points. Older messages do not represent the current
+	// state of the network and are therefore not used as anchor points.
+	CfgSyncAnchorPointsMaxAgeSec = "sync.anchorPointsMaxAgeSec"
 )
 
 func init() {
@@ -46,6 +53,8 @@ func init() {
 	flag.Int(CfgSyncDesyncedIfNoMessageAfterSec, 300, "the time period in seconds which sets the node as desynced if no new messages are received")
 	flag.Int(CfgSyncAnchorPointsCleanupIntervalSec, 10, "the interval at which it is checked whether anchor points fall into the cleanup window")
 	flag.Int(CfgSyncAnchorPointsCleanupAfterSec, 60, "the amount of time which is allowed to pass between setting an anchor point and it not becoming solid (to clean its slot for another anchor point)")
+	flag.Int(CfgSyncTipsRequestIntervalSec, 10, "the interval in seconds at which the tips of the neighbors are requested while the node is desynced")
+	flag.Int(CfgSyncAnchorPointsMaxAgeSec, 300, "the maximum age in seconds of messages which are used as anchor points")
 }
 
 var (
@@ -57,6 +66,18 @@ var (
 	// tells whether the node is synced or not.
 	synced atomic.Bool
 	log    *logger.Logger
+
+	// the anchor points of the running synchronization monitor, nil if the node is synced.
+	currentAnchorPoints      *anchorpoints
+	currentAnchorPointsMutex sync.RWMutex
+
+	// Events defines the events of the sync plugin.
+	Events = struct {
+		// Fired when the node becomes synchronized or desynchronized.
+		SyncStatusChanged *events.Event
+	}{
+		SyncStatusChanged: events.NewEvent(syncStatusChangedCaller),
+	}
 )
 
 // Synced tells whether the node is in a state we consider synchronized, meaning
@@ -67,7 +88,18 @@ func Synced() bool {
 
 // OverwriteSyncedState overwrites the synced state with the given value.
 func OverwriteSyncedState(syncedOverwrite bool) {
-	synced.Store(syncedOverwrite)
+	setSynced(syncedOverwrite)
+}
+
+// setSynced sets the synced state and triggers the SyncStatusChanged event if it changed.
+func setSynced(newSynced bool) {
+	if synced.Swap(newSynced) != newSynced {
+		Events.SyncStatusChanged.Trigger(newSynced)
+	}
+}
+
+func syncStatusChangedCaller(handler interface{}, params ...interface{}) {
+	handler.(func(bool))(params[0].(bool))
 }
 
 func configure(_ *node.Plugin) {
@@ -75,6 +107,8 @@ func configure(_ *node.Plugin) {
 }
 
 func run(_ *node.Plugin) {
+	monitorProgress()
+
 	// per default the node starts in a desynced state
 	if !Synced() {
 		monitorForSynchronization()
@@ -88,13 +122,14 @@ func run(_ *node.Plugin) {
 
 // marks the node as synced and spawns the background worker to monitor desynchronization.
 func markSynced() {
-	synced.Store(true)
+	setCurrentAnchorPoints(nil)
+	setSynced(true)
 	monitorForDesynchronization()
 }
 
 // marks the node as desynced and spawns the background worker to monitor synchronization.
 func markDesynced() {
-	synced.Store(false)
+	setSynced(false)
 	monitorForSynchronization()
 }
 
@@ -170,7 +205,8 @@ func monitorForDesynchronization() {
 // a set of newly received messages and then waiting for them to become solid.
 func monitorForSynchronization() {
 	wantedAnchorPointsCount := config.Node.GetInt(CfgSyncAnchorPointsCount)
-	anchorPoints := newAnchorPoints(wantedAnchorPointsCount)
+	anchorPoints := newAnchorPoints(wantedAnchorPointsCount, config.Node.GetDuration(CfgSyncAnchorPointsMaxAgeSec)*time.Second)
+	setCurrentAnchorPoints(anchorPoints)
 	log.Infof("monitoring for synchronization, awaiting %d anchor point messages to become solid", wantedAnchorPointsCount)
 
 	synced := make(chan types.Empty)
@@ -178,7 +214,14 @@ func monitorForSynchronization() {
 	initAnchorPointClosure := events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
 		defer cachedMessage.Release()
 		defer cachedMessageMetadata.Release()
-		if addedAnchorID := initAnchorPoint(anchorPoints, cachedMessage.Unwrap()); addedAnchorID != nil {
+		msg := cachedMessage.Unwrap()
+
+		// stop requesting the message in case it was requested as a tip of a neighbor
+		if messagelayer.MessageRequester.IsRequested(msg.Id()) {
+			messagelayer.MessageRequester.StopRequest(msg.Id())
+		}
+
+		if addedAnchorID := initAnchorPoint(anchorPoints, msg); addedAnchorID != nil {
 			anchorPoints.Lock()
 			defer anchorPoints.Unlock()
 			log.Infof("added message %s as anchor point (%d of %d collected)", addedAnchorID.String()[:10], anchorPoints.collectedCount(), anchorPoints.wanted)
@@ -200,17 +243,38 @@ func monitorForSynchronization() {
 		synced <- types.Empty{}
 	})
 
+	addNeighborTipsClosure := events.NewClosure(func(ev *gossippkg.TipsReceivedEvent) {
+		if addedCount := addNeighborTips(anchorPoints, ev.Ids); addedCount > 0 {
+			log.Infof("added %d tips of neighbor %s as anchor points", addedCount, ev.Peer.ID())
+		}
+	})
+
+	// actively ask new neighbors for their tips, the event is triggered while the manager is locked
+	requestTipsClosure := events.NewClosure(func(n *gossippkg.Neighbor) {
+		go gossip.Manager().RequestTips(n.ID())
+	})
+
 	daemon.BackgroundWorker("Sync-Monitor", func(shutdownSignal <-chan struct{}) {
 		messagelayer.Tangle.Events.MessageAttached.Attach(initAnchorPointClosure)
 		defer messagelayer.Tangle.Events.MessageAttached.Detach(initAnchorPointClosure)
 		messagelayer.Tangle.Events.MessageSolid.Attach(checkAnchorPointSolidityClosure)
 		defer messagelayer.Tangle.Events.MessageSolid.Detach(checkAnchorPointSolidityClosure)
+		gossip.Manager().Events().TipsReceived.Attach(addNeighborTipsClosure)
+		defer gossip.Manager().Events().TipsReceived.Detach(addNeighborTipsClosure)
+		gossip.Manager().Events().NeighborAdded.Attach(requestTipsClosure)
+		defer gossip.Manager().Events().NeighborAdded.Detach(requestTipsClosure)
+
+		gossip.Manager().RequestTips()
+		tipsRequestTicker := time.NewTicker(config.Node.GetDuration(CfgSyncTipsRequestIntervalSec) * time.Second)
+		defer tipsRequestTicker.Stop()
 
 		cleanupDelta := config.Node.GetDuration(CfgSyncAnchorPointsCleanupAfterSec) * time.Second
-		ticker := time.NewTimer(config.Node.GetDuration(CfgSyncAnchorPointsCleanupIntervalSec) * time.Second)
+		ticker := time.NewTicker(config.Node.GetDuration(CfgSyncAnchorPointsCleanupIntervalSec) * time.Second)
 		defer ticker.Stop()
 		for {
 			select {
+			case <-tipsRequestTicker.C:
+				gossip.Manager().RequestTips()
 			case <-ticker.C:
 				anchorPoints.Lock()
 				for id, itGotAdded := range anchorPoints.ids {
@@ -240,22 +304,79 @@ func initAnchorPoint(anchorPoints *anchorpoints, msg *message.Message) *message.
 	anchorPoints.Lock()
 	defer anchorPoints.Unlock()
 
+	// a requested tip of a neighbor is dropped if it turns out to be too old
+	id := msg.Id()
+	if anchorPoints.has(id) {
+		if anchorPoints.tooOld(msg.IssuingTime()) {
+			anchorPoints.remove(id)
+		}
+		return nil
+	}
+
 	// we don't need to add additional anchor points if the set was already filled once
 	if anchorPoints.wasFilled() {
 		return nil
 	}
 
-	// as a rule, we don't consider messages attaching directly to genesis anchors
-	if msg.TrunkId() == message.EmptyId || msg.BranchId() == message.EmptyId {
+	// as a rule, we don't consider messages attaching directly to genesis or old messages as anchors
+	if msg.TrunkId() == message.EmptyId || msg.BranchId() == message.EmptyId || anchorPoints.tooOld(msg.IssuingTime()) {
 		return nil
 	}
 
-	// add a new anchor point
-	id := msg.Id()
 	anchorPoints.add(id)
 	return &id
 }
 
+// adds the given tips of a neighbor as anchor points and requests the ones that are not stored yet, so that the
+// solidification is actively driven towards the current tips of the network. Only the tips that still need to be
+// solidified are used, so that a neighbor can not mark the node as synced by sending old or already solid messages.
+func addNeighborTips(anchorPoints *anchorpoints, tipIDs []message.Id) (addedCount int) {
+	anchorPoints.Lock()
+	defer anchorPoints.Unlock()
+
+	if synced.Load() {
+		return 0
+	}
+
+	for _, tipID := range tipIDs {
+		if anchorPoints.wasFilled() {
+			break
+		}
+		if tipID == message.EmptyId || anchorPoints.has(tipID) {
+			continue
+		}
+
+		stored, solid, issuingTime := messageState(tipID)
+		if solid || (stored && anchorPoints.tooOld(issuingTime)) {
+			continue
+		}
+
+		anchorPoints.add(tipID)
+		addedCount++
+
+		if !stored {
+			messagelayer.MessageRequester.ScheduleRequest(tipID)
+		}
+	}
+
+	return addedCount
+}
+
+// returns whether the given message is stored, whether it is solid and when it was issued.
+func messageState(messageID message.Id) (stored bool, solid bool, issuingTime time.Time) {
+	messagelayer.Tangle.Message(messageID).Consume(func(msg *message.Message) {
+		issuingTime = msg.IssuingTime()
+	})
+
+	cachedMessageMetadata := messagelayer.Tangle.MessageMetadata(messageID)
+	defer cachedMessageMetadata.Release()
+
+	if messageMetadata := cachedMessageMetadata.Unwrap(); messageMetadata != nil {
+		return true, messageMetadata.IsSolid(), issuingTime
+	}
+	return false, false, issuingTime
+}
+
 // checks whether an anchor point message became solid.
 // if all anchor points became solid, it sets the node's state to synchronized.
 func checkAnchorPointSolidity(anchorPoints *anchorpoints, msg *message.Message) (bool, *message.Id) {
@@ -271,6 +392,10 @@ func checkAnchorPointSolidity(anchorPoints *anchorpoints, msg *message.Message)
 	if !anchorPoints.has(msgID) {
 		return false, nil
 	}
+	if anchorPoints.tooOld(msg.IssuingTime()) {
+		anchorPoints.remove(msgID)
+		return false, nil
+	}
 
 	// an anchor became solid
 	anchorPoints.markAsSolidified(msgID)
@@ -283,10 +408,11 @@ func checkAnchorPointSolidity(anchorPoints *anchorpoints, msg *message.Message)
 	return true, &msgID
 }
 
-func newAnchorPoints(wantedAnchorPointsCount int) *anchorpoints {
+func newAnchorPoints(wantedAnchorPointsCount int, maxAge time.Duration) *anchorpoints {
 	return &anchorpoints{
 		ids:    make(map[message.Id]time.Time),
 		wanted: wantedAnchorPointsCount,
+		maxAge: maxAge,
 	}
 }
 
@@ -299,6 +425,8 @@ type anchorpoints struct {
 	wanted int
 	// how many anchor points have been solidified.
 	solidified int
+	// the maximum age of the messages used as anchor points, 0 if the age is not limited.
+	maxAge time.Duration
 }
 
 // adds the given message to the anchor points set.
@@ -306,6 +434,16 @@ func (ap *anchorpoints) add(id message.Id) {
 	ap.ids[id] = time.Now()
 }
 
+// removes the given message from the anchor points set without counting it as solidified.
+func (ap *anchorpoints) remove(id message.Id) {
+	delete(ap.ids, id)
+}
+
+// tells whether a message issued at the given time is too old to be an anchor point.
+func (ap *anchorpoints) tooOld(issuingTime time.Time) bool {
+	return ap.maxAge > 0 && time.Since(issuingTime) > ap.maxAge
+}
+
 func (ap *anchorpoints) has(id message.Id) bool {
 	_, has := ap.ids[id]
 	return has
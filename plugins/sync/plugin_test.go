@@ -0,0 +1,166 @@
+package sync
+
+import (
+	"testing"
+	"time"
+
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/messagerequester"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+)
+
+// setupMessageLayer replaces the message layer of the node with an empty one and returns a function which attaches
+// the given messages and waits until they are stored.
+func setupMessageLayer(t *testing.T) (attach func(msgs ...*message.Message), teardown func()) {
+	messagelayer.Tangle = tangle.New(mapdb.NewMapDB())
+	messagelayer.MessageRequester = messagerequester.New()
+	synced.Store(false)
+
+	attached := make(chan message.Id, 10)
+	messagelayer.Tangle.Events.MessageAttached.Attach(events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessageMetadata.Release()
+		cachedMessage.Consume(func(msg *message.Message) {
+			attached <- msg.Id()
+		})
+	}))
+
+	attach = func(msgs ...*message.Message) {
+		for _, msg := range msgs {
+			messagelayer.Tangle.AttachMessage(msg)
+			select {
+			case <-attached:
+			case <-time.After(time.Second):
+				t.Fatal("message was not attached")
+			}
+		}
+	}
+
+	return attach, func() { messagelayer.Tangle.Shutdown() }
+}
+
+func TestAnchorPoints(t *testing.T) {
+	anchorPoints := newAnchorPoints(2, time.Minute)
+	first, second := message.Id{1}, message.Id{2}
+
+	anchorPoints.add(first)
+	assert.True(t, anchorPoints.has(first))
+	assert.False(t, anchorPoints.wasFilled())
+
+	// solidified anchor points still count as collected
+	anchorPoints.markAsSolidified(first)
+	assert.False(t, anchorPoints.has(first))
+	assert.Equal(t, 1, anchorPoints.collectedCount())
+	assert.False(t, anchorPoints.wereAllSolidified())
+
+	// removed anchor points free their slot
+	anchorPoints.add(second)
+	assert.True(t, anchorPoints.wasFilled())
+	anchorPoints.remove(second)
+	assert.False(t, anchorPoints.wasFilled())
+
+	anchorPoints.add(second)
+	anchorPoints.markAsSolidified(second)
+	assert.True(t, anchorPoints.wereAllSolidified())
+
+	assert.False(t, anchorPoints.tooOld(time.Now()))
+	assert.True(t, anchorPoints.tooOld(time.Now().Add(-2*time.Minute)))
+	assert.False(t, newAnchorPoints(2, 0).tooOld(time.Now().Add(-time.Hour)))
+}
+
+func TestAddNeighborTips(t *testing.T) {
+	attach, teardown := setupMessageLayer(t)
+	defer teardown()
+
+	localIdentity := identity.GenerateLocalIdentity()
+	solidMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("solid")), 0)
+	missingParent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 1, payload.NewData([]byte("missing")), 0)
+	unsolidMessage := message.New(missingParent.Id(), missingParent.Id(), localIdentity, time.Now(), 2, payload.NewData([]byte("unsolid")), 0)
+	oldMessage := message.New(missingParent.Id(), missingParent.Id(), localIdentity, time.Now().Add(-time.Hour), 3, payload.NewData([]byte("old")), 0)
+	unknownTip := message.New(solidMessage.Id(), solidMessage.Id(), localIdentity, time.Now(), 4, payload.NewData([]byte("unknown")), 0)
+	attach(solidMessage, unsolidMessage, oldMessage)
+	require.Eventually(t, func() bool {
+		_, solid, _ := messageState(solidMessage.Id())
+		return solid
+	}, time.Second, 10*time.Millisecond)
+
+	// solid and old tips are ignored, unknown tips are requested
+	anchorPoints := newAnchorPoints(3, time.Minute)
+	addedCount := addNeighborTips(anchorPoints, []message.Id{message.EmptyId, solidMessage.Id(), oldMessage.Id(), unsolidMessage.Id(), unknownTip.Id()})
+	assert.Equal(t, 2, addedCount)
+	assert.True(t, anchorPoints.has(unsolidMessage.Id()))
+	assert.True(t, anchorPoints.has(unknownTip.Id()))
+	assert.Equal(t, 0, anchorPoints.solidified)
+	assert.True(t, messagelayer.MessageRequester.IsRequested(unknownTip.Id()))
+	assert.False(t, messagelayer.MessageRequester.IsRequested(unsolidMessage.Id()))
+
+	// tips are not added twice and not at all once the node is synced
+	assert.Equal(t, 0, addNeighborTips(anchorPoints, []message.Id{unsolidMessage.Id()}))
+	synced.Store(true)
+	assert.Equal(t, 0, addNeighborTips(newAnchorPoints(3, time.Minute), []message.Id{unsolidMessage.Id()}))
+}
+
+func TestInitAnchorPoint(t *testing.T) {
+	_, teardown := setupMessageLayer(t)
+	defer teardown()
+
+	localIdentity := identity.GenerateLocalIdentity()
+	parent := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("parent")), 0)
+	recentMessage := message.New(parent.Id(), parent.Id(), localIdentity, time.Now(), 1, payload.NewData([]byte("recent")), 0)
+	oldMessage := message.New(parent.Id(), parent.Id(), localIdentity, time.Now().Add(-time.Hour), 2, payload.NewData([]byte("old")), 0)
+	oldTip := message.New(parent.Id(), parent.Id(), localIdentity, time.Now().Add(-time.Hour), 3, payload.NewData([]byte("old tip")), 0)
+
+	anchorPoints := newAnchorPoints(3, time.Minute)
+
+	// messages attaching to the genesis and old messages do not become anchor points
+	assert.Nil(t, initAnchorPoint(anchorPoints, parent))
+	assert.Nil(t, initAnchorPoint(anchorPoints, oldMessage))
+	assert.Equal(t, recentMessage.Id(), *initAnchorPoint(anchorPoints, recentMessage))
+
+	// requested tips of neighbors are dropped once they turn out to be too old
+	anchorPoints.add(oldTip.Id())
+	assert.Nil(t, initAnchorPoint(anchorPoints, oldTip))
+	assert.False(t, anchorPoints.has(oldTip.Id()))
+	assert.Equal(t, 1, anchorPoints.collectedCount())
+}
+
+func TestCheckAnchorPointSolidity(t *testing.T) {
+	_, teardown := setupMessageLayer(t)
+	defer teardown()
+
+	localIdentity := identity.GenerateLocalIdentity()
+	firstAnchor := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 0, payload.NewData([]byte("first")), 0)
+	secondAnchor := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 1, payload.NewData([]byte("second")), 0)
+	oldAnchor := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now().Add(-time.Hour), 2, payload.NewData([]byte("old")), 0)
+	otherMessage := message.New(message.EmptyId, message.EmptyId, localIdentity, time.Now(), 3, payload.NewData([]byte("other")), 0)
+
+	anchorPoints := newAnchorPoints(2, time.Minute)
+	anchorPoints.add(firstAnchor.Id())
+	anchorPoints.add(oldAnchor.Id())
+
+	allSolid, solidAnchorID := checkAnchorPointSolidity(anchorPoints, otherMessage)
+	assert.False(t, allSolid)
+	assert.Nil(t, solidAnchorID)
+
+	// old anchor points do not count when they become solid
+	allSolid, solidAnchorID = checkAnchorPointSolidity(anchorPoints, oldAnchor)
+	assert.False(t, allSolid)
+	assert.Nil(t, solidAnchorID)
+	assert.False(t, anchorPoints.has(oldAnchor.Id()))
+
+	allSolid, solidAnchorID = checkAnchorPointSolidity(anchorPoints, firstAnchor)
+	assert.False(t, allSolid)
+	assert.Equal(t, firstAnchor.Id(), *solidAnchorID)
+
+	anchorPoints.add(secondAnchor.Id())
+	allSolid, solidAnchorID = checkAnchorPointSolidity(anchorPoints, secondAnchor)
+	assert.True(t, allSolid)
+	assert.Equal(t, secondAnchor.Id(), *solidAnchorID)
+}
@@ -0,0 +1,99 @@
+package sync
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/timeutil"
+	"go.uber.org/atomic"
+)
+
+const (
+	// the interval at which the solidification rate is measured.
+	progressMeasurementInterval = time.Second
+	// the weight of the latest measurement in the moving average of the solidification rate.
+	solidificationRateSmoothing = 0.2
+)
+
+var (
+	// the amount of messages that became solid since the last measurement.
+	solidifiedMessages atomic.Uint64
+	// the moving average of the messages that became solid per second.
+	solidificationRate atomic.Float64
+)
+
+// Progress describes the progress of the synchronization.
+type Progress struct {
+	// Synced tells whether the node is synchronized.
+	Synced bool
+	// AnchorPoints is the amount of anchor points which need to become solid for the node to be synchronized.
+	AnchorPoints int
+	// CollectedAnchorPoints is the amount of anchor points which were collected so far.
+	CollectedAnchorPoints int
+	// SolidAnchorPoints is the amount of anchor points which have become solid.
+	SolidAnchorPoints int
+	// MissingMessages is the amount of missing messages which are currently requested from the neighbors.
+	MissingMessages int
+	// SolidificationRate is the amount of messages which became solid per second.
+	SolidificationRate float64
+	// EstimatedRemaining is the estimated time until the missing messages are solidified. As the missing messages
+	// are only discovered while walking towards the past, it is a lower bound and zero if it cannot be estimated.
+	EstimatedRemaining time.Duration
+}
+
+// SyncProgress returns the current progress of the synchronization.
+func SyncProgress() Progress {
+	progress := Progress{
+		Synced:             Synced(),
+		MissingMessages:    messagelayer.MessageRequester.RequestQueueSize(),
+		SolidificationRate: solidificationRate.Load(),
+	}
+
+	currentAnchorPointsMutex.RLock()
+	anchorPoints := currentAnchorPoints
+	currentAnchorPointsMutex.RUnlock()
+	if anchorPoints != nil {
+		anchorPoints.Lock()
+		progress.AnchorPoints = anchorPoints.wanted
+		progress.CollectedAnchorPoints = anchorPoints.collectedCount()
+		progress.SolidAnchorPoints = anchorPoints.solidified
+		anchorPoints.Unlock()
+	}
+
+	if !progress.Synced && progress.SolidificationRate > 0 {
+		progress.EstimatedRemaining = time.Duration(float64(progress.MissingMessages) / progress.SolidificationRate * float64(time.Second))
+	}
+
+	return progress
+}
+
+func setCurrentAnchorPoints(anchorPoints *anchorpoints) {
+	currentAnchorPointsMutex.Lock()
+	defer currentAnchorPointsMutex.Unlock()
+
+	currentAnchorPoints = anchorPoints
+}
+
+// starts a background worker which measures the rate at which messages become solid.
+func monitorProgress() {
+	countSolidMessagesClosure := events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+		cachedMessage.Release()
+		cachedMessageMetadata.Release()
+		solidifiedMessages.Inc()
+	})
+
+	daemon.BackgroundWorker("Sync-Progress", func(shutdownSignal <-chan struct{}) {
+		messagelayer.Tangle.Events.MessageSolid.Attach(countSolidMessagesClosure)
+		defer messagelayer.Tangle.Events.MessageSolid.Detach(countSolidMessagesClosure)
+
+		timeutil.Ticker(func() {
+			rate := float64(solidifiedMessages.Swap(0)) / progressMeasurementInterval.Seconds()
+			solidificationRate.Store(solidificationRateSmoothing*rate + (1-solidificationRateSmoothing)*solidificationRate.Load())
+		}, progressMeasurementInterval, shutdownSignal)
+	}, shutdown.PrioritySynchronization)
+}
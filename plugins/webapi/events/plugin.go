@@ -0,0 +1,267 @@
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+	"github.com/iotaledger/goshimmer/packages/shutdown"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/iotaledger/hive.go/daemon"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+// PluginName is the name of the web API events endpoint plugin.
+const PluginName = "WebAPI events Endpoint"
+
+const (
+	// TopicMessageAttached is the topic of messages attached to the tangle.
+	TopicMessageAttached = "messageAttached"
+	// TopicMessageSolid is the topic of messages that became solid.
+	TopicMessageSolid = "messageSolid"
+	// TopicTipAdded is the topic of messages that became tips.
+	TopicTipAdded = "tipAdded"
+	// TopicTipRemoved is the topic of tips that got approved or expired.
+	TopicTipRemoved = "tipRemoved"
+	// TopicTransactionBooked is the topic of value transactions booked into the ledger.
+	TopicTransactionBooked = "transactionBooked"
+	// TopicPayloadLiked is the topic of value payloads that got liked.
+	TopicPayloadLiked = "payloadLiked"
+	// TopicTransactionFinalized is the topic of value transactions whose opinion got finalized.
+	TopicTransactionFinalized = "transactionFinalized"
+	// TopicFork is the topic of value transactions that got forked into a new branch.
+	TopicFork = "fork"
+	// TopicBranchPreferred is the topic of branches that became preferred.
+	TopicBranchPreferred = "branchPreferred"
+	// TopicBranchLiked is the topic of branches that became liked.
+	TopicBranchLiked = "branchLiked"
+	// TopicBranchDisliked is the topic of branches that became disliked.
+	TopicBranchDisliked = "branchDisliked"
+	// TopicRandomness is the topic of the dRNG randomness.
+	TopicRandomness = "randomness"
+	// TopicSyncStatus is the topic of changes of the sync status of the node.
+	TopicSyncStatus = "syncStatus"
+
+	// QueryTopics is the query parameter containing the subscribed topics.
+	QueryTopics = "topics"
+	// QueryPayloadTypes is the query parameter containing the payload types to filter for.
+	QueryPayloadTypes = "payloadTypes"
+	// QueryAddresses is the query parameter containing the addresses to filter for.
+	QueryAddresses = "addresses"
+
+	webSocketWriteTimeout = 3 * time.Second
+)
+
+// Topics contains all topics that can be subscribed to.
+var Topics = []string{
+	TopicMessageAttached,
+	TopicMessageSolid,
+	TopicTipAdded,
+	TopicTipRemoved,
+	TopicTransactionBooked,
+	TopicPayloadLiked,
+	TopicTransactionFinalized,
+	TopicFork,
+	TopicBranchPreferred,
+	TopicBranchLiked,
+	TopicBranchDisliked,
+	TopicRandomness,
+	TopicSyncStatus,
+}
+
+var (
+	// Plugin is the plugin instance of the web API events endpoint plugin.
+	Plugin = node.NewPlugin(PluginName, node.Enabled, configure, run)
+	log    *logger.Logger
+
+	upgrader = websocket.Upgrader{
+		HandshakeTimeout: webSocketWriteTimeout,
+		CheckOrigin:      func(r *http.Request) bool { return true },
+	}
+)
+
+func configure(plugin *node.Plugin) {
+	log = logger.NewLogger(PluginName)
+	webapi.Server.GET("events", subscribe)
+}
+
+func run(*node.Plugin) {
+	if err := daemon.BackgroundWorker("WebAPI[Events]", func(shutdownSignal <-chan struct{}) {
+		// the sources are attached at runtime, as the dapps are configured after the web API
+		sources := eventSources()
+		for _, s := range sources {
+			s.event.Attach(s.closure)
+		}
+		<-shutdownSignal
+		for _, s := range sources {
+			s.event.Detach(s.closure)
+		}
+	}, shutdown.PriorityWebAPI); err != nil {
+		log.Panicf("Failed to start as daemon: %s", err)
+	}
+}
+
+// subscribe upgrades the request to a WebSocket connection which streams the events of the requested topics.
+// The topics are given as comma separated list in the topics query parameter, all topics are subscribed if it is
+// omitted. The optional payloadTypes and addresses query parameters filter the events carrying the respective
+// information.
+func subscribe(c echo.Context) error {
+	s, err := parseSubscription(c.QueryParams())
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	ws, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+	if err != nil {
+		log.Debugw("error upgrading connection", "err", err)
+		return nil
+	}
+	defer ws.Close()
+
+	id := registerSubscriber(s)
+	defer removeSubscriber(id)
+
+	// the client does not send anything, reading only detects the closing of the connection
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case event := <-s.events:
+			if err := ws.SetWriteDeadline(time.Now().Add(webSocketWriteTimeout)); err != nil {
+				return nil
+			}
+			if err := ws.WriteJSON(event); err != nil {
+				return nil
+			}
+		case <-closed:
+			return nil
+		}
+	}
+}
+
+func parseSubscription(query map[string][]string) (*subscriber, error) {
+	s := &subscriber{
+		topics:       make(map[string]bool),
+		payloadTypes: make(map[payload.Type]bool),
+		addresses:    make(map[string]bool),
+		events:       make(chan *Event, subscriberBufferSize),
+	}
+
+	for _, topic := range splitQueryValues(query[QueryTopics]) {
+		if !isTopic(topic) {
+			return nil, fmt.Errorf("unknown topic: %s", topic)
+		}
+		s.topics[topic] = true
+	}
+	if len(s.topics) == 0 {
+		for _, topic := range Topics {
+			s.topics[topic] = true
+		}
+	}
+
+	for _, value := range splitQueryValues(query[QueryPayloadTypes]) {
+		payloadType, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid payload type: %s", value)
+		}
+		s.payloadTypes[payload.Type(payloadType)] = true
+	}
+
+	for _, value := range splitQueryValues(query[QueryAddresses]) {
+		addr, err := address.FromBase58(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address: %s", value)
+		}
+		s.addresses[addr.String()] = true
+	}
+
+	return s, nil
+}
+
+// splitQueryValues splits the comma separated values of the given query parameter.
+func splitQueryValues(values []string) (result []string) {
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				result = append(result, v)
+			}
+		}
+	}
+
+	return
+}
+
+func isTopic(topic string) bool {
+	for _, t := range Topics {
+		if t == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Event is a single event of the event stream. Depending on the topic, one of the optional fields is set.
+type Event struct {
+	Topic       string        `json:"topic"`
+	Timestamp   int64         `json:"timestamp"`
+	Message     *Message      `json:"message,omitempty"`
+	Tip         string        `json:"tip,omitempty"`
+	Transaction *Transaction  `json:"transaction,omitempty"`
+	Branch      *utils.Branch `json:"branch,omitempty"`
+	Randomness  *Randomness   `json:"randomness,omitempty"`
+	Synced      *bool         `json:"synced,omitempty"`
+
+	// the information used to filter the event
+	payloadType  *payload.Type
+	addresses    []string
+	hasAddresses bool
+}
+
+// Message contains the information of a message.
+type Message struct {
+	ID              string   `json:"id"`
+	TrunkID         string   `json:"trunkId"`
+	BranchID        string   `json:"branchId"`
+	IssuerPublicKey string   `json:"issuerPublicKey"`
+	IssuingTime     int64    `json:"issuingTime"`
+	PayloadType     uint32   `json:"payloadType"`
+	Addresses       []string `json:"addresses,omitempty"`
+}
+
+// Transaction contains the information of a value transaction.
+type Transaction struct {
+	ID        string   `json:"id"`
+	PayloadID string   `json:"payloadId,omitempty"`
+	Addresses []string `json:"addresses"`
+	Opinion   string   `json:"opinion,omitempty"`
+	BranchID  string   `json:"branchId,omitempty"`
+}
+
+// Randomness contains a dRNG randomness.
+type Randomness struct {
+	Round      uint64 `json:"round"`
+	Randomness []byte `json:"randomness"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// ErrorResponse is returned if the subscription is invalid.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,41 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+func TestParseSubscription(t *testing.T) {
+	// all topics are subscribed if none are given
+	s, err := parseSubscription(map[string][]string{})
+	require.NoError(t, err)
+	assert.Len(t, s.topics, len(Topics))
+	assert.Empty(t, s.payloadTypes)
+	assert.Empty(t, s.addresses)
+
+	addr := address.Random()
+	s, err = parseSubscription(map[string][]string{
+		QueryTopics:       {TopicMessageSolid + ", " + TopicFork, TopicSyncStatus},
+		QueryPayloadTypes: {"0,1"},
+		QueryAddresses:    {addr.String()},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{TopicMessageSolid: true, TopicFork: true, TopicSyncStatus: true}, s.topics)
+	assert.Equal(t, map[payload.Type]bool{0: true, 1: true}, s.payloadTypes)
+	assert.Equal(t, map[string]bool{addr.String(): true}, s.addresses)
+
+	for _, query := range []map[string][]string{
+		{QueryTopics: {"unknownTopic"}},
+		{QueryPayloadTypes: {"-1"}},
+		{QueryPayloadTypes: {"value"}},
+		{QueryAddresses: {"invalid"}},
+	} {
+		_, err := parseSubscription(query)
+		assert.Error(t, err, "query %v", query)
+	}
+}
@@ -0,0 +1,263 @@
+package events
+
+import (
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	valuepayload "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/payload"
+	valuetangle "github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	drngpayload "github.com/iotaledger/goshimmer/packages/binary/drng/payload"
+	"github.com/iotaledger/goshimmer/packages/binary/drng/state"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/message"
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/tangle"
+	"github.com/iotaledger/goshimmer/packages/vote"
+	"github.com/iotaledger/goshimmer/plugins/drng"
+	"github.com/iotaledger/goshimmer/plugins/messagelayer"
+	"github.com/iotaledger/goshimmer/plugins/sync"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/iotaledger/hive.go/events"
+	"github.com/iotaledger/hive.go/node"
+)
+
+var valuePayloadType = valuepayload.Type
+
+// source connects an event of the node to a topic of the event stream.
+type source struct {
+	event   *events.Event
+	closure *events.Closure
+}
+
+func eventSources() []source {
+	sources := []source{
+		{messagelayer.Tangle.Events.MessageAttached, events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+			publishMessage(TopicMessageAttached, cachedMessage, cachedMessageMetadata)
+		})},
+		{messagelayer.Tangle.Events.MessageSolid, events.NewClosure(func(cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+			publishMessage(TopicMessageSolid, cachedMessage, cachedMessageMetadata)
+		})},
+		{messagelayer.TipSelector.Events.TipAdded, events.NewClosure(func(messageID message.Id) {
+			publishTip(TopicTipAdded, messageID)
+		})},
+		{messagelayer.TipSelector.Events.TipRemoved, events.NewClosure(func(messageID message.Id) {
+			publishTip(TopicTipRemoved, messageID)
+		})},
+		{drng.Instance().Events.Randomness, events.NewClosure(func(randomness state.Randomness) {
+			if !hasSubscribers(TopicRandomness) {
+				return
+			}
+			drngType := drngpayload.Type
+			publish(&Event{
+				Topic:     TopicRandomness,
+				Timestamp: time.Now().Unix(),
+				Randomness: &Randomness{
+					Round:      randomness.Round,
+					Randomness: randomness.Randomness,
+					Timestamp:  randomness.Timestamp.Unix(),
+				},
+				payloadType: &drngType,
+			})
+		})},
+		{sync.Events.SyncStatusChanged, events.NewClosure(func(synced bool) {
+			publish(&Event{
+				Topic:     TopicSyncStatus,
+				Timestamp: time.Now().Unix(),
+				Synced:    &synced,
+			})
+		})},
+	}
+
+	// the value tangle only exists if the dApp is enabled
+	if !node.IsSkipped(valuetransfers.App) {
+		sources = append(sources, valueTransfersSources()...)
+	}
+
+	return sources
+}
+
+func valueTransfersSources() []source {
+	return []source{
+		{valuetransfers.Tangle.Events.TransactionBooked, events.NewClosure(func(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *valuetangle.CachedTransactionMetadata, _ bool) {
+			cachedTransactionMetadata.Release()
+			publishTransaction(TopicTransactionBooked, cachedTransaction, nil)
+		})},
+		{valuetransfers.Tangle.Events.PayloadLiked, events.NewClosure(func(cachedPayload *valuepayload.CachedPayload, cachedPayloadMetadata *valuetangle.CachedPayloadMetadata) {
+			cachedPayloadMetadata.Release()
+			publishPayload(TopicPayloadLiked, cachedPayload)
+		})},
+		{valuetransfers.FCOB.Events.Finalized, events.NewClosure(publishFinalizedTransaction)},
+		{valuetransfers.Voter().Events().Finalized, events.NewClosure(publishFinalizedTransaction)},
+		{valuetransfers.Tangle.Events.Fork, events.NewClosure(func(cachedTransaction *transaction.CachedTransaction, cachedTransactionMetadata *valuetangle.CachedTransactionMetadata, cachedBranch *branchmanager.CachedBranch, _ []transaction.OutputID) {
+			cachedTransactionMetadata.Release()
+			defer cachedBranch.Release()
+			publishTransaction(TopicFork, cachedTransaction, func(tx *Transaction) {
+				if branch := cachedBranch.Unwrap(); branch != nil {
+					tx.BranchID = branch.ID().String()
+				}
+			})
+		})},
+		{valuetransfers.Tangle.BranchManager().Events.BranchPreferred, events.NewClosure(func(cachedBranch *branchmanager.CachedBranch) {
+			publishBranch(TopicBranchPreferred, cachedBranch)
+		})},
+		{valuetransfers.Tangle.BranchManager().Events.BranchLiked, events.NewClosure(func(cachedBranch *branchmanager.CachedBranch) {
+			publishBranch(TopicBranchLiked, cachedBranch)
+		})},
+		{valuetransfers.Tangle.BranchManager().Events.BranchDisliked, events.NewClosure(func(cachedBranch *branchmanager.CachedBranch) {
+			publishBranch(TopicBranchDisliked, cachedBranch)
+		})},
+	}
+}
+
+func publishMessage(topic string, cachedMessage *message.CachedMessage, cachedMessageMetadata *tangle.CachedMessageMetadata) {
+	cachedMessageMetadata.Release()
+	defer cachedMessage.Release()
+	if !hasSubscribers(topic) {
+		return
+	}
+
+	msg := cachedMessage.Unwrap()
+	if msg == nil {
+		return
+	}
+
+	payloadType := msg.Payload().Type()
+	event := &Event{
+		Topic:     topic,
+		Timestamp: time.Now().Unix(),
+		Message: &Message{
+			ID:              msg.Id().String(),
+			TrunkID:         msg.TrunkId().String(),
+			BranchID:        msg.BranchId().String(),
+			IssuerPublicKey: msg.IssuerPublicKey().String(),
+			IssuingTime:     msg.IssuingTime().Unix(),
+			PayloadType:     payloadType,
+		},
+		payloadType:  &payloadType,
+		hasAddresses: true,
+	}
+	if valuePayload, ok := msg.Payload().(*valuepayload.Payload); ok {
+		event.addresses = transactionAddresses(valuePayload.Transaction())
+		event.Message.Addresses = event.addresses
+	}
+
+	publish(event)
+}
+
+func publishTip(topic string, messageID message.Id) {
+	if !hasSubscribers(topic) {
+		return
+	}
+
+	publish(&Event{
+		Topic:     topic,
+		Timestamp: time.Now().Unix(),
+		Tip:       messageID.String(),
+	})
+}
+
+func publishPayload(topic string, cachedPayload *valuepayload.CachedPayload) {
+	defer cachedPayload.Release()
+	if !hasSubscribers(topic) {
+		return
+	}
+
+	valuePayload := cachedPayload.Unwrap()
+	if valuePayload == nil {
+		return
+	}
+
+	publish(newTransactionEvent(topic, valuePayload.Transaction(), func(tx *Transaction) {
+		tx.PayloadID = valuePayload.ID().String()
+	}))
+}
+
+func publishTransaction(topic string, cachedTransaction *transaction.CachedTransaction, modify func(tx *Transaction)) {
+	defer cachedTransaction.Release()
+	if !hasSubscribers(topic) {
+		return
+	}
+
+	tx := cachedTransaction.Unwrap()
+	if tx == nil {
+		return
+	}
+
+	publish(newTransactionEvent(topic, tx, modify))
+}
+
+func publishBranch(topic string, cachedBranch *branchmanager.CachedBranch) {
+	defer cachedBranch.Release()
+	if !hasSubscribers(topic) {
+		return
+	}
+
+	branch := cachedBranch.Unwrap()
+	if branch == nil {
+		return
+	}
+
+	parsedBranch := utils.ParseBranch(branch)
+	publish(&Event{
+		Topic:       topic,
+		Timestamp:   time.Now().Unix(),
+		Branch:      &parsedBranch,
+		payloadType: &valuePayloadType,
+	})
+}
+
+func publishFinalizedTransaction(id string, opinion vote.Opinion) {
+	if !hasSubscribers(TopicTransactionFinalized) {
+		return
+	}
+
+	transactionID, err := transaction.IDFromBase58(id)
+	if err != nil {
+		return
+	}
+
+	publishTransaction(TopicTransactionFinalized, valuetransfers.Tangle.Transaction(transactionID), func(tx *Transaction) {
+		tx.Opinion = opinion.String()
+	})
+}
+
+func newTransactionEvent(topic string, tx *transaction.Transaction, modify func(tx *Transaction)) *Event {
+	addresses := transactionAddresses(tx)
+	event := &Event{
+		Topic:     topic,
+		Timestamp: time.Now().Unix(),
+		Transaction: &Transaction{
+			ID:        tx.ID().String(),
+			Addresses: addresses,
+		},
+		payloadType:  &valuePayloadType,
+		addresses:    addresses,
+		hasAddresses: true,
+	}
+	if modify != nil {
+		modify(event.Transaction)
+	}
+
+	return event
+}
+
+// transactionAddresses returns the addresses of the inputs and outputs of the given transaction.
+func transactionAddresses(tx *transaction.Transaction) (addresses []string) {
+	seen := make(map[address.Address]bool)
+	add := func(addr address.Address) bool {
+		if !seen[addr] {
+			seen[addr] = true
+			addresses = append(addresses, addr.String())
+		}
+		return true
+	}
+
+	tx.Inputs().ForEachAddress(add)
+	tx.Outputs().ForEach(func(addr address.Address, _ []*balance.Balance) bool {
+		return add(addr)
+	})
+
+	return
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+// subscriberBufferSize is the number of events buffered per subscriber before events get dropped.
+const subscriberBufferSize = 500
+
+var (
+	subscribers      = make(map[uint64]*subscriber)
+	subscribersMutex sync.RWMutex
+	nextSubscriberID uint64
+)
+
+// subscriber is a connected client with its subscribed topics and filters.
+type subscriber struct {
+	topics       map[string]bool
+	payloadTypes map[payload.Type]bool
+	addresses    map[string]bool
+	events       chan *Event
+}
+
+// matches returns true if the event belongs to a subscribed topic and passes the filters of the subscriber.
+// The filters only apply to the events that carry the respective information.
+func (s *subscriber) matches(event *Event) bool {
+	if !s.topics[event.Topic] {
+		return false
+	}
+	if len(s.payloadTypes) > 0 && event.payloadType != nil && !s.payloadTypes[*event.payloadType] {
+		return false
+	}
+	if len(s.addresses) > 0 && event.hasAddresses {
+		for _, address := range event.addresses {
+			if s.addresses[address] {
+				return true
+			}
+		}
+		return false
+	}
+
+	return true
+}
+
+func registerSubscriber(s *subscriber) uint64 {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+
+	id := nextSubscriberID
+	nextSubscriberID++
+	subscribers[id] = s
+
+	return id
+}
+
+func removeSubscriber(id uint64) {
+	subscribersMutex.Lock()
+	defer subscribersMutex.Unlock()
+
+	delete(subscribers, id)
+}
+
+// hasSubscribers returns true if any client subscribed to the given topic.
+func hasSubscribers(topic string) bool {
+	subscribersMutex.RLock()
+	defer subscribersMutex.RUnlock()
+
+	for _, s := range subscribers {
+		if s.topics[topic] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// publish sends the event to all matching subscribers. Events are dropped for slow subscribers.
+func publish(event *Event) {
+	subscribersMutex.RLock()
+	defer subscribersMutex.RUnlock()
+
+	for _, s := range subscribers {
+		if !s.matches(event) {
+			continue
+		}
+		select {
+		case s.events <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/goshimmer/packages/binary/messagelayer/payload"
+)
+
+func newTestSubscriber(t *testing.T, query map[string][]string) *subscriber {
+	s, err := parseSubscription(query)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestSubscriber_Matches(t *testing.T) {
+	dataType := payload.DataType
+	otherType := payload.Type(42)
+	addr := "addr"
+
+	s := &subscriber{
+		topics:       map[string]bool{TopicMessageAttached: true, TopicSyncStatus: true},
+		payloadTypes: map[payload.Type]bool{dataType: true},
+		addresses:    map[string]bool{addr: true},
+	}
+
+	// the topic must be subscribed
+	assert.False(t, s.matches(&Event{Topic: TopicMessageSolid, payloadType: &dataType}))
+
+	// the filters only apply to the events carrying the respective information
+	assert.True(t, s.matches(&Event{Topic: TopicSyncStatus}))
+	assert.True(t, s.matches(&Event{Topic: TopicMessageAttached, payloadType: &dataType, addresses: []string{"other", addr}, hasAddresses: true}))
+	assert.False(t, s.matches(&Event{Topic: TopicMessageAttached, payloadType: &otherType, addresses: []string{addr}, hasAddresses: true}))
+	assert.False(t, s.matches(&Event{Topic: TopicMessageAttached, payloadType: &dataType, hasAddresses: true}))
+}
+
+func TestPublish(t *testing.T) {
+	solidSubscriber := newTestSubscriber(t, map[string][]string{QueryTopics: {TopicMessageSolid}})
+	syncSubscriber := newTestSubscriber(t, map[string][]string{QueryTopics: {TopicSyncStatus}})
+	solidID := registerSubscriber(solidSubscriber)
+	syncID := registerSubscriber(syncSubscriber)
+	defer removeSubscriber(syncID)
+
+	assert.True(t, hasSubscribers(TopicMessageSolid))
+	assert.False(t, hasSubscribers(TopicFork))
+
+	// the events are only delivered to the matching subscribers
+	event := &Event{Topic: TopicMessageSolid}
+	publish(event)
+	assert.Equal(t, event, <-solidSubscriber.events)
+	assert.Empty(t, syncSubscriber.events)
+
+	// events are dropped instead of blocking if a subscriber does not keep up
+	for i := 0; i < subscriberBufferSize+1; i++ {
+		publish(event)
+	}
+	assert.Len(t, solidSubscriber.events, subscriberBufferSize)
+
+	// removed subscribers do not receive events anymore
+	removeSubscriber(solidID)
+	assert.False(t, hasSubscribers(TopicMessageSolid))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/iotaledger/goshimmer/plugins/banner"
 	"github.com/iotaledger/goshimmer/plugins/sync"
 	"github.com/iotaledger/goshimmer/plugins/webapi"
+	webapi_sync "github.com/iotaledger/goshimmer/plugins/webapi/sync"
 	"github.com/iotaledger/hive.go/node"
 	"github.com/labstack/echo"
 )
@@ -26,6 +27,15 @@ func configure(_ *node.Plugin) {
 // {
 // 	"version":"v0.2.0",
 //  "synchronized": true,
+//  "syncProgress": {
+//  	"synced": true,
+//  	"anchorPoints": 0,
+//  	"collectedAnchorPoints": 0,
+//  	"solidAnchorPoints": 0,
+//  	"missingMessages": 0,
+//  	"solidificationRate": 12.3,
+//  	"estimatedRemainingSec": 0
+//  },
 // 	"identityID":"5bf4aa1d6c47e4ce",
 // 	"publickey":"CjUsn86jpFHWnSCx3NhWfU4Lk16mDdy1Hr7ERSTv3xn9",
 // 	"enabledplugins":[
@@ -73,6 +83,7 @@ func getInfo(c echo.Context) error {
 	return c.JSON(http.StatusOK, Response{
 		Version:         banner.AppVersion,
 		Synced:          sync.Synced(),
+		SyncProgress:    webapi_sync.CurrentProgress(),
 		IdentityID:      local.GetInstance().Identity.ID().String(),
 		PublicKey:       local.GetInstance().PublicKey().String(),
 		EnabledPlugins:  enabledPlugins,
@@ -86,6 +97,8 @@ type Response struct {
 	Version string `json:"version,omitempty"`
 	// whether the node is synchronized
 	Synced bool `json:"synced"`
+	// progress of the synchronization
+	SyncProgress webapi_sync.Progress `json:"syncProgress"`
 	// identity ID of the node encoded in hex and truncated to its first 8 bytes
 	IdentityID string `json:"identityID,omitempty"`
 	// public key of the node encoded in base58
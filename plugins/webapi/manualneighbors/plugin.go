@@ -0,0 +1,119 @@
+package manualneighbors
+
+import (
+	"encoding/base64"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/autopeering/peer/service"
+	"github.com/iotaledger/hive.go/identity"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+// PluginName is the name of the web API manual neighbors endpoint plugin.
+const PluginName = "WebAPI manual neighbors Endpoint"
+
+// Plugin is the plugin instance of the web API manual neighbors endpoint plugin.
+var Plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+
+func configure(plugin *node.Plugin) {
+	webapi.Server.GET("gossip/manualNeighbors", getManualNeighbors)
+	webapi.Server.POST("gossip/manualNeighbors", addManualNeighbors)
+	webapi.Server.DELETE("gossip/manualNeighbors", removeManualNeighbors)
+}
+
+// getManualNeighbors returns the manual neighbors and whether they are currently connected.
+func getManualNeighbors(c echo.Context) error {
+	neighbors := []Neighbor{}
+	for p, connected := range gossip.ManualNeighbors() {
+		gossipService := p.Services().Get(service.GossipKey)
+		neighbors = append(neighbors, Neighbor{
+			ID:        p.ID().String(),
+			PublicKey: base64.StdEncoding.EncodeToString(p.PublicKey().Bytes()),
+			Address:   net.JoinHostPort(p.IP().String(), strconv.Itoa(gossipService.Port())),
+			Connected: connected,
+		})
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].ID < neighbors[j].ID })
+
+	return c.JSON(http.StatusOK, Response{Neighbors: neighbors})
+}
+
+// addManualNeighbors adds the given manual neighbors.
+// Either all neighbors are added or none of them in case of a parsing error.
+func addManualNeighbors(c echo.Context) error {
+	var request AddRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	for _, definition := range request.Neighbors {
+		if _, err := gossip.ParseManualNeighbor(definition); err != nil {
+			return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+		}
+	}
+	for _, definition := range request.Neighbors {
+		p, _ := gossip.ParseManualNeighbor(definition)
+		if err := gossip.AddManualNeighbor(p); err != nil && err != gossip.ErrManualNeighborExists {
+			return c.JSON(http.StatusInternalServerError, Response{Error: err.Error()})
+		}
+	}
+
+	return getManualNeighbors(c)
+}
+
+// removeManualNeighbors removes the manual neighbors with the given public keys and drops their connections.
+func removeManualNeighbors(c echo.Context) error {
+	var request RemoveRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	ids := make([]identity.ID, 0, len(request.PublicKeys))
+	for _, encodedPublicKey := range request.PublicKeys {
+		publicKey, err := gossip.ParsePublicKey(encodedPublicKey)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+		}
+		ids = append(ids, identity.NewID(publicKey))
+	}
+	for _, id := range ids {
+		if err := gossip.RemoveManualNeighbor(id); err != nil {
+			if err == gossip.ErrUnknownManualNeighbor {
+				return c.JSON(http.StatusNotFound, Response{Error: err.Error()})
+			}
+			return c.JSON(http.StatusInternalServerError, Response{Error: err.Error()})
+		}
+	}
+
+	return getManualNeighbors(c)
+}
+
+// AddRequest contains the manual neighbors to add in the form publicKey@host:port.
+type AddRequest struct {
+	Neighbors []string `json:"neighbors"`
+}
+
+// RemoveRequest contains the base64 encoded public keys of the manual neighbors to remove.
+type RemoveRequest struct {
+	PublicKeys []string `json:"publicKeys"`
+}
+
+// Response contains the manual neighbors of the node.
+type Response struct {
+	Neighbors []Neighbor `json:"neighbors"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Neighbor contains information of a manual neighbor.
+type Neighbor struct {
+	ID        string `json:"id"`
+	PublicKey string `json:"publicKey"`
+	Address   string `json:"address"`
+	Connected bool   `json:"connected"`
+}
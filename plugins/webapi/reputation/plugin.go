@@ -0,0 +1,73 @@
+package reputation
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/plugins/gossip"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+// PluginName is the name of the web API reputation endpoint plugin.
+const PluginName = "WebAPI reputation Endpoint"
+
+// Plugin is the plugin instance of the web API reputation endpoint plugin.
+var Plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+
+func configure(plugin *node.Plugin) {
+	webapi.Server.GET("gossip/reputation", getReputation)
+}
+
+// getReputation returns the scores of the neighbors and the currently blacklisted peers.
+func getReputation(c echo.Context) error {
+	rep := gossip.Reputation()
+
+	scores := []Score{}
+	for id, score := range rep.Scores() {
+		scores = append(scores, Score{
+			ID:         id.String(),
+			Score:      score.Value,
+			Useful:     score.Useful,
+			Duplicate:  score.Duplicate,
+			Invalid:    score.Invalid,
+			Unanswered: score.Unanswered,
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].ID < scores[j].ID })
+
+	blacklisted := []BlacklistedPeer{}
+	for id, until := range rep.Blacklist() {
+		blacklisted = append(blacklisted, BlacklistedPeer{
+			ID:    id.String(),
+			Until: until.Unix(),
+		})
+	}
+	sort.Slice(blacklisted, func(i, j int) bool { return blacklisted[i].ID < blacklisted[j].ID })
+
+	return c.JSON(http.StatusOK, Response{Neighbors: scores, Blacklisted: blacklisted})
+}
+
+// Response contains the reputation of the neighbors.
+type Response struct {
+	Neighbors   []Score           `json:"neighbors"`
+	Blacklisted []BlacklistedPeer `json:"blacklisted"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// Score contains the reputation of a single neighbor.
+type Score struct {
+	ID         string  `json:"id"`
+	Score      float64 `json:"score"`
+	Useful     uint64  `json:"useful"`
+	Duplicate  uint64  `json:"duplicate"`
+	Invalid    uint64  `json:"invalid"`
+	Unanswered uint64  `json:"unanswered"`
+}
+
+// BlacklistedPeer contains a blacklisted peer and the unix time its blacklisting ends.
+type BlacklistedPeer struct {
+	ID    string `json:"id"`
+	Until int64  `json:"until"`
+}
@@ -0,0 +1,76 @@
+package sync
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/plugins/sync"
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/hive.go/node"
+	"github.com/labstack/echo"
+)
+
+// PluginName is the name of the web API sync endpoint plugin.
+const PluginName = "WebAPI sync Endpoint"
+
+// Plugin is the plugin instance of the web API sync endpoint plugin.
+var Plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+
+func configure(_ *node.Plugin) {
+	webapi.Server.GET("sync", getSyncProgress)
+}
+
+// getSyncProgress returns the synchronization progress of the node.
+// e.g.,
+//
+//	{
+//		"synced": false,
+//		"anchorPoints": 3,
+//		"collectedAnchorPoints": 3,
+//		"solidAnchorPoints": 1,
+//		"missingMessages": 120,
+//		"solidificationRate": 40.5,
+//		"estimatedRemainingSec": 3
+//	}
+func getSyncProgress(c echo.Context) error {
+	return c.JSON(http.StatusOK, Response{Progress: CurrentProgress()})
+}
+
+// CurrentProgress returns the current synchronization progress of the node.
+func CurrentProgress() Progress {
+	progress := sync.SyncProgress()
+
+	return Progress{
+		Synced:                progress.Synced,
+		AnchorPoints:          progress.AnchorPoints,
+		CollectedAnchorPoints: progress.CollectedAnchorPoints,
+		SolidAnchorPoints:     progress.SolidAnchorPoints,
+		MissingMessages:       progress.MissingMessages,
+		SolidificationRate:    progress.SolidificationRate,
+		EstimatedRemainingSec: int64(progress.EstimatedRemaining.Seconds()),
+	}
+}
+
+// Response holds the response of the GET request.
+type Response struct {
+	Progress
+	// error of the response
+	Error string `json:"error,omitempty"`
+}
+
+// Progress contains the synchronization progress of the node.
+type Progress struct {
+	// whether the node is synchronized
+	Synced bool `json:"synced"`
+	// amount of anchor points which need to become solid, zero if the node is synced
+	AnchorPoints int `json:"anchorPoints"`
+	// amount of anchor points collected so far
+	CollectedAnchorPoints int `json:"collectedAnchorPoints"`
+	// amount of anchor points which became solid
+	SolidAnchorPoints int `json:"solidAnchorPoints"`
+	// amount of missing messages currently requested from the neighbors
+	MissingMessages int `json:"missingMessages"`
+	// amount of messages which became solid per second
+	SolidificationRate float64 `json:"solidificationRate"`
+	// estimated seconds until the missing messages are solidified, zero if unknown
+	EstimatedRemainingSec int64 `json:"estimatedRemainingSec"`
+}
@@ -0,0 +1,105 @@
+package addresshistory
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+const (
+	// DefaultLimit is the number of history entries returned if no limit is given.
+	DefaultLimit = 100
+	// MaxLimit is the maximum number of history entries returned by a single request.
+	MaxLimit = 1000
+)
+
+// Handler gets the transactions that spent from or created outputs on the given address ordered by the time they were
+// booked. The result is paginated using the offset and limit query parameters.
+func Handler(c echo.Context) error {
+	addr, err := address.FromBase58(c.QueryParam("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+	offset, err := parseQueryInt(c, "offset", 0)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+	limit, err := parseQueryInt(c, "limit", DefaultLimit)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+	if limit == 0 || limit > MaxLimit {
+		return c.JSON(http.StatusBadRequest, Response{Error: fmt.Sprintf("limit must be between 1 and %d", MaxLimit)})
+	}
+
+	history, total := valuetransfers.LedgerState.AddressHistory(addr, offset, limit)
+	entries := make([]Entry, len(history))
+	for i, addressTransaction := range history {
+		inclusionState, exists := utils.TransactionInclusionState(addressTransaction.TransactionID())
+		if !exists {
+			// the transactions of the snapshot have no metadata and are part of the master branch
+			inclusionState = utils.InclusionState{
+				Solid:     true,
+				Preferred: true,
+				Liked:     true,
+				Finalized: true,
+				BranchID:  branchmanager.MasterBranchID.String(),
+			}
+		}
+
+		entries[i] = Entry{
+			TransactionID:  addressTransaction.TransactionID().String(),
+			Timestamp:      addressTransaction.Timestamp().Unix(),
+			Spent:          addressTransaction.Spent(),
+			Created:        addressTransaction.Created(),
+			InclusionState: inclusionState,
+		}
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		Address: addr.String(),
+		Total:   total,
+		Offset:  offset,
+		History: entries,
+	})
+}
+
+// parseQueryInt parses the non-negative integer of the given query parameter or returns the default value if the
+// parameter is not set.
+func parseQueryInt(c echo.Context, name string, defaultValue int) (int, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	result, err := strconv.Atoi(value)
+	if err != nil || result < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", name, value)
+	}
+
+	return result, nil
+}
+
+// Response is the HTTP response from retrieving the history of an address.
+type Response struct {
+	Address string  `json:"address,omitempty"`
+	Total   int     `json:"total"`
+	Offset  int     `json:"offset"`
+	History []Entry `json:"history,omitempty"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Entry is a transaction in the history of an address.
+type Entry struct {
+	TransactionID  string               `json:"transactionID"`
+	Timestamp      int64                `json:"timestamp"`
+	Spent          bool                 `json:"spent"`
+	Created        bool                 `json:"created"`
+	InclusionState utils.InclusionState `json:"inclusionState"`
+}
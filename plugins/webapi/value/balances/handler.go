@@ -0,0 +1,38 @@
+package balances
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+// Handler gets the colored balances of the given address.
+func Handler(c echo.Context) error {
+	addr, err := address.FromBase58(c.QueryParam("address"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	coloredBalances := make([]utils.Balance, 0)
+	for color, value := range valuetransfers.LedgerState.Balances(addr) {
+		coloredBalances = append(coloredBalances, utils.Balance{
+			Value: value,
+			Color: color.String(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, Response{
+		Address:  addr.String(),
+		Balances: coloredBalances,
+	})
+}
+
+// Response is the HTTP response from retrieving the balances of an address.
+type Response struct {
+	Address  string          `json:"address,omitempty"`
+	Balances []utils.Balance `json:"balances,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
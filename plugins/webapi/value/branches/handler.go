@@ -0,0 +1,87 @@
+package branches
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+// Handler gets the branch with the given id together with its children and, for aggregated branches, the conflict
+// branches it consists of.
+func Handler(c echo.Context) error {
+	branchID, err := branchmanager.BranchIDFromBase58(c.QueryParam("branchID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	branchManager := valuetransfers.Tangle.BranchManager()
+	cachedBranch := branchManager.Branch(branchID)
+	defer cachedBranch.Release()
+	branch := cachedBranch.Unwrap()
+	if branch == nil {
+		return c.JSON(http.StatusNotFound, Response{Error: "branch not found"})
+	}
+
+	children := make([]string, 0)
+	branchManager.ChildBranches(branchID).Consume(func(childBranch *branchmanager.ChildBranch) {
+		children = append(children, childBranch.ChildID().String())
+	})
+	sort.Strings(children)
+
+	constituentIDs, err := branchManager.AggregatedBranchConstituents(branchID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, Response{Error: err.Error()})
+	}
+	constituents := make([]string, len(constituentIDs))
+	for i, constituentID := range constituentIDs {
+		constituents[i] = constituentID.String()
+	}
+	sort.Strings(constituents)
+
+	parsedBranch := utils.ParseBranch(branch)
+
+	return c.JSON(http.StatusOK, Response{
+		Branch:             &parsedBranch,
+		Children:           children,
+		AggregatedBranches: constituents,
+	})
+}
+
+// TransactionsHandler gets the ids of the transactions that are booked into the branch with the given id.
+func TransactionsHandler(c echo.Context) error {
+	branchID, err := branchmanager.BranchIDFromBase58(c.QueryParam("branchID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, TransactionsResponse{Error: err.Error()})
+	}
+
+	if !valuetransfers.Tangle.BranchManager().Branch(branchID).Consume(func(*branchmanager.Branch) {}) {
+		return c.JSON(http.StatusNotFound, TransactionsResponse{Error: "branch not found"})
+	}
+
+	transactionIDs := valuetransfers.Tangle.TransactionsInBranch(branchID)
+	transactions := make([]string, len(transactionIDs))
+	for i, transactionID := range transactionIDs {
+		transactions[i] = transactionID.String()
+	}
+	sort.Strings(transactions)
+
+	return c.JSON(http.StatusOK, TransactionsResponse{TransactionIDs: transactions})
+}
+
+// Response is the HTTP response from retrieving a branch.
+type Response struct {
+	Branch             *utils.Branch `json:"branch,omitempty"`
+	Children           []string      `json:"children,omitempty"`
+	AggregatedBranches []string      `json:"aggregatedBranches,omitempty"`
+	Error              string        `json:"error,omitempty"`
+}
+
+// TransactionsResponse is the HTTP response from retrieving the transactions of a branch.
+type TransactionsResponse struct {
+	TransactionIDs []string `json:"transactionIDs,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
@@ -0,0 +1,61 @@
+package colors
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/labstack/echo"
+)
+
+// Handler gets the minting information and the supply of all colors or of the color given in the query parameter.
+func Handler(c echo.Context) error {
+	var colorSupplies []*tangle.ColorSupply
+	if colorParam := c.QueryParam("color"); colorParam != "" {
+		color, err := balance.ColorFromBase58(colorParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+		}
+
+		colorSupply, exists := valuetransfers.LedgerState.ColorSupply(color)
+		if !exists {
+			return c.JSON(http.StatusNotFound, Response{Error: "color not found"})
+		}
+		colorSupplies = append(colorSupplies, colorSupply)
+	} else {
+		for _, colorSupply := range valuetransfers.LedgerState.ColorSupplies() {
+			colorSupplies = append(colorSupplies, colorSupply)
+		}
+	}
+
+	colors := make([]Color, len(colorSupplies))
+	for i, colorSupply := range colorSupplies {
+		colors[i] = Color{
+			Color:                colorSupply.Color.String(),
+			MintingTransactionID: colorSupply.MintingTransactionID.String(),
+			InitialSupply:        colorSupply.InitialSupply,
+			CirculatingSupply:    colorSupply.CirculatingSupply,
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		return colors[i].Color < colors[j].Color
+	})
+
+	return c.JSON(http.StatusOK, Response{Colors: colors})
+}
+
+// Response is the HTTP response from retrieving the colors.
+type Response struct {
+	Colors []Color `json:"colors,omitempty"`
+	Error  string  `json:"error,omitempty"`
+}
+
+// Color holds the minting information and the supply of a color.
+type Color struct {
+	Color                string `json:"color"`
+	MintingTransactionID string `json:"mintingTransactionID"`
+	InitialSupply        int64  `json:"initialSupply"`
+	CirculatingSupply    int64  `json:"circulatingSupply"`
+}
@@ -0,0 +1,77 @@
+package conflicts
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+// Handler gets all conflicts or the conflict given in the query parameter together with their member branches.
+func Handler(c echo.Context) error {
+	branchManager := valuetransfers.Tangle.BranchManager()
+
+	var conflictIDs []branchmanager.ConflictID
+	if conflictParam := c.QueryParam("conflictID"); conflictParam != "" {
+		conflictID, err := transaction.OutputIDFromBase58(conflictParam)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+		}
+		if !branchManager.Conflict(conflictID).Consume(func(*branchmanager.Conflict) {}) {
+			return c.JSON(http.StatusNotFound, Response{Error: "conflict not found"})
+		}
+		conflictIDs = append(conflictIDs, conflictID)
+	} else {
+		branchManager.ForEachConflict(func(cachedConflict *branchmanager.CachedConflict) bool {
+			cachedConflict.Consume(func(conflict *branchmanager.Conflict) {
+				conflictIDs = append(conflictIDs, conflict.ID())
+			})
+
+			return true
+		})
+	}
+
+	conflicts := make([]Conflict, 0, len(conflictIDs))
+	for _, conflictID := range conflictIDs {
+		conflicts = append(conflicts, parseConflict(branchManager, conflictID))
+	}
+	sort.Slice(conflicts, func(i, j int) bool {
+		return conflicts[i].ID < conflicts[j].ID
+	})
+
+	return c.JSON(http.StatusOK, Response{Conflicts: conflicts})
+}
+
+// parseConflict converts the conflict with the given id and its member branches into their JSON representation.
+func parseConflict(branchManager *branchmanager.BranchManager, conflictID branchmanager.ConflictID) Conflict {
+	result := Conflict{
+		ID:      conflictID.String(),
+		Members: make([]utils.Branch, 0),
+	}
+	branchManager.ConflictMembers(conflictID).Consume(func(conflictMember *branchmanager.ConflictMember) {
+		branchManager.Branch(conflictMember.BranchID()).Consume(func(branch *branchmanager.Branch) {
+			result.Members = append(result.Members, utils.ParseBranch(branch))
+		})
+	})
+	sort.Slice(result.Members, func(i, j int) bool {
+		return result.Members[i].ID < result.Members[j].ID
+	})
+
+	return result
+}
+
+// Response is the HTTP response from retrieving the conflicts.
+type Response struct {
+	Conflicts []Conflict `json:"conflicts,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Conflict holds the information of a conflict and its member branches.
+type Conflict struct {
+	ID      string         `json:"id"`
+	Members []utils.Branch `json:"members"`
+}
@@ -0,0 +1,44 @@
+package gettransactionbyid
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+// Handler gets the transaction and its inclusion state by the given transaction id.
+func Handler(c echo.Context) error {
+	txnID, err := transaction.IDFromBase58(c.QueryParam("txnID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	cachedTxnObj := valuetransfers.Tangle.Transaction(txnID)
+	defer cachedTxnObj.Release()
+	txn := cachedTxnObj.Unwrap()
+	if txn == nil {
+		return c.JSON(http.StatusNotFound, Response{Error: "transaction not found"})
+	}
+
+	inclusionState, exists := utils.TransactionInclusionState(txnID)
+	if !exists {
+		return c.JSON(http.StatusNotFound, Response{Error: "transaction metadata not found"})
+	}
+
+	parsedTxn := utils.ParseTransaction(txn)
+
+	return c.JSON(http.StatusOK, Response{
+		Transaction:    &parsedTxn,
+		InclusionState: &inclusionState,
+	})
+}
+
+// Response is the HTTP response from retrieving a transaction.
+type Response struct {
+	Transaction    *utils.Transaction    `json:"transaction,omitempty"`
+	InclusionState *utils.InclusionState `json:"inclusionState,omitempty"`
+	Error          string                `json:"error,omitempty"`
+}
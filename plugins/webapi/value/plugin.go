@@ -0,0 +1,34 @@
+package value
+
+import (
+	"github.com/iotaledger/goshimmer/plugins/webapi"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/addresshistory"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/balances"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/branches"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/colors"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/conflicts"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/gettransactionbyid"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/sendtransaction"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/unspentoutputs"
+	"github.com/iotaledger/hive.go/node"
+)
+
+// PluginName is the name of the web API value endpoint plugin.
+const PluginName = "WebAPI Value Endpoint"
+
+var (
+	// Plugin is the plugin instance of the web API value endpoint plugin.
+	Plugin = node.NewPlugin(PluginName, node.Enabled, configure)
+)
+
+func configure(_ *node.Plugin) {
+	webapi.Server.POST("value/sendTransaction", sendtransaction.Handler)
+	webapi.Server.POST("value/unspentOutputs", unspentoutputs.Handler)
+	webapi.Server.GET("value/balances", balances.Handler)
+	webapi.Server.GET("value/addressHistory", addresshistory.Handler)
+	webapi.Server.GET("value/transactionByID", gettransactionbyid.Handler)
+	webapi.Server.GET("value/colors", colors.Handler)
+	webapi.Server.GET("value/conflicts", conflicts.Handler)
+	webapi.Server.GET("value/branch", branches.Handler)
+	webapi.Server.GET("value/branch/transactions", branches.TransactionsHandler)
+}
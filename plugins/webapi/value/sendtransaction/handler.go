@@ -0,0 +1,48 @@
+package sendtransaction
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	"github.com/iotaledger/goshimmer/plugins/issuer"
+	"github.com/labstack/echo"
+)
+
+// Handler issues a value object containing the given signed transaction.
+func Handler(c echo.Context) error {
+	var request Request
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	// parse tx
+	tx, _, err := transaction.FromBytes(request.TransactionBytes)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	// check the signatures before we issue the transaction (taking the conditions of the consumed outputs into account)
+	if !valuetransfers.Tangle.SignaturesValid(tx) {
+		return c.JSON(http.StatusBadRequest, Response{Error: "invalid signature"})
+	}
+
+	// issue the value object containing the transaction
+	valueObject := valuetransfers.ValueObjectFactory().IssueTransaction(tx)
+	if _, err = issuer.IssuePayload(valueObject); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, Response{TransactionID: tx.ID().String()})
+}
+
+// Request holds the transaction object (bytes) to send.
+type Request struct {
+	TransactionBytes []byte `json:"txnBytes"`
+}
+
+// Response is the HTTP response from sending a transaction.
+type Response struct {
+	TransactionID string `json:"transactionID,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
@@ -0,0 +1,75 @@
+package unspentoutputs
+
+import (
+	"net/http"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/plugins/webapi/value/utils"
+	"github.com/labstack/echo"
+)
+
+// Handler gets the unspent outputs of the given addresses.
+func Handler(c echo.Context) error {
+	var request Request
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+	}
+
+	var unspents []UnspentOutput
+	for _, strAddress := range request.Addresses {
+		outputAddress, err := address.FromBase58(strAddress)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, Response{Error: err.Error()})
+		}
+
+		outputIDs := make([]OutputID, 0)
+		valuetransfers.Tangle.OutputsOnAddress(outputAddress).Consume(func(output *tangle.Output) {
+			// skip outputs that have been spent already
+			if output.ConsumerCount() != 0 {
+				return
+			}
+
+			outputIDs = append(outputIDs, OutputID{
+				ID:             output.ID().String(),
+				Balances:       utils.ParseBalances(output.Balances()),
+				Conditions:     utils.ParseOutputConditions(output.Conditions()),
+				InclusionState: utils.OutputInclusionState(output),
+			})
+		})
+
+		unspents = append(unspents, UnspentOutput{
+			Address:   strAddress,
+			OutputIDs: outputIDs,
+		})
+	}
+
+	return c.JSON(http.StatusOK, Response{UnspentOutputs: unspents})
+}
+
+// Request holds the addresses to query.
+type Request struct {
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// Response is the HTTP response from retrieving the unspent outputs of addresses.
+type Response struct {
+	UnspentOutputs []UnspentOutput `json:"unspentOutputs,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// UnspentOutput holds the address and the corresponding unspent output ids.
+type UnspentOutput struct {
+	Address   string     `json:"address"`
+	OutputIDs []OutputID `json:"outputIDs"`
+}
+
+// OutputID holds the output id, its balances, its conditions and the inclusion state of the transaction that created
+// it.
+type OutputID struct {
+	ID             string                  `json:"id"`
+	Balances       []utils.Balance         `json:"balances"`
+	Conditions     *utils.OutputConditions `json:"conditions,omitempty"`
+	InclusionState utils.InclusionState    `json:"inclusionState"`
+}
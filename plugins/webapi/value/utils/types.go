@@ -0,0 +1,198 @@
+package utils
+
+import (
+	"sort"
+	"time"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/branchmanager"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+)
+
+// Transaction holds the information of a transaction.
+type Transaction struct {
+	Inputs      []string `json:"inputs"`
+	Outputs     []Output `json:"outputs"`
+	Signature   []byte   `json:"signature"`
+	DataPayload []byte   `json:"dataPayload"`
+}
+
+// Output consists of an address, its balances and the optional conditions that restrict the spending of the output.
+type Output struct {
+	Address    string            `json:"address"`
+	Balances   []Balance         `json:"balances"`
+	Conditions *OutputConditions `json:"conditions,omitempty"`
+}
+
+// OutputConditions holds the timelock and the expiry of an output. Times are given as unix timestamps in seconds and
+// omitted if the output does not have the corresponding condition.
+type OutputConditions struct {
+	Timelock        int64  `json:"timelock,omitempty"`
+	Expiry          int64  `json:"expiry,omitempty"`
+	FallbackAddress string `json:"fallbackAddress,omitempty"`
+}
+
+// Balance holds the value and the color of a token.
+type Balance struct {
+	Value int64  `json:"value"`
+	Color string `json:"color"`
+}
+
+// InclusionState represents the different states of a transaction in the value tangle.
+type InclusionState struct {
+	Solid       bool   `json:"solid"`
+	Conflicting bool   `json:"conflicting"`
+	Preferred   bool   `json:"preferred"`
+	Liked       bool   `json:"liked"`
+	Finalized   bool   `json:"finalized"`
+	BranchID    string `json:"branchID,omitempty"`
+}
+
+// Branch holds the information of a branch of the value tangle.
+type Branch struct {
+	ID         string   `json:"id"`
+	Parents    []string `json:"parents"`
+	Conflicts  []string `json:"conflicts"`
+	Aggregated bool     `json:"aggregated"`
+	Preferred  bool     `json:"preferred"`
+	Liked      bool     `json:"liked"`
+	Finalized  bool     `json:"finalized"`
+	Confirmed  bool     `json:"confirmed"`
+}
+
+// ParseTransaction converts a transaction into its JSON representation.
+func ParseTransaction(tx *transaction.Transaction) (result Transaction) {
+	tx.Inputs().ForEach(func(outputID transaction.OutputID) bool {
+		result.Inputs = append(result.Inputs, outputID.String())
+
+		return true
+	})
+
+	tx.Outputs().ForEach(func(address address.Address, balances []*balance.Balance) bool {
+		result.Outputs = append(result.Outputs, Output{
+			Address:    address.String(),
+			Balances:   ParseBalances(balances),
+			Conditions: ParseOutputConditions(tx.Outputs().Conditions(address)),
+		})
+
+		return true
+	})
+
+	result.Signature = tx.SignatureBytes()
+	result.DataPayload = tx.GetDataPayload()
+
+	return
+}
+
+// ParseBalances converts a list of balances into their JSON representation.
+func ParseBalances(balances []*balance.Balance) (result []Balance) {
+	result = make([]Balance, 0, len(balances))
+	for _, coloredBalance := range balances {
+		result = append(result, Balance{
+			Value: coloredBalance.Value(),
+			Color: coloredBalance.Color().String(),
+		})
+	}
+
+	return
+}
+
+// ParseOutputConditions converts the conditions of an output into their JSON representation. It returns nil if the
+// output has no conditions.
+func ParseOutputConditions(conditions *transaction.OutputConditions) *OutputConditions {
+	if conditions.Empty() {
+		return nil
+	}
+
+	result := &OutputConditions{}
+	if conditions.HasTimelock() {
+		result.Timelock = conditions.Timelock().Unix()
+	}
+	if conditions.HasExpiry() {
+		result.Expiry = conditions.Expiry().Unix()
+		result.FallbackAddress = conditions.FallbackAddress().String()
+	}
+
+	return result
+}
+
+// ToOutputConditions converts the JSON representation back into the conditions of an output. It returns nil if there
+// are no conditions. Since the conditions of outputs have the precision of seconds, they are restored unchanged.
+func (conditions *OutputConditions) ToOutputConditions() (*transaction.OutputConditions, error) {
+	if conditions == nil || (conditions.Timelock == 0 && conditions.Expiry == 0) {
+		return nil, nil
+	}
+
+	result := transaction.NewOutputConditions()
+	if conditions.Timelock != 0 {
+		result.WithTimelock(time.Unix(conditions.Timelock, 0))
+	}
+	if conditions.Expiry != 0 {
+		fallbackAddress, err := address.FromBase58(conditions.FallbackAddress)
+		if err != nil {
+			return nil, err
+		}
+		result.WithExpiry(time.Unix(conditions.Expiry, 0), fallbackAddress)
+	}
+
+	return result, nil
+}
+
+// TransactionInclusionState retrieves the inclusion state of the transaction with the given id from the value tangle.
+// It returns false if the transaction is not known to the node.
+func TransactionInclusionState(transactionID transaction.ID) (inclusionState InclusionState, exists bool) {
+	valuetransfers.Tangle.TransactionMetadata(transactionID).Consume(func(metadata *tangle.TransactionMetadata) {
+		exists = true
+
+		inclusionState.Solid = metadata.Solid()
+		inclusionState.Conflicting = metadata.Conflicting()
+		inclusionState.Preferred = metadata.Preferred()
+		inclusionState.Finalized = metadata.Finalized()
+		inclusionState.BranchID = metadata.BranchID().String()
+		inclusionState.Liked = valuetransfers.Tangle.BranchManager().IsBranchLiked(metadata.BranchID())
+	})
+
+	return
+}
+
+// OutputInclusionState retrieves the inclusion state of the transaction that created the given output. Outputs that
+// were loaded from the snapshot have no transaction metadata and are considered to be part of the master branch.
+func OutputInclusionState(output *tangle.Output) InclusionState {
+	if inclusionState, exists := TransactionInclusionState(output.TransactionID()); exists {
+		return inclusionState
+	}
+
+	return InclusionState{
+		Solid:     output.Solid(),
+		Preferred: true,
+		Liked:     valuetransfers.Tangle.BranchManager().IsBranchLiked(output.BranchID()),
+		Finalized: true,
+		BranchID:  output.BranchID().String(),
+	}
+}
+
+// ParseBranch converts a branch into its JSON representation.
+func ParseBranch(branch *branchmanager.Branch) (result Branch) {
+	result = Branch{
+		ID:         branch.ID().String(),
+		Parents:    make([]string, 0),
+		Conflicts:  make([]string, 0),
+		Aggregated: branch.IsAggregated(),
+		Preferred:  branch.Preferred(),
+		Liked:      branch.Liked(),
+		Finalized:  branch.Finalized(),
+		Confirmed:  branch.Confirmed(),
+	}
+	for _, parentID := range branch.ParentBranches() {
+		result.Parents = append(result.Parents, parentID.String())
+	}
+	for conflictID := range branch.Conflicts() {
+		result.Conflicts = append(result.Conflicts, conflictID.String())
+	}
+	sort.Strings(result.Conflicts)
+
+	return
+}
@@ -0,0 +1,340 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/iotaledger/goshimmer/client"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/wallet"
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	// passwordEnvironmentVariable defines the environment variable that can be used to pass the password to the wallet
+	// without an interactive prompt.
+	passwordEnvironmentVariable = "CLI_WALLET_PASSWORD"
+
+	directionIncoming = "incoming"
+	directionOutgoing = "outgoing"
+	directionMint     = "mint"
+)
+
+func initCommand(args []string) error {
+	if err := flag.NewFlagSet("init", flag.ExitOnError).Parse(args); err != nil {
+		return err
+	}
+
+	if fileExists(*walletFileName) {
+		return fmt.Errorf("the wallet file %s exists already", *walletFileName)
+	}
+
+	password, err := readPassword(true)
+	if err != nil {
+		return err
+	}
+
+	seed := wallet.NewSeed()
+	walletFile := &WalletFile{}
+	if err := walletFile.encryptSeed(seed.Bytes(), password); err != nil {
+		return err
+	}
+	if err := walletFile.save(*walletFileName); err != nil {
+		return err
+	}
+
+	fmt.Printf("created new wallet in %s\n", *walletFileName)
+	fmt.Printf("receive address: %s\n", seed.Address(0))
+
+	return nil
+}
+
+func addressCommand(args []string) error {
+	flags := flag.NewFlagSet("address", flag.ExitOnError)
+	newAddress := flags.Bool("new", false, "generate a new receive address")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	return withWallet(func(w *wallet.Wallet, _ *WalletFile) error {
+		if *newAddress {
+			fmt.Printf("%d: %s\n", w.LastAddressIndex()+1, w.NewReceiveAddress())
+
+			return nil
+		}
+
+		for i, addr := range w.Addresses() {
+			fmt.Printf("%d: %s\n", i, addr)
+		}
+
+		return nil
+	})
+}
+
+func balanceCommand(args []string) error {
+	if err := flag.NewFlagSet("balance", flag.ExitOnError).Parse(args); err != nil {
+		return err
+	}
+
+	return withRefreshedWallet(func(w *wallet.Wallet, _ *WalletFile) error {
+		confirmedBalances, pendingBalances := w.Balances()
+		if len(confirmedBalances) == 0 && len(pendingBalances) == 0 {
+			fmt.Println("the wallet is empty")
+
+			return nil
+		}
+
+		fmt.Printf("%-44s %12s %12s\n", "COLOR", "CONFIRMED", "PENDING")
+		for _, color := range sortedColors(confirmedBalances, pendingBalances) {
+			fmt.Printf("%-44s %12d %12d\n", color, confirmedBalances[color], pendingBalances[color])
+		}
+
+		return nil
+	})
+}
+
+func sendCommand(args []string) error {
+	flags := flag.NewFlagSet("send", flag.ExitOnError)
+	amount := flags.Int64("amount", 0, "the amount of tokens to send")
+	colorString := flags.String("color", "IOTA", "the color of the tokens to send")
+	destinationString := flags.String("destination", "", "the address that receives the tokens")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *amount <= 0 {
+		return errors.New("the amount has to be positive")
+	}
+	color, err := balance.ColorFromBase58(*colorString)
+	if err != nil {
+		return fmt.Errorf("invalid color: %w", err)
+	}
+	if color == balance.ColorNew {
+		return errors.New("use the mint command to create new colors")
+	}
+	destination, err := address.FromBase58(*destinationString)
+	if err != nil {
+		return fmt.Errorf("invalid destination: %w", err)
+	}
+
+	return withRefreshedWallet(func(w *wallet.Wallet, walletFile *WalletFile) error {
+		return sendFunds(w, walletFile, destination, *amount, color)
+	})
+}
+
+func mintCommand(args []string) error {
+	flags := flag.NewFlagSet("mint", flag.ExitOnError)
+	amount := flags.Int64("amount", 0, "the amount of IOTA tokens to convert into the new color")
+	destinationString := flags.String("destination", "", "the address that receives the new tokens (default: the receive address of the wallet)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *amount <= 0 {
+		return errors.New("the amount has to be positive")
+	}
+
+	return withRefreshedWallet(func(w *wallet.Wallet, walletFile *WalletFile) error {
+		destination := w.ReceiveAddress()
+		if *destinationString != "" {
+			var err error
+			if destination, err = address.FromBase58(*destinationString); err != nil {
+				return fmt.Errorf("invalid destination: %w", err)
+			}
+		}
+
+		return sendFunds(w, walletFile, destination, *amount, balance.ColorNew)
+	})
+}
+
+func historyCommand(args []string) error {
+	if err := flag.NewFlagSet("history", flag.ExitOnError).Parse(args); err != nil {
+		return err
+	}
+
+	return withRefreshedWallet(func(_ *wallet.Wallet, walletFile *WalletFile) error {
+		if len(walletFile.History) == 0 {
+			fmt.Println("the wallet has no transfers yet")
+
+			return nil
+		}
+
+		fmt.Printf("%-9s %-44s %-44s %-44s %12s\n", "DIRECTION", "TRANSACTION", "ADDRESS", "COLOR", "AMOUNT")
+		for _, entry := range walletFile.History {
+			fmt.Printf("%-9s %-44s %-44s %-44s %12d\n", entry.Direction, entry.TransactionID, entry.Address, entry.Color, entry.Amount)
+		}
+
+		return nil
+	})
+}
+
+// sendFunds issues a transfer of the given amount and records it in the history of the wallet file.
+func sendFunds(w *wallet.Wallet, walletFile *WalletFile, destination address.Address, amount int64, color balance.Color) error {
+	tx, err := w.SendFunds(wallet.Destination(destination, amount, color))
+	if err != nil {
+		return err
+	}
+
+	direction := directionOutgoing
+	if color == balance.ColorNew {
+		direction = directionMint
+	}
+
+	walletFile.History = append(walletFile.History, &HistoryEntry{
+		Direction:     direction,
+		TransactionID: tx.ID().String(),
+		Address:       destination.String(),
+		Color:         color.String(),
+		Amount:        amount,
+	})
+
+	fmt.Printf("issued transaction %s\n", tx.ID())
+
+	return nil
+}
+
+// withWallet opens the wallet file, executes the given function and persists the updated state of the wallet.
+func withWallet(handler func(w *wallet.Wallet, walletFile *WalletFile) error) error {
+	walletFile, err := loadWalletFile(*walletFileName)
+	if err != nil {
+		return fmt.Errorf("unable to open wallet (use the init command to create one): %w", err)
+	}
+
+	password, err := readPassword(false)
+	if err != nil {
+		return err
+	}
+	seedBytes, err := walletFile.decryptSeed(password)
+	if err != nil {
+		return err
+	}
+
+	w := wallet.New(
+		wallet.Import(wallet.NewSeed(seedBytes), walletFile.LastAddressIndex),
+		wallet.GenericConnector(wallet.NewWebConnector(client.NewGoShimmerAPI(*nodeURL))),
+	)
+
+	handlerErr := handler(w, walletFile)
+
+	// persist the address index even if the command failed, so handed out addresses are never reused
+	walletFile.LastAddressIndex = w.LastAddressIndex()
+	if err := walletFile.save(*walletFileName); err != nil {
+		return err
+	}
+
+	return handlerErr
+}
+
+// withRefreshedWallet opens the wallet, scans its addresses for outputs and records new incoming transfers before it
+// executes the given function.
+func withRefreshedWallet(handler func(w *wallet.Wallet, walletFile *WalletFile) error) error {
+	return withWallet(func(w *wallet.Wallet, walletFile *WalletFile) error {
+		if err := w.Refresh(); err != nil {
+			return fmt.Errorf("unable to refresh the wallet: %w", err)
+		}
+		recordIncomingTransfers(w, walletFile)
+
+		return handler(w, walletFile)
+	})
+}
+
+// recordIncomingTransfers adds the outputs of transactions that are not known to the history as incoming transfers.
+// Outputs of our own transactions (i.e. the remainder) are not recorded.
+func recordIncomingTransfers(w *wallet.Wallet, walletFile *WalletFile) {
+	knownTransactions := make(map[string]bool)
+	for _, entry := range walletFile.History {
+		knownTransactions[entry.TransactionID] = true
+	}
+
+	outputs := w.Outputs()
+	sort.Slice(outputs, func(i, j int) bool {
+		return bytes.Compare(outputs[i].ID().Bytes(), outputs[j].ID().Bytes()) < 0
+	})
+	for _, output := range outputs {
+		if knownTransactions[output.TransactionID.String()] {
+			continue
+		}
+
+		for _, color := range sortedColors(output.Balances) {
+			walletFile.History = append(walletFile.History, &HistoryEntry{
+				Direction:     directionIncoming,
+				TransactionID: output.TransactionID.String(),
+				Address:       output.Address.String(),
+				Color:         color.String(),
+				Amount:        output.Balances[color],
+			})
+		}
+	}
+}
+
+// readPassword reads the password from the environment or prompts the user for it. New passwords have to be entered
+// twice.
+func readPassword(confirm bool) ([]byte, error) {
+	if password, exists := os.LookupEnv(passwordEnvironmentVariable); exists {
+		return []byte(password), nil
+	}
+
+	password, err := promptPassword("password: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(password) == 0 {
+		return nil, errors.New("the password must not be empty")
+	}
+
+	if confirm {
+		confirmation, err := promptPassword("repeat password: ")
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(password, confirmation) {
+			return nil, errors.New("the passwords do not match")
+		}
+	}
+
+	return password, nil
+}
+
+func promptPassword(prompt string) ([]byte, error) {
+	fmt.Print(prompt)
+	defer fmt.Println()
+
+	stdin := int(os.Stdin.Fd())
+	if terminal.IsTerminal(stdin) {
+		return terminal.ReadPassword(stdin)
+	}
+
+	// fall back to reading a line if the input is not a terminal (i.e. piped)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(strings.TrimRight(line, "\r\n")), nil
+}
+
+func sortedColors(coloredAmounts ...map[balance.Color]int64) (colors []balance.Color) {
+	seen := make(map[balance.Color]bool)
+	for _, amounts := range coloredAmounts {
+		for color := range amounts {
+			if !seen[color] {
+				seen[color] = true
+				colors = append(colors, color)
+			}
+		}
+	}
+
+	sort.Slice(colors, func(i, j int) bool {
+		return bytes.Compare(colors[i][:], colors[j][:]) < 0
+	})
+
+	return
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	nodeURL        = flag.StringP("node", "n", "http://127.0.0.1:8080", "the URL of the web API of the node that the wallet connects to")
+	walletFileName = flag.StringP("wallet", "w", "wallet.json", "the file that stores the encrypted seed and the state of the wallet")
+)
+
+// command represents a subcommand of the wallet. It receives the remaining command line arguments.
+type command struct {
+	description string
+	run         func(args []string) error
+}
+
+var commands = map[string]command{
+	"init":    {"create a new wallet with a random seed", initCommand},
+	"address": {"list the addresses of the wallet or generate a new one", addressCommand},
+	"balance": {"show the confirmed and pending balances per color", balanceCommand},
+	"send":    {"send funds to a destination address", sendCommand},
+	"mint":    {"create new colored tokens from IOTA tokens", mintCommand},
+	"history": {"show the incoming and outgoing transfers of the wallet", historyCommand},
+}
+
+func main() {
+	flag.CommandLine.SetInterspersed(false)
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		printUsage()
+		os.Exit(1)
+	}
+
+	cmd, exists := commands[flag.Arg(0)]
+	if !exists {
+		fmt.Printf("unknown command: %s\n\n", flag.Arg(0))
+		printUsage()
+		os.Exit(1)
+	}
+
+	if err := cmd.run(flag.Args()[1:]); err != nil {
+		fmt.Printf("%s failed: %s\n", flag.Arg(0), err)
+		os.Exit(1)
+	}
+}
+
+func printUsage() {
+	fmt.Printf("Usage: %s [global options] <command> [command options]\n\nCommands:\n", os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  %-9s %s\n", name, commands[name].description)
+	}
+
+	fmt.Printf("\nGlobal options:\n%s", flag.CommandLine.FlagUsages())
+}
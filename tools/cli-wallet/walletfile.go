@@ -0,0 +1,135 @@
+package main
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// walletFileVersion defines the version of the format of the wallet file.
+	walletFileVersion = 1
+
+	saltLength = 32
+	keyLength  = 32
+)
+
+// ErrWrongPassword is returned if the seed of the wallet file can not be decrypted with the given password.
+var ErrWrongPassword = errors.New("wrong password or corrupted wallet file")
+
+// WalletFile contains the persisted state of the wallet. The seed is encrypted with a key that is derived from the
+// password of the user.
+type WalletFile struct {
+	Version          int             `json:"version"`
+	Salt             []byte          `json:"salt"`
+	Nonce            []byte          `json:"nonce"`
+	EncryptedSeed    []byte          `json:"encryptedSeed"`
+	LastAddressIndex uint64          `json:"lastAddressIndex"`
+	History          []*HistoryEntry `json:"history"`
+}
+
+// HistoryEntry represents a single incoming or outgoing transfer of the wallet.
+type HistoryEntry struct {
+	Direction     string `json:"direction"`
+	TransactionID string `json:"transactionID"`
+	Address       string `json:"address"`
+	Color         string `json:"color"`
+	Amount        int64  `json:"amount"`
+}
+
+// loadWalletFile reads the wallet file from disk.
+func loadWalletFile(fileName string) (walletFile *WalletFile, err error) {
+	fileBytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return
+	}
+
+	walletFile = &WalletFile{}
+	if err = json.Unmarshal(fileBytes, walletFile); err != nil {
+		return
+	}
+	if walletFile.Version != walletFileVersion {
+		err = fmt.Errorf("unsupported wallet file version %d", walletFile.Version)
+	}
+
+	return
+}
+
+// save writes the wallet file to disk. The file is only readable by the current user.
+func (walletFile *WalletFile) save(fileName string) error {
+	fileBytes, err := json.MarshalIndent(walletFile, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fileName, fileBytes, 0600)
+}
+
+// encryptSeed encrypts the given seed with a key derived from the password and stores it in the wallet file.
+func (walletFile *WalletFile) encryptSeed(seedBytes []byte, password []byte) (err error) {
+	salt := make([]byte, saltLength)
+	if _, err = rand.Read(salt); err != nil {
+		return
+	}
+
+	aead, err := newAEAD(password, salt)
+	if err != nil {
+		return
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return
+	}
+
+	walletFile.Version = walletFileVersion
+	walletFile.Salt = salt
+	walletFile.Nonce = nonce
+	walletFile.EncryptedSeed = aead.Seal(nil, nonce, seedBytes, nil)
+
+	return
+}
+
+// decryptSeed decrypts the seed of the wallet file with a key derived from the password.
+func (walletFile *WalletFile) decryptSeed(password []byte) (seedBytes []byte, err error) {
+	aead, err := newAEAD(password, walletFile.Salt)
+	if err != nil {
+		return
+	}
+	if len(walletFile.Nonce) != aead.NonceSize() {
+		return nil, ErrWrongPassword
+	}
+
+	if seedBytes, err = aead.Open(nil, walletFile.Nonce, walletFile.EncryptedSeed, nil); err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	return
+}
+
+func newAEAD(password []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(password, salt, 1<<15, 8, 1, keyLength)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func fileExists(fileName string) bool {
+	_, err := os.Stat(fileName)
+
+	return err == nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/address"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/balance"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/tangle"
+	"github.com/iotaledger/goshimmer/dapps/valuetransfers/packages/transaction"
+	flag "github.com/spf13/pflag"
+)
+
+var (
+	inputFile  = flag.StringP("input", "i", "genesis.json", "the JSON file containing the list of address/color/amount entries")
+	outputFile = flag.StringP("output", "o", "snapshot.bin", "the file to write the binary snapshot to")
+)
+
+// Entry represents a single funded address of the genesis.
+type Entry struct {
+	Address string `json:"address"`
+	Color   string `json:"color"`
+	Amount  int64  `json:"amount"`
+}
+
+func main() {
+	flag.Parse()
+
+	entries, err := readEntries(*inputFile)
+	if err != nil {
+		fmt.Printf("unable to read entries: %s\n", err)
+		os.Exit(1)
+	}
+
+	snapshot, err := buildSnapshot(entries)
+	if err != nil {
+		fmt.Printf("unable to build snapshot: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*outputFile, snapshot.Bytes(), 0644); err != nil {
+		fmt.Printf("unable to write snapshot: %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("wrote snapshot with %d addresses to %s\n", len(snapshot[transaction.GenesisID]), *outputFile)
+}
+
+func readEntries(fileName string) (entries []Entry, err error) {
+	entriesBytes, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(entriesBytes, &entries)
+
+	return
+}
+
+// buildSnapshot creates a snapshot where all entries are outputs of the genesis transaction. Entries with the same
+// address and color are summed up.
+func buildSnapshot(entries []Entry) (tangle.Snapshot, error) {
+	coloredAmounts := make(map[address.Address]map[balance.Color]int64)
+	for i, entry := range entries {
+		outputAddress, err := address.FromBase58(entry.Address)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address in entry %d: %w", i, err)
+		}
+
+		color, err := balance.ColorFromBase58(entry.Color)
+		if err != nil {
+			return nil, fmt.Errorf("invalid color in entry %d: %w", i, err)
+		}
+		if color == balance.ColorNew {
+			return nil, fmt.Errorf("invalid color in entry %d: the genesis can not mint new colors", i)
+		}
+
+		if entry.Amount <= 0 {
+			return nil, fmt.Errorf("invalid amount in entry %d: %d", i, entry.Amount)
+		}
+
+		if _, exists := coloredAmounts[outputAddress]; !exists {
+			coloredAmounts[outputAddress] = make(map[balance.Color]int64)
+		}
+		coloredAmounts[outputAddress][color] += entry.Amount
+	}
+
+	genesisOutputs := make(map[address.Address][]*balance.Balance)
+	for outputAddress, amounts := range coloredAmounts {
+		// add the balances in a fixed order, so the same entries always result in the same snapshot bytes
+		for _, color := range sortedColors(amounts) {
+			genesisOutputs[outputAddress] = append(genesisOutputs[outputAddress], balance.New(color, amounts[color]))
+		}
+	}
+
+	return tangle.Snapshot{
+		transaction.GenesisID: genesisOutputs,
+	}, nil
+}
+
+func sortedColors(coloredAmounts map[balance.Color]int64) (colors []balance.Color) {
+	colors = make([]balance.Color, 0, len(coloredAmounts))
+	for color := range coloredAmounts {
+		colors = append(colors, color)
+	}
+
+	sort.Slice(colors, func(i, j int) bool {
+		return bytes.Compare(colors[i][:], colors[j][:]) < 0
+	})
+
+	return
+}